@@ -8,15 +8,16 @@ import (
 	"net/http"
 
 	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/instrumentation"
 	"github.com/developertom01/klaviyo-go/models"
 )
 
 type (
 	AccountsApi interface {
 		//Retrieve the account(s) associated with a given private API key. This will return 1 account object within the array.
-		GetAccounts(ctx context.Context, accountFields []models.AccountsField) (*models.AccountsCollectionResponse, error)
+		GetAccounts(ctx context.Context, accountFields models.Fields[models.AccountsField]) (*models.AccountsCollectionResponse, error)
 		//Retrieve a single account object by its account ID. You can only request the account by which the private API key was generated.
-		GetAccount(ctx context.Context, id string, accountFields []models.AccountsField) (*models.AccountResponse, error)
+		GetAccount(ctx context.Context, id string, accountFields models.Fields[models.AccountsField]) (*models.AccountResponse, error)
 	}
 
 	accountApi struct {
@@ -28,24 +29,19 @@ type (
 )
 
 func NewAccountsApi(session common.Session, httpClient common.HTTPClient) AccountsApi {
-	var client common.HTTPClient
-	if httpClient == nil {
-		client = http.DefaultClient
-	} else {
-		client = httpClient
-	}
+	opt := session.GetOptions()
 	return &accountApi{
 		session:    session,
-		baseApiUrl: common.BASE_URL,
-		revision:   common.API_REVISION,
-		httpClient: client,
+		baseApiUrl: common.ResolveBaseUrl(opt),
+		revision:   common.ResolveRevision(opt),
+		httpClient: common.ResolveHTTPClient(opt, httpClient),
 	}
 }
 
-func (api *accountApi) getAccountsInternal(ctx context.Context, accountFields []models.AccountsField) (*models.AccountsCollectionResponse, error) {
+func (api *accountApi) getAccountsInternal(ctx context.Context, accountFields models.Fields[models.AccountsField]) (*models.AccountsCollectionResponse, error) {
 
-	var fieldsParam = models.BuildAccountFieldsParam(accountFields)
-	url := fmt.Sprintf("%s/api/accounts/?%s", api.baseApiUrl, fieldsParam)
+	query := common.NewQuery().Apply(accountFields)
+	url := query.URL(fmt.Sprintf("%s/api/accounts/", api.baseApiUrl))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -65,14 +61,17 @@ func (api *accountApi) getAccountsInternal(ctx context.Context, accountFields []
 	return &accountResp, nil
 }
 
-func (api *accountApi) GetAccounts(ctx context.Context, accountFields []models.AccountsField) (*models.AccountsCollectionResponse, error) {
+func (api *accountApi) GetAccounts(ctx context.Context, accountFields models.Fields[models.AccountsField]) (*models.AccountsCollectionResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "AccountsApi.GetAccounts")
+
 	return api.getAccountsInternal(ctx, accountFields)
 }
 
-func (api *accountApi) GetAccount(ctx context.Context, id string, accountFields []models.AccountsField) (*models.AccountResponse, error) {
+func (api *accountApi) GetAccount(ctx context.Context, id string, accountFields models.Fields[models.AccountsField]) (*models.AccountResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "AccountsApi.GetAccount")
 
-	var fieldsParam = models.BuildAccountFieldsParam(accountFields)
-	url := fmt.Sprintf("%s/api/accounts/%s/?%s", api.baseApiUrl, id, fieldsParam)
+	query := common.NewQuery().Apply(accountFields)
+	url := query.URL(fmt.Sprintf("%s/api/accounts/%s/", api.baseApiUrl, id))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
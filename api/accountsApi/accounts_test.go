@@ -47,6 +47,7 @@ func (suit *AccountsApiTestSuite) TestGetAccountsBadRequest() {
 	_, err = suit.api.GetAccounts(context.Background(), nil)
 
 	suit.ErrorAs(err, &exceptions.ErrorResponse{}, nil)
+	suit.ErrorIs(err, exceptions.ErrValidation)
 }
 
 func (suit *AccountsApiTestSuite) TestGetAccountsServerError() {
@@ -60,6 +61,7 @@ func (suit *AccountsApiTestSuite) TestGetAccountsServerError() {
 	_, err = suit.api.GetAccounts(context.Background(), nil)
 
 	suit.ErrorAs(err, &exceptions.ErrorResponse{}, nil)
+	suit.ErrorAs(err, &exceptions.ServerError{})
 }
 
 func (suit *AccountsApiTestSuite) TestGetAccountsOkResponse() {
@@ -120,6 +122,23 @@ func (suit *AccountsApiTestSuite) TestGetAccountOkResponse() {
 	suit.Equal(mockedAccount.Data.ID, accountResp.Data.ID)
 }
 
+func (suit *AccountsApiTestSuite) TestGetAccountsUsesConfiguredBaseUrlAndRevision() {
+	opt := options.NewOptions().WithApiKey("test-key").WithBaseUrl("http://localhost:8080").WithRevision("2024-07-15")
+	api := NewAccountsApi(common.NewApiKeySession(opt, nil), suit.mockedClient)
+
+	err := common.PrepareMockResponse(http.StatusOK, mockedAccountsCollectionResponse(1), suit.mockedClient)
+	if err != nil {
+		suit.T().Fatal(err)
+	}
+
+	_, err = api.GetAccounts(context.Background(), nil)
+	suit.Nil(err)
+
+	req := suit.mockedClient.Calls[0].Arguments.Get(0).(*http.Request)
+	suit.Equal("localhost:8080", req.URL.Host)
+	suit.Equal("2024-07-15", req.Header.Get("revision"))
+}
+
 func TestAccountsApiTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountsApiTestSuite))
 }
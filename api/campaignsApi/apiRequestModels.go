@@ -68,9 +68,9 @@ type (
 	}
 
 	CreateCampaignMessagesDataAttributes struct {
-		Channel       string                `json:"channel"`                  //The channel the message is to be sent on (email or sms, for example)
+		Channel       models.Channel        `json:"channel"`                  //The channel the message is to be sent on (email or sms, for example)
 		Label         *string               `json:"label,omitempty"`          //The label or name on the message
-		Content       *MessageContent       `json:"content,omitempty"`        //Additional attributes relating to the content of the message
+		Content       models.MessageContent `json:"content,omitempty"`        //Additional attributes relating to the content of the message, models.EmailContent or models.SMSContent matching the channel
 		RenderOptions *MessageRenderOptions `json:"render_options,omitempty"` //Additional options for rendering the message
 	}
 
@@ -90,7 +90,7 @@ type (
 		Method           models.SendStrategyMethod `json:"method"`                      //Describes the shape of the options object. Allowed values: ['static', 'throttled', 'immediate', 'smart_send_time']
 		OptionsStatic    *OptionsStatic            `json:"options_static,omitempty"`    //The send configuration options the campaign will send with. These define variables that alter the send strategy and must match the given method. Intended to be used with the 'static' method.
 		OptionsThrottled *OptionsThrottled         `json:"options_throttled,omitempty"` //The send configuration options the campaign will send with. These define variables that alter the send strategy and must match the given method. Intended to be used with the 'throttled' method.
-		OptionsSto       OptionsSto                `json:"options_sto"`
+		OptionsSto       *OptionsSto               `json:"options_sto,omitempty"`       //The send configuration options the campaign will send with. These define variables that alter the send strategy and must match the given method. Intended to be used with the 'smart_send_time' method.
 	}
 
 	OptionsStatic struct {
@@ -143,87 +143,11 @@ type (
 
 	UpdateCampaignMessageAttributes struct {
 		Label         *string               `json:"label,omitempty"`          //The label or name on the message
-		Content       *MessageContent       `json:"content,omitempty"`        //Additional attributes relating to the content of the message
+		Content       models.MessageContent `json:"content,omitempty"`        //Additional attributes relating to the content of the message, models.EmailContent or models.SMSContent matching the channel
 		RenderOptions *MessageRenderOptions `json:"render_options,omitempty"` //Additional options for rendering the message
 	}
 )
 
-// -------- Message content UNION type
-
-type (
-	//Generic map object that exports IsSMSContent and IsEmailContent
-	MessageContent map[string]any
-
-	MessageEmailContent struct {
-		Subject      *string `json:"subject,omitempty"`        //The subject of the message
-		PreviewText  *string `json:"preview_text,omitempty"`   //Preview text associated with the message
-		FromEmail    *string `json:"from_email,omitempty"`     //The email the message should be sent from
-		FromLabel    *string `json:"from_label,omitempty"`     //The label associated with the from_email
-		ReplyToEmail *string `json:"reply_to_email,omitempty"` //Optional Reply-To email address
-		CcEmail      *string `json:"cc_email,omitempty"`       //Optional CC email address
-
-	}
-
-	MessageSMSContent struct {
-		Body *string `json:"body,omitempty"` //The message body
-	}
-)
-
-// Converts data from `MessageEmailContent` or `MessageSMSContent` to `MessageContent`
-func toMessageContent[T MessageEmailContent | MessageSMSContent](data T) (*MessageContent, error) {
-	dataByte, err := json.Marshal(data)
-	if err != nil {
-		return nil, err
-	}
-
-	var messageContent MessageContent
-	err = json.Unmarshal(dataByte, &messageContent)
-
-	return &messageContent, err
-}
-
-// Converts to `MessageContent` type
-func (mec MessageEmailContent) ToMessageContent() (*MessageContent, error) {
-	return toMessageContent(mec)
-}
-
-// Converts to `MessageContent` type
-func (msc MessageSMSContent) ToMessageContent() (*MessageContent, error) {
-	return toMessageContent(msc)
-}
-
-// Returns MessageEmailContent and true if MessageContent is email
-func (mc MessageContent) IsEmailContent() (*MessageEmailContent, bool) {
-	byteData, err := json.Marshal(mc)
-	if err != nil {
-		return nil, false
-	}
-
-	var messageEmailContent MessageEmailContent
-	err = json.Unmarshal(byteData, &messageEmailContent)
-	if err != nil {
-		return nil, false
-	}
-
-	return &messageEmailContent, true
-}
-
-// Returns MessageSMSContent and true if MessageContent is sms
-func (mc MessageContent) IsSMSContent() (*MessageSMSContent, bool) {
-	byteData, err := json.Marshal(mc)
-	if err != nil {
-		return nil, false
-	}
-
-	var msgSMSContent MessageSMSContent
-	err = json.Unmarshal(byteData, &msgSMSContent)
-	if err != nil {
-		return nil, false
-	}
-
-	return &msgSMSContent, true
-}
-
 // ----- AssignCampaignMessageTemplate payloads
 
 type (
@@ -270,3 +194,54 @@ type CampaignSendCreationJobPayload struct {
 	Type string `json:"type"` // campaign-send-job
 	ID   string `json:"id"`   //The ID of the campaign to send
 }
+
+// Returns models.ErrContentChannelMismatch when the content is not of the message channel
+func (a CreateCampaignMessagesDataAttributes) MarshalJSON() ([]byte, error) {
+	if err := models.ValidateMessageContent(a.Channel, a.Content); err != nil {
+		return nil, err
+	}
+
+	type attributes CreateCampaignMessagesDataAttributes
+	return json.Marshal(attributes(a))
+}
+
+func (a *CreateCampaignMessagesDataAttributes) UnmarshalJSON(data []byte) error {
+	type attributes CreateCampaignMessagesDataAttributes
+	var raw struct {
+		attributes
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	content, err := models.UnmarshalMessageContent(raw.Channel, raw.Content)
+	if err != nil {
+		return err
+	}
+
+	*a = CreateCampaignMessagesDataAttributes(raw.attributes)
+	a.Content = content
+	return nil
+}
+
+// Decodes the content by its keys, as the channel of the message is not part of the payload
+func (a *UpdateCampaignMessageAttributes) UnmarshalJSON(data []byte) error {
+	type attributes UpdateCampaignMessageAttributes
+	var raw struct {
+		attributes
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	content, err := models.UnmarshalMessageContent("", raw.Content)
+	if err != nil {
+		return err
+	}
+
+	*a = UpdateCampaignMessageAttributes(raw.attributes)
+	a.Content = content
+	return nil
+}
@@ -0,0 +1,265 @@
+package campaigns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Percentages of recipients per hour a throttled campaign can send to
+var throttlePercentages = []int64{10, 11, 13, 14, 17, 20, 25, 33, 50}
+
+// Matched with errors.Is by the errors of CampaignBuilder.Build and CampaignDataAttributeSendStrategy.Validate
+var ErrInvalidCampaign = errors.New("Invalid campaign")
+
+// Invalid field of a campaign payload
+type CampaignValidationError struct {
+	Field string //JSON pointer of the field, eg. /data/attributes/audiences/included
+	Err   error
+}
+
+func (e CampaignValidationError) Error() string {
+	return fmt.Sprintf("campaigns: %s: %s", e.Field, e.Err)
+}
+
+func (e CampaignValidationError) Unwrap() error {
+	return e.Err
+}
+
+func (e CampaignValidationError) Is(target error) bool {
+	return target == ErrInvalidCampaign
+}
+
+// Builds a CreateCampaignRequestData, checking that the send strategy method matches its options, that at least one
+// audience is included, and that the campaign has one message per channel. Invalid fields are returned together by Build
+type CampaignBuilder struct {
+	attributes CreateCampaignDataDataAttributes
+}
+
+// Returns a builder of campaign `name`, sent immediately unless another send strategy is set
+func NewCampaignBuilder(name string) *CampaignBuilder {
+	return &CampaignBuilder{
+		attributes: CreateCampaignDataDataAttributes{
+			Name:      name,
+			Audiences: CampaignDataAttributesAudiences{Included: []string{}, Excluded: []string{}},
+		},
+	}
+}
+
+// Adds lists or segments the campaign is sent to
+func (b *CampaignBuilder) IncludeAudiences(ids ...string) *CampaignBuilder {
+	b.attributes.Audiences.Included = append(b.attributes.Audiences.Included, ids...)
+	return b
+}
+
+// Adds lists or segments the campaign is not sent to
+func (b *CampaignBuilder) ExcludeAudiences(ids ...string) *CampaignBuilder {
+	b.attributes.Audiences.Excluded = append(b.attributes.Audiences.Excluded, ids...)
+	return b
+}
+
+func (b *CampaignBuilder) SendImmediately() *CampaignBuilder {
+	return b.WithSendStrategy(CampaignDataAttributeSendStrategy{Method: models.SendStrategyMethodImmediate})
+}
+
+// Sends at `datetime`, or at that time in the timezone of each recipient when `isLocal` is true
+func (b *CampaignBuilder) SendAt(datetime time.Time, isLocal bool) *CampaignBuilder {
+	return b.WithSendStrategy(CampaignDataAttributeSendStrategy{
+		Method: models.SendStrategyMethodStatic,
+		OptionsStatic: &OptionsStatic{
+			Datetime: datetime.UTC().Format(time.RFC3339),
+			IsLocal:  &isLocal,
+		},
+	})
+}
+
+// Sends from `datetime` to `percentage` of the recipients per hour, one of 10, 11, 13, 14, 17, 20, 25, 33 or 50
+func (b *CampaignBuilder) SendThrottled(datetime time.Time, percentage int64) *CampaignBuilder {
+	return b.WithSendStrategy(CampaignDataAttributeSendStrategy{
+		Method: models.SendStrategyMethodThrottled,
+		OptionsThrottled: &OptionsThrottled{
+			Datetime:           datetime,
+			ThrottlePercentage: &percentage,
+		},
+	})
+}
+
+// Sends on `date` at the best time for each recipient
+func (b *CampaignBuilder) SendAtSmartSendTime(date time.Time) *CampaignBuilder {
+	return b.WithSendStrategy(CampaignDataAttributeSendStrategy{
+		Method:     models.SendStrategyMethodSmartSendTime,
+		OptionsSto: &OptionsSto{Date: date},
+	})
+}
+
+// Sets the send strategy as is. It is validated by Build
+func (b *CampaignBuilder) WithSendStrategy(strategy CampaignDataAttributeSendStrategy) *CampaignBuilder {
+	b.attributes.SendStrategy = &strategy
+	return b
+}
+
+func (b *CampaignBuilder) WithSmartSending(useSmartSending bool) *CampaignBuilder {
+	b.attributes.SendOptions = &SendOptions{UseSmartSending: &useSmartSending}
+	return b
+}
+
+func (b *CampaignBuilder) WithTrackingOptions(options TrackingOptions) *CampaignBuilder {
+	b.attributes.TrackingOptions = &options
+	return b
+}
+
+// Adds an email message with `content`
+func (b *CampaignBuilder) EmailMessage(label string, content models.EmailContent) *CampaignBuilder {
+	return b.Message(CreateCampaignMessagesDataAttributes{Channel: models.ChannelEmail, Label: &label, Content: content})
+}
+
+// Adds an sms message with `content`
+func (b *CampaignBuilder) SMSMessage(label string, content models.SMSContent) *CampaignBuilder {
+	return b.Message(CreateCampaignMessagesDataAttributes{Channel: models.ChannelSms, Label: &label, Content: content})
+}
+
+// Adds a message. Its content, when set, must be of its channel
+func (b *CampaignBuilder) Message(message CreateCampaignMessagesDataAttributes) *CampaignBuilder {
+	b.attributes.CampaignMessages.Data = append(b.attributes.CampaignMessages.Data, CampaignAttributesMessagesData{
+		Type:       "campaign-message",
+		Attributes: message,
+	})
+	return b
+}
+
+// Returns the payload, or the CampaignValidationError of every invalid field joined
+func (b *CampaignBuilder) Build() (CreateCampaignRequestData, error) {
+	var errs []error
+	fail := func(field string, err error) {
+		errs = append(errs, CampaignValidationError{Field: "/data/attributes/" + field, Err: err})
+	}
+
+	attributes := b.attributes
+	if attributes.Name == "" {
+		fail("name", errors.New("is required"))
+	}
+	if len(attributes.Audiences.Included) == 0 {
+		fail("audiences/included", errors.New("at least one audience must be included"))
+	}
+	for _, id := range attributes.Audiences.Excluded {
+		if slices.Contains(attributes.Audiences.Included, id) {
+			fail("audiences/excluded", fmt.Errorf("audience %s is both included and excluded", id))
+		}
+	}
+
+	if attributes.SendStrategy != nil {
+		errs = append(errs, sendStrategyErrors(*attributes.SendStrategy, "/data/attributes/send_strategy")...)
+	}
+
+	messages := attributes.CampaignMessages.Data
+	if len(messages) == 0 {
+		fail("campaign-messages/data", errors.New("at least one message is required"))
+	}
+	channels := map[models.Channel]int{}
+	for i, message := range messages {
+		field := fmt.Sprintf("campaign-messages/data/%d", i)
+		channel := message.Attributes.Channel
+
+		switch first, ok := channels[channel]; {
+		case channel == "":
+			fail(field+"/attributes/channel", errors.New("is required"))
+		case ok:
+			fail(field+"/attributes/channel", fmt.Errorf("duplicates the %s message %d, a campaign has one message per channel", channel, first))
+		default:
+			channels[channel] = i
+		}
+
+		if err := models.ValidateMessageContent(channel, message.Attributes.Content); err != nil {
+			fail(field+"/attributes/content", err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return CreateCampaignRequestData{}, errors.Join(errs...)
+	}
+
+	// The payload does not share lists with the builder, which may go on adding to them
+	attributes.Audiences.Included = slices.Clone(attributes.Audiences.Included)
+	attributes.Audiences.Excluded = slices.Clone(attributes.Audiences.Excluded)
+	attributes.CampaignMessages.Data = slices.Clone(attributes.CampaignMessages.Data)
+
+	return CreateCampaignRequestData{
+		Data: CreateCampaignData{
+			Type:       "campaign",
+			Attributes: attributes,
+		},
+	}, nil
+}
+
+// Builds the campaign and creates it. Returns the errors of Build without calling the API when it is invalid
+func (b *CampaignBuilder) Create(ctx context.Context, api CampaignsApi) (*models.CampaignResponse, error) {
+	data, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return api.CreateCampaign(ctx, data)
+}
+
+// Returns the CampaignValidationError of every option not matching the method joined, nil when the strategy is valid
+func (s CampaignDataAttributeSendStrategy) Validate() error {
+	return errors.Join(sendStrategyErrors(s, "/send_strategy")...)
+}
+
+// Returns the errors of send strategy `s` at JSON pointer `field`
+func sendStrategyErrors(s CampaignDataAttributeSendStrategy, field string) []error {
+	var errs []error
+	fail := func(name string, err error) {
+		errs = append(errs, CampaignValidationError{Field: field + "/" + name, Err: err})
+	}
+
+	switch s.Method {
+	case models.SendStrategyMethodStatic, models.SendStrategyMethodThrottled, models.SendStrategyMethodSmartSendTime, models.SendStrategyMethodImmediate:
+	default:
+		fail("method", fmt.Errorf("%q is not one of static, throttled, immediate or smart_send_time", s.Method))
+		return errs
+	}
+
+	// Options of each method, which are required by it and not allowed with any other
+	options := []struct {
+		method models.SendStrategyMethod
+		name   string
+		set    bool
+	}{
+		{models.SendStrategyMethodStatic, "options_static", s.OptionsStatic != nil},
+		{models.SendStrategyMethodThrottled, "options_throttled", s.OptionsThrottled != nil},
+		{models.SendStrategyMethodSmartSendTime, "options_sto", s.OptionsSto != nil},
+	}
+	for _, option := range options {
+		switch {
+		case option.method == s.Method && !option.set:
+			fail(option.name, fmt.Errorf("is required by the %s method", s.Method))
+		case option.method != s.Method && option.set:
+			fail(option.name, fmt.Errorf("is only used by the %s method", option.method))
+		}
+	}
+
+	if s.Method == models.SendStrategyMethodStatic && s.OptionsStatic != nil && s.OptionsStatic.Datetime == "" {
+		fail("options_static/datetime", errors.New("is required"))
+	}
+	if s.Method == models.SendStrategyMethodThrottled && s.OptionsThrottled != nil {
+		if s.OptionsThrottled.Datetime.IsZero() {
+			fail("options_throttled/datetime", errors.New("is required"))
+		}
+		switch percentage := s.OptionsThrottled.ThrottlePercentage; {
+		case percentage == nil:
+			fail("options_throttled/throttle_percentage", errors.New("is required"))
+		case !slices.Contains(throttlePercentages, *percentage):
+			fail("options_throttled/throttle_percentage", fmt.Errorf("%d is not one of %v", *percentage, throttlePercentages))
+		}
+	}
+	if s.Method == models.SendStrategyMethodSmartSendTime && s.OptionsSto != nil && s.OptionsSto.Date.IsZero() {
+		fail("options_sto/date", errors.New("is required"))
+	}
+
+	return errs
+}
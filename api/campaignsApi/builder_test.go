@@ -0,0 +1,130 @@
+package campaigns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/developertom01/klaviyo-go/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Returns the fields of the CampaignValidationError values joined in `err`
+func invalidFields(t *testing.T, err error) []string {
+	require.ErrorIs(t, err, ErrInvalidCampaign)
+
+	var fields []string
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var validationErr CampaignValidationError
+		require.ErrorAs(t, err, &validationErr)
+		fields = append(fields, validationErr.Field)
+	}
+	return fields
+}
+
+func TestCampaignBuilder(t *testing.T) {
+	subject := "Spring sale"
+	sendAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	data, err := NewCampaignBuilder("Spring").
+		IncludeAudiences("LIST01").
+		ExcludeAudiences("LIST02").
+		SendThrottled(sendAt, 25).
+		EmailMessage("Email", models.EmailContent{Subject: &subject}).
+		Build()
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data": {"type": "campaign", "attributes": {
+		"name": "Spring",
+		"audiences": {"included": ["LIST01"], "excluded": ["LIST02"]},
+		"send_strategy": {"method": "throttled", "options_throttled": {"datetime": "2024-03-01T09:00:00Z", "throttle_percentage": 25}},
+		"campaign-messages": {"data": [{"type": "campaign-message", "attributes": {"channel": "email", "label": "Email", "content": {"subject": "Spring sale"}}}]}
+	}}}`, string(payload))
+}
+
+func TestCampaignBuilderSendStrategies(t *testing.T) {
+	sendAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	builder := func() *CampaignBuilder {
+		return NewCampaignBuilder("Spring").IncludeAudiences("LIST01").SMSMessage("SMS", models.SMSContent{})
+	}
+
+	for name, b := range map[string]*CampaignBuilder{
+		"immediate":       builder().SendImmediately(),
+		"static":          builder().SendAt(sendAt, true),
+		"smart send time": builder().SendAtSmartSendTime(sendAt),
+	} {
+		data, err := b.Build()
+		if assert.NoError(t, err, name) {
+			payload, err := json.Marshal(data.Data.Attributes.SendStrategy)
+			require.NoError(t, err)
+			assert.Equal(t, name == "smart send time", containsKey(payload, "options_sto"), name)
+		}
+	}
+
+	_, err := builder().SendThrottled(sendAt, 30).Build()
+	assert.Equal(t, []string{"/data/attributes/send_strategy/options_throttled/throttle_percentage"}, invalidFields(t, err))
+
+	_, err = builder().WithSendStrategy(CampaignDataAttributeSendStrategy{
+		Method:     models.SendStrategyMethodStatic,
+		OptionsSto: &OptionsSto{Date: sendAt},
+	}).Build()
+	assert.Equal(t, []string{
+		"/data/attributes/send_strategy/options_static",
+		"/data/attributes/send_strategy/options_sto",
+	}, invalidFields(t, err))
+
+	_, err = builder().WithSendStrategy(CampaignDataAttributeSendStrategy{Method: "later"}).Build()
+	assert.Equal(t, []string{"/data/attributes/send_strategy/method"}, invalidFields(t, err))
+}
+
+func TestCampaignBuilderAggregatesErrors(t *testing.T) {
+	opt := options.NewOptionsWithDefaultValues().WithApiKey("test-key")
+	client := common.NewMockHTTPClient()
+	api := NewCampaignsApi(common.NewApiKeySession(opt, common.NewRetryOptionsWithDefaultValues()), client)
+
+	_, err := NewCampaignBuilder("").
+		IncludeAudiences("LIST01").
+		ExcludeAudiences("LIST01").
+		EmailMessage("Email", models.EmailContent{}).
+		Message(CreateCampaignMessagesDataAttributes{Channel: models.ChannelEmail}).
+		Message(CreateCampaignMessagesDataAttributes{Channel: models.ChannelSms, Content: models.EmailContent{}}).
+		Create(context.Background(), api)
+
+	assert.Equal(t, []string{
+		"/data/attributes/name",
+		"/data/attributes/audiences/excluded",
+		"/data/attributes/campaign-messages/data/1/attributes/channel",
+		"/data/attributes/campaign-messages/data/2/attributes/content",
+	}, invalidFields(t, err))
+	client.AssertNotCalled(t, "Do", mock.Anything)
+
+	_, err = NewCampaignBuilder("Spring").Build()
+	assert.Equal(t, []string{"/data/attributes/audiences/included", "/data/attributes/campaign-messages/data"}, invalidFields(t, err))
+}
+
+func TestSendStrategyValidate(t *testing.T) {
+	percentage := int64(50)
+	strategy := CampaignDataAttributeSendStrategy{
+		Method:           models.SendStrategyMethodThrottled,
+		OptionsThrottled: &OptionsThrottled{Datetime: time.Now(), ThrottlePercentage: &percentage},
+	}
+	assert.NoError(t, strategy.Validate())
+
+	strategy.Method = models.SendStrategyMethodImmediate
+	err := strategy.Validate()
+	assert.True(t, errors.Is(err, ErrInvalidCampaign))
+	assert.ErrorContains(t, err, "/send_strategy/options_throttled: is only used by the throttled method")
+}
+
+func containsKey(payload []byte, key string) bool {
+	var object map[string]any
+	return json.Unmarshal(payload, &object) == nil && object[key] != nil
+}
@@ -6,9 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/instrumentation"
 	"github.com/developertom01/klaviyo-go/models"
 )
 
@@ -16,8 +16,9 @@ type (
 	CampaignsApi interface {
 		//Returns some or all campaigns based on filters.
 		//A channel filter is required to list campaigns. Please provide either:
-		//filterBuilder.Equal("messages.channel", "email") or filterBuilder.Equal("messages.channel", "sms") and then build the filter by 
-		//filterStr := filterBuilder.Build()
+		//filter.Equals("messages.channel", filter.String("email")) or filter.Equals("messages.channel", filter.String("sms")) and then build the filter by
+		//filterStr, err := filter.Build(expression)
+		//The filter is validated against GetCampaignsFilterSchema before sending
 		GetCampaigns(ctx context.Context, filter string, options *GetCampaignsOptions) (*models.CampaignsCollectionResponse, error)
 
 		//Creates a campaign given a set of parameters, then returns it.
@@ -34,18 +35,18 @@ type (
 
 		//Get the estimated recipient count for a campaign with the provided campaign ID.
 		//You can refresh this count by using the Create Campaign Recipient Estimation Job endpoint.
-		GetCampaignRecipientEstimation(ctx context.Context, id string, fields []models.CampaignRecipientEstimationField) (*models.CampaignRecipientCountResponse, error)
+		GetCampaignRecipientEstimation(ctx context.Context, id string, fields models.Fields[models.CampaignRecipientEstimationField]) (*models.CampaignRecipientCountResponse, error)
 
 		//Clones an existing campaign, returning a new campaign based on the original with a new ID and name.
 		CreateCampaignClone(ctx context.Context, data CreateCampaignCloneRequestData) (*models.CampaignResponse, error)
 
 		//Return the related campaign
-		GetCampaignMessageCampaign(ctx context.Context, messageId string, campaignFields []models.CampaignsField) (*models.CampaignResponse, error)
+		GetCampaignMessageCampaign(ctx context.Context, messageId string, campaignFields models.Fields[models.CampaignsField]) (*models.CampaignResponse, error)
 
 		//Return the related template for `messageId`
-		GetCampaignMessageTemplate(ctx context.Context, messageId string, templateFields []models.TemplateField) (*models.TemplateResponse, error)
+		GetCampaignMessageTemplate(ctx context.Context, messageId string, templateFields models.Fields[models.TemplateField]) (*models.TemplateResponse, error)
 		//Return all tags that belong to the given campaign.
-		GetCampaignTags(ctx context.Context, campaignId string, tagFields []models.TagField) (*models.TagsCollectionResponse, error)
+		GetCampaignTags(ctx context.Context, campaignId string, tagFields models.Fields[models.TagField]) (*models.TagsCollectionResponse, error)
 
 		//Return all messages that belong to the given campaign.
 		GetCampaignMessages(ctx context.Context, campaignId string, options *GetCampaignMessagesOptions) (*models.CampaignMessageCollectionResponse, error)
@@ -69,67 +70,51 @@ type (
 )
 
 func NewCampaignsApi(session common.Session, httpClient common.HTTPClient) CampaignsApi {
-	var client common.HTTPClient
-	if httpClient == nil {
-		client = http.DefaultClient
-	} else {
-		client = httpClient
-	}
+	opt := session.GetOptions()
 
 	return &campaignsApi{
 		session:    session,
-		httpClient: client,
-		baseApiUrl: common.BASE_URL,
-		revision:   common.API_REVISION,
+		httpClient: common.ResolveHTTPClient(opt, httpClient),
+		baseApiUrl: common.ResolveBaseUrl(opt),
+		revision:   common.ResolveRevision(opt),
 	}
 }
 
 type GetCampaignsOptions struct {
-	CampaignFields        []models.CampaignsField
-	CampaignMessageFields []models.CampaignMessageField
-	TagFields             []models.TagField
+	CampaignFields        models.Fields[models.CampaignsField]
+	CampaignMessageFields models.Fields[models.CampaignMessageField]
+	TagFields             models.Fields[models.TagField]
 	PageCursor            *string
-	Sort                  *models.CampaignSortField
+	Sort                  models.Sort[models.CampaignSortField]
 	Include               []models.CampaignIncludeField
 }
 
-func buildGetCampaignsParams(filter string, opt *GetCampaignsOptions) string {
+func buildGetCampaignsParams(filter string, opt *GetCampaignsOptions) *common.Query {
+	query := common.NewQuery().Filter(filter)
 	if opt == nil {
-		return ""
-	}
-
-	var params = []string{filter}
-
-	if opt.CampaignFields != nil {
-		params = append(params, models.BuildCampaignFieldsParam(opt.CampaignFields))
+		return query
 	}
 
-	if opt.CampaignMessageFields != nil {
-		params = append(params, models.BuildCampaignMessageFieldsParam(opt.CampaignMessageFields))
-	}
-
-	if opt.TagFields != nil {
-		params = append(params, models.BuildTagFieldParam(opt.TagFields))
-	}
-
-	if opt.Include != nil {
-		params = append(params, models.BuildCampaignIncludeFieldParam(opt.Include))
-	}
+	query.
+		Apply(opt.CampaignFields, opt.CampaignMessageFields, opt.TagFields, opt.Sort).
+		Include(common.StringsOf(opt.Include)...)
 
 	if opt.PageCursor != nil {
-		params = append(params, fmt.Sprintf("page[cursor]=%s", *opt.PageCursor))
-	}
-
-	if opt.Sort != nil {
-		params = append(params, fmt.Sprintf("sort=%s", *opt.Sort))
+		query.PageCursor(*opt.PageCursor)
 	}
 
-	return strings.Join(params, "&")
+	return query
 }
 
 func (api *campaignsApi) GetCampaigns(ctx context.Context, filter string, options *GetCampaignsOptions) (*models.CampaignsCollectionResponse, error) {
-	queryParams := buildGetCampaignsParams(filter, options)
-	url := fmt.Sprintf("%s/api/campaigns/?%s", api.baseApiUrl, queryParams)
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaigns")
+
+	if err := common.ValidateFilter(api.session, GetCampaignsFilterSchema, filter); err != nil {
+		return nil, err
+	}
+
+	query := buildGetCampaignsParams(filter, options)
+	url := query.URL(fmt.Sprintf("%s/api/campaigns/", api.baseApiUrl))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -148,6 +133,8 @@ func (api *campaignsApi) GetCampaigns(ctx context.Context, filter string, option
 }
 
 func (api *campaignsApi) CreateCampaign(ctx context.Context, data CreateCampaignRequestData) (*models.CampaignResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.CreateCampaign")
+
 	url := fmt.Sprintf("%s/api/campaigns/", api.baseApiUrl)
 
 	reqData, err := json.Marshal(data)
@@ -157,7 +144,7 @@ func (api *campaignsApi) CreateCampaign(ctx context.Context, data CreateCampaign
 
 	reqDataBuffer := bytes.NewBuffer(reqData)
 
-	req, err := http.NewRequest(http.MethodPost, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -174,8 +161,10 @@ func (api *campaignsApi) CreateCampaign(ctx context.Context, data CreateCampaign
 }
 
 func (api *campaignsApi) GetCampaign(ctx context.Context, id string, filter string, options *GetCampaignsOptions) (*models.CampaignResponse, error) {
-	queryParams := buildGetCampaignsParams(filter, options)
-	url := fmt.Sprintf("%s/api/campaigns/%s/?%s", api.baseApiUrl, id, queryParams)
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaign")
+
+	query := buildGetCampaignsParams(filter, options)
+	url := query.URL(fmt.Sprintf("%s/api/campaigns/%s/", api.baseApiUrl, id))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -194,6 +183,8 @@ func (api *campaignsApi) GetCampaign(ctx context.Context, id string, filter stri
 }
 
 func (api *campaignsApi) UpdateCampaigns(ctx context.Context, id string, data CreateCampaignRequestData) (*models.CampaignResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.UpdateCampaigns")
+
 	url := fmt.Sprintf("%s/api/campaigns/%s/", api.baseApiUrl, id)
 
 	reqData, err := json.Marshal(data)
@@ -203,7 +194,7 @@ func (api *campaignsApi) UpdateCampaigns(ctx context.Context, id string, data Cr
 
 	reqDataBuffer := bytes.NewBuffer(reqData)
 
-	req, err := http.NewRequest(http.MethodPatch, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -220,9 +211,11 @@ func (api *campaignsApi) UpdateCampaigns(ctx context.Context, id string, data Cr
 }
 
 func (api *campaignsApi) DeleteCampaigns(ctx context.Context, id string) error {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.DeleteCampaigns")
+
 	url := fmt.Sprintf("%s/api/campaigns/%s/", api.baseApiUrl, id)
 
-	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return err
 	}
@@ -231,11 +224,13 @@ func (api *campaignsApi) DeleteCampaigns(ctx context.Context, id string) error {
 	return err
 }
 
-func (api *campaignsApi) GetCampaignRecipientEstimation(ctx context.Context, id string, fields []models.CampaignRecipientEstimationField) (*models.CampaignRecipientCountResponse, error) {
-	var fieldsParam = models.BuildCampaignRecipientEstimateFieldParam(fields)
-	url := fmt.Sprintf("%s/api/campaign-recipient-estimations/%s/?%s", api.baseApiUrl, id, fieldsParam)
+func (api *campaignsApi) GetCampaignRecipientEstimation(ctx context.Context, id string, fields models.Fields[models.CampaignRecipientEstimationField]) (*models.CampaignRecipientCountResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaignRecipientEstimation")
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	query := common.NewQuery().Apply(fields)
+	url := query.URL(fmt.Sprintf("%s/api/campaign-recipient-estimations/%s/", api.baseApiUrl, id))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -252,6 +247,8 @@ func (api *campaignsApi) GetCampaignRecipientEstimation(ctx context.Context, id
 }
 
 func (api *campaignsApi) CreateCampaignClone(ctx context.Context, data CreateCampaignCloneRequestData) (*models.CampaignResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.CreateCampaignClone")
+
 	url := fmt.Sprintf("%s/api/campaign-clone/", api.baseApiUrl)
 
 	reqData, err := json.Marshal(data)
@@ -261,7 +258,7 @@ func (api *campaignsApi) CreateCampaignClone(ctx context.Context, data CreateCam
 
 	reqDataBuffer := bytes.NewBuffer(reqData)
 
-	req, err := http.NewRequest(http.MethodPost, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -277,10 +274,11 @@ func (api *campaignsApi) CreateCampaignClone(ctx context.Context, data CreateCam
 	return &resp, err
 }
 
-func (api *campaignsApi) GetCampaignMessageCampaign(ctx context.Context, messageId string, campaignFields []models.CampaignsField) (*models.CampaignResponse, error) {
+func (api *campaignsApi) GetCampaignMessageCampaign(ctx context.Context, messageId string, campaignFields models.Fields[models.CampaignsField]) (*models.CampaignResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaignMessageCampaign")
 
-	var params = models.BuildCampaignFieldsParam(campaignFields)
-	url := fmt.Sprintf("%s/api/campaign-messages/%s/campaign/?%s", api.baseApiUrl, messageId, params)
+	query := common.NewQuery().Apply(campaignFields)
+	url := query.URL(fmt.Sprintf("%s/api/campaign-messages/%s/campaign/", api.baseApiUrl, messageId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -298,9 +296,11 @@ func (api *campaignsApi) GetCampaignMessageCampaign(ctx context.Context, message
 	return &campaignResp, err
 }
 
-func (api *campaignsApi) GetCampaignMessageTemplate(ctx context.Context, messageId string, templateFields []models.TemplateField) (*models.TemplateResponse, error) {
-	var params = models.BuildTemplateFieldParam(templateFields)
-	url := fmt.Sprintf("%s/api/campaign-messages/%s/template/?%s", api.baseApiUrl, messageId, params)
+func (api *campaignsApi) GetCampaignMessageTemplate(ctx context.Context, messageId string, templateFields models.Fields[models.TemplateField]) (*models.TemplateResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaignMessageTemplate")
+
+	query := common.NewQuery().Apply(templateFields)
+	url := query.URL(fmt.Sprintf("%s/api/campaign-messages/%s/template/", api.baseApiUrl, messageId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -319,10 +319,11 @@ func (api *campaignsApi) GetCampaignMessageTemplate(ctx context.Context, message
 }
 
 // -- Untested
-func (api *campaignsApi) GetCampaignTags(ctx context.Context, campaignId string, tagFields []models.TagField) (*models.TagsCollectionResponse, error) {
-	var params = models.BuildTagFieldParam(tagFields)
+func (api *campaignsApi) GetCampaignTags(ctx context.Context, campaignId string, tagFields models.Fields[models.TagField]) (*models.TagsCollectionResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaignTags")
 
-	url := fmt.Sprintf("%s/api/campaigns/%s/tags/?%s", api.baseApiUrl, campaignId, params)
+	query := common.NewQuery().Apply(tagFields)
+	url := query.URL(fmt.Sprintf("%s/api/campaigns/%s/tags/", api.baseApiUrl, campaignId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -342,40 +343,28 @@ func (api *campaignsApi) GetCampaignTags(ctx context.Context, campaignId string,
 
 // Query parameters for GetCampaignMessages
 type GetCampaignMessagesOptions struct {
-	campaignMessageField []models.CampaignMessageField
-	campaignFields       []models.CampaignsField
-	templateFields       []models.TemplateField
+	campaignMessageField models.Fields[models.CampaignMessageField]
+	campaignFields       models.Fields[models.CampaignsField]
+	templateFields       models.Fields[models.TemplateField]
 	Include              []models.CampaignMessageIncludeField
 }
 
-func buildGetCampaignMessagesParams(opt *GetCampaignMessagesOptions) string {
-
+func buildGetCampaignMessagesParams(opt *GetCampaignMessagesOptions) *common.Query {
+	query := common.NewQuery()
 	if opt == nil {
-		return ""
-	}
-
-	var params = make([]string, 0)
-
-	if opt.campaignMessageField != nil {
-		params = append(params, models.BuildCampaignMessageFieldsParam(opt.campaignMessageField))
-	}
-	if opt.campaignFields != nil {
-		params = append(params, models.BuildCampaignFieldsParam(opt.campaignFields))
-	}
-	if opt.campaignFields != nil {
-		params = append(params, models.BuildTemplateFieldParam(opt.templateFields))
-	}
-	if opt.campaignFields != nil {
-		params = append(params, models.BuildCampaignMessageIncludeFieldParam(opt.Include))
+		return query
 	}
 
-	return strings.Join(params, "&")
+	return query.
+		Apply(opt.campaignMessageField, opt.campaignFields, opt.templateFields).
+		Include(common.StringsOf(opt.Include)...)
 }
 
 func (api *campaignsApi) GetCampaignMessages(ctx context.Context, campaignId string, options *GetCampaignMessagesOptions) (*models.CampaignMessageCollectionResponse, error) {
-	var params = buildGetCampaignMessagesParams(options)
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaignMessages")
 
-	url := fmt.Sprintf("%s/api/campaigns/%s/campaign-messages/?%s", api.baseApiUrl, campaignId, params)
+	query := buildGetCampaignMessagesParams(options)
+	url := query.URL(fmt.Sprintf("%s/api/campaigns/%s/campaign-messages/", api.baseApiUrl, campaignId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
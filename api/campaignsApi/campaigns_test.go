@@ -2,13 +2,16 @@ package campaigns
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 
 	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/common/filter"
 	"github.com/developertom01/klaviyo-go/exceptions"
 	"github.com/developertom01/klaviyo-go/models"
 	"github.com/developertom01/klaviyo-go/options"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -33,7 +36,7 @@ func (suit *CampaignsApiTestSuite) TestGetCampaignsBadRequest() {
 		suit.T().Fatal(err)
 	}
 
-	filter := common.NewFilterBuilder().Equal("name", "sam")
+	filter := common.NewFilterBuilder().Equal("messages.channel", "email")
 	_, err = suit.api.GetCampaigns(context.Background(), filter.Build(), nil)
 
 	suit.ErrorAs(err, &exceptions.ErrorResponse{}, nil)
@@ -46,7 +49,7 @@ func (suit *CampaignsApiTestSuite) TestGetCampaignsServerError() {
 		suit.T().Fatal(err)
 	}
 
-	filter := common.NewFilterBuilder().Equal("name", "sam")
+	filter := common.NewFilterBuilder().Equal("messages.channel", "email")
 	_, err = suit.api.GetCampaigns(context.Background(), filter.Build(), nil)
 
 	suit.ErrorAs(err, &exceptions.ErrorResponse{}, nil)
@@ -59,13 +62,44 @@ func (suit *CampaignsApiTestSuite) TestGetCampaignsOkResponse() {
 		suit.T().Fatal(err)
 	}
 
-	filter := common.NewFilterBuilder().Equal("name", "sam")
+	filter := common.NewFilterBuilder().Equal("messages.channel", "email")
 	resp, err := suit.api.GetCampaigns(context.Background(), filter.Build(), nil)
 
 	suit.Nil(err)
 	suit.Equal(mockedRespData.Data[0].ID, resp.Data[0].ID)
 }
 
+func (suit *CampaignsApiTestSuite) TestGetCampaignsRequiresChannelFilter() {
+	_, err := suit.api.GetCampaigns(context.Background(), `equals(name,"sam")`, nil)
+
+	var schemaErr *filter.SchemaError
+	suit.ErrorAs(err, &schemaErr)
+	suit.ErrorIs(err, filter.ErrInvalidFilter)
+	suit.Equal("name", schemaErr.Field)
+	suit.Equal(filter.OperatorEquals, schemaErr.Operator)
+	suit.mockedClient.AssertNotCalled(suit.T(), "Do", mock.Anything)
+
+	_, err = suit.api.GetCampaigns(context.Background(), `contains(name,"sam")`, nil)
+	suit.ErrorAs(err, &schemaErr)
+	suit.Equal("messages.channel", schemaErr.Field)
+	suit.mockedClient.AssertNotCalled(suit.T(), "Do", mock.Anything)
+}
+
+func (suit *CampaignsApiTestSuite) TestGetCampaignsFilterValidationDisabled() {
+	opt := options.NewOptionsWithDefaultValues().WithApiKey("test-key").WithFilterValidation(false)
+	api := NewCampaignsApi(common.NewApiKeySession(opt, common.NewRetryOptionsWithDefaultValues()), suit.mockedClient)
+
+	mockedRespData := mockCampaignCollectionResponse(1)
+	err := common.PrepareMockResponse(http.StatusOK, mockedRespData, suit.mockedClient)
+	if err != nil {
+		suit.T().Fatal(err)
+	}
+
+	_, err = api.GetCampaigns(context.Background(), `equals(name,"sam")`, nil)
+
+	suit.Nil(err)
+}
+
 func (suit *CampaignsApiTestSuite) TestDeleteCampaignsServerError() {
 	var campaignId = "test id2"
 
@@ -151,6 +185,38 @@ func (suit *CampaignsApiTestSuite) TestCreateCampaignOKRequest() {
 	suit.Equal(mockedRespData.Data.ID, res.Data.ID)
 }
 
+func (suit *CampaignsApiTestSuite) TestCreateCampaignRejectsMismatchedContent() {
+	reqData := mockCreateCampaignRequestData()
+	reqData.Data.Attributes.CampaignMessages.Data[0].Attributes = CreateCampaignMessagesDataAttributes{
+		Channel: models.ChannelEmail,
+		Content: models.SMSContent{},
+	}
+
+	_, err := suit.api.CreateCampaign(context.Background(), reqData)
+
+	suit.ErrorIs(err, models.ErrContentChannelMismatch)
+	suit.mockedClient.AssertNotCalled(suit.T(), "Do", mock.Anything)
+}
+
+func (suit *CampaignsApiTestSuite) TestGetCampaignIncludesCampaignMessages() {
+	err := common.PrepareMockResponse(http.StatusOK, json.RawMessage(`{"data": {
+		"type": "campaign", "id": "campaign-id", "attributes": {},
+		"relationships": {"campaign-messages": {"data": [{"type": "campaign-message", "id": "message-id"}]}}
+	}}`), suit.mockedClient)
+	if err != nil {
+		suit.T().Fatal(err)
+	}
+
+	res, err := suit.api.GetCampaign(context.Background(), "campaign-id", "", &GetCampaignsOptions{
+		Include: []models.CampaignIncludeField{models.CampaignIncludeFieldCampaignMessage},
+	})
+
+	suit.Nil(err)
+	req := suit.mockedClient.Calls[0].Arguments.Get(0).(*http.Request)
+	suit.Equal("campaign-messages", req.URL.Query().Get("include"))
+	suit.Equal([]models.RelationshipData{{Type: "campaign-message", ID: "message-id"}}, res.Data.Relationships.CampaignMessage.Data)
+}
+
 func (suit *CampaignsApiTestSuite) TestUpdateCampaignsServerError() {
 	var campaignId = "123232"
 	reqData := mockCreateCampaignRequestData()
@@ -0,0 +1,20 @@
+package campaigns
+
+import (
+	"github.com/developertom01/klaviyo-go/common/filter"
+)
+
+// Filter accepted by GetCampaigns. A messages.channel filter is required
+var GetCampaignsFilterSchema = filter.Schema{
+	Endpoint: "GET /api/campaigns/",
+	Fields: map[string][]filter.Operator{
+		"messages.channel": {filter.OperatorEquals},
+		"name":             {filter.OperatorContains},
+		"status":           {filter.OperatorAny, filter.OperatorEquals},
+		"archived":         {filter.OperatorEquals},
+		"created_at":       filter.DateTimeOperators,
+		"scheduled_at":     filter.DateTimeOperators,
+		"updated_at":       filter.DateTimeOperators,
+	},
+	Required: []string{"messages.channel"},
+}
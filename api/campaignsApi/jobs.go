@@ -8,19 +8,20 @@ import (
 	"net/http"
 
 	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/instrumentation"
 	"github.com/developertom01/klaviyo-go/models"
 )
 
 type CampaignJobsApi interface {
-	//Get a campaign send job
-	GetCampaignSendJob(ctx context.Context, jobFields []models.CampaignSendJobField) (*models.CampaignSendJobResponse, error)
+	//Get a campaign send job. `jobId` is the ID of the campaign being sent
+	GetCampaignSendJob(ctx context.Context, jobId string, jobFields models.Fields[models.CampaignSendJobField]) (*models.CampaignSendJobResponse, error)
 
 	//Permanently cancel the campaign, setting the status to CANCELED or revert the campaign, setting the status back to DRAFT
 	UpdateCampaignSendJob(ctx context.Context, jobId string, payload UpdateCampaignSendJobPayload) (*models.CampaignSendJobResponse, error)
 
 	//Retrieve the status of a recipient estimation job triggered with the Create Campaign Recipient Estimation Job endpoint.
 	//`campaignId`is the ID of the campaign to get recipient estimation status
-	GetCampaignRecipientEstimationJob(ctx context.Context, campaignId string, jobFields []models.CampaignSendJobField) (*models.CampaignSendJobResponse, error)
+	GetCampaignRecipientEstimationJob(ctx context.Context, campaignId string, jobFields models.Fields[models.CampaignSendJobField]) (*models.CampaignSendJobResponse, error)
 
 	//Permanently cancel the campaign, setting the status to CANCELED or revert the campaign, setting the status back to DRAFT
 	CreateCampaignSendJob(ctx context.Context, payload CampaignSendCreationJobPayload) (*models.CampaignSendJobResponse, error)
@@ -32,9 +33,11 @@ type CampaignJobsApi interface {
 	CreateCampaignRecipientEstimationJob(ctx context.Context, payload CampaignSendCreationJobPayload) (*models.CampaignSendJobResponse, error)
 }
 
-func (api *campaignsApi) GetCampaignSendJob(ctx context.Context, jobFields []models.CampaignSendJobField) (*models.CampaignSendJobResponse, error) {
-	fieldsParam := models.BuildCampaignSendJobFieldsParam(jobFields)
-	url := fmt.Sprintf("%s/api/campaigns/?%s", api.baseApiUrl, fieldsParam)
+func (api *campaignsApi) GetCampaignSendJob(ctx context.Context, jobId string, jobFields models.Fields[models.CampaignSendJobField]) (*models.CampaignSendJobResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaignSendJob")
+
+	query := common.NewQuery().Apply(jobFields)
+	url := query.URL(fmt.Sprintf("%s/api/campaign-send-jobs/%s/", api.baseApiUrl, jobId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -52,6 +55,8 @@ func (api *campaignsApi) GetCampaignSendJob(ctx context.Context, jobFields []mod
 }
 
 func (api *campaignsApi) UpdateCampaignSendJob(ctx context.Context, jobId string, payload UpdateCampaignSendJobPayload) (*models.CampaignSendJobResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.UpdateCampaignSendJob")
+
 	url := fmt.Sprintf("%s/api/campaign-send-jobs/%s", api.baseApiUrl, jobId)
 
 	reqData, err := json.Marshal(payload)
@@ -60,7 +65,7 @@ func (api *campaignsApi) UpdateCampaignSendJob(ctx context.Context, jobId string
 	}
 
 	reqDataBuffer := bytes.NewBuffer(reqData)
-	req, err := http.NewRequest(http.MethodPatch, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -76,9 +81,11 @@ func (api *campaignsApi) UpdateCampaignSendJob(ctx context.Context, jobId string
 	return &resp, err
 }
 
-func (api *campaignsApi) GetCampaignRecipientEstimationJob(ctx context.Context, campaignId string, jobFields []models.CampaignSendJobField) (*models.CampaignSendJobResponse, error) {
-	fieldsParam := models.BuildCampaignSendJobFieldsParam(jobFields)
-	url := fmt.Sprintf("%s/api/campaign-recipient-estimation-jobs/%s/?%s", api.baseApiUrl, campaignId, fieldsParam)
+func (api *campaignsApi) GetCampaignRecipientEstimationJob(ctx context.Context, campaignId string, jobFields models.Fields[models.CampaignSendJobField]) (*models.CampaignSendJobResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaignRecipientEstimationJob")
+
+	query := common.NewQuery().Apply(jobFields)
+	url := query.URL(fmt.Sprintf("%s/api/campaign-recipient-estimation-jobs/%s/", api.baseApiUrl, campaignId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -96,6 +103,8 @@ func (api *campaignsApi) GetCampaignRecipientEstimationJob(ctx context.Context,
 }
 
 func (api campaignsApi) CreateCampaignSendJob(ctx context.Context, payload CampaignSendCreationJobPayload) (*models.CampaignSendJobResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.CreateCampaignSendJob")
+
 	url := fmt.Sprintf("%s/api/campaign-send-jobs/", api.baseApiUrl)
 
 	reqData, err := json.Marshal(payload)
@@ -104,7 +113,7 @@ func (api campaignsApi) CreateCampaignSendJob(ctx context.Context, payload Campa
 	}
 
 	reqDataBuffer := bytes.NewBuffer(reqData)
-	req, err := http.NewRequest(http.MethodPost, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +130,8 @@ func (api campaignsApi) CreateCampaignSendJob(ctx context.Context, payload Campa
 }
 
 func (api campaignsApi) CreateCampaignRecipientEstimationJob(ctx context.Context, payload CampaignSendCreationJobPayload) (*models.CampaignSendJobResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.CreateCampaignRecipientEstimationJob")
+
 	url := fmt.Sprintf("%s/api/campaign-recipient-estimation-jobs/", api.baseApiUrl)
 
 	reqData, err := json.Marshal(payload)
@@ -129,7 +140,7 @@ func (api campaignsApi) CreateCampaignRecipientEstimationJob(ctx context.Context
 	}
 
 	reqDataBuffer := bytes.NewBuffer(reqData)
-	req, err := http.NewRequest(http.MethodPost, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
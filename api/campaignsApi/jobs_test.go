@@ -35,7 +35,7 @@ func (suit *CampaignsJobsApiTestSuite) TestGetCampaignSendJobServerError() {
 		suit.T().Fatal(err)
 	}
 
-	_, err = suit.api.GetCampaignSendJob(context.Background(), nil)
+	_, err = suit.api.GetCampaignSendJob(context.Background(), "job-id", nil)
 
 	suit.ErrorAs(err, &exceptions.ErrorResponse{}, nil)
 }
@@ -48,7 +48,7 @@ func (suit *CampaignsJobsApiTestSuite) TestGetCampaignSendJobBadRequest() {
 		suit.T().Fatal(err)
 	}
 
-	_, err = suit.api.GetCampaignSendJob(context.Background(), nil)
+	_, err = suit.api.GetCampaignSendJob(context.Background(), "job-id", nil)
 
 	suit.ErrorAs(err, &exceptions.ErrorResponse{}, nil)
 }
@@ -61,10 +61,13 @@ func (suit *CampaignsJobsApiTestSuite) TestGetCampaignSendJobStatusOk() {
 		suit.T().Fatal(err)
 	}
 
-	res, err := suit.api.GetCampaignSendJob(context.Background(), nil)
+	res, err := suit.api.GetCampaignSendJob(context.Background(), "job-id", nil)
 
 	suit.Nil(err)
 	suit.Equal(mockedRespData.Data.ID, res.Data.ID)
+
+	req := suit.mockedClient.Calls[0].Arguments.Get(0).(*http.Request)
+	suit.Equal("/api/campaign-send-jobs/job-id/", req.URL.Path)
 }
 
 // ------ Test UpdateCampaignSendJob
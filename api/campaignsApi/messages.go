@@ -6,9 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/instrumentation"
 	"github.com/developertom01/klaviyo-go/models"
 )
 
@@ -26,40 +26,28 @@ type (
 )
 
 type GetCampaignMessageOptions struct {
-	CampaignFields        []models.CampaignsField
-	CampaignMessageFields []models.CampaignMessageField
-	TemplateFields        []models.TemplateField
+	CampaignFields        models.Fields[models.CampaignsField]
+	CampaignMessageFields models.Fields[models.CampaignMessageField]
+	TemplateFields        models.Fields[models.TemplateField]
 	Include               []models.CampaignIncludeField
 }
 
-func buildGetCampaignMessageParams(opt *GetCampaignMessageOptions) string {
+func buildGetCampaignMessageParams(opt *GetCampaignMessageOptions) *common.Query {
+	query := common.NewQuery()
 	if opt == nil {
-		return ""
+		return query
 	}
-	var params = make([]string, 0)
 
-	if opt.CampaignFields != nil {
-		params = append(params, models.BuildCampaignFieldsParam(opt.CampaignFields))
-	}
-
-	if opt.CampaignMessageFields != nil {
-		params = append(params, models.BuildCampaignMessageFieldsParam(opt.CampaignMessageFields))
-	}
-
-	if opt.TemplateFields != nil {
-		params = append(params, models.BuildTemplateFieldParam(opt.TemplateFields))
-	}
-
-	if opt.Include != nil {
-		params = append(params, models.BuildCampaignIncludeFieldParam(opt.Include))
-	}
-
-	return strings.Join(params, "&")
+	return query.
+		Apply(opt.CampaignFields, opt.CampaignMessageFields, opt.TemplateFields).
+		Include(common.StringsOf(opt.Include)...)
 }
 
 func (api *campaignsApi) GetCampaignMessage(ctx context.Context, messageId string, options *GetCampaignMessageOptions) (*models.CampaignMessageResponse, error) {
-	queryParams := buildGetCampaignMessageParams(options)
-	url := fmt.Sprintf("%s/api/campaign-messages/%s/?%s", api.baseApiUrl, messageId, queryParams)
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaignMessage")
+
+	query := buildGetCampaignMessageParams(options)
+	url := query.URL(fmt.Sprintf("%s/api/campaign-messages/%s/", api.baseApiUrl, messageId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -78,6 +66,8 @@ func (api *campaignsApi) GetCampaignMessage(ctx context.Context, messageId strin
 }
 
 func (api *campaignsApi) UpdateCampaignMessage(ctx context.Context, messageId string, payload UpdateCampaignMessagePayload) (*models.CampaignMessageResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.UpdateCampaignMessage")
+
 	url := fmt.Sprintf("%s/api/campaign-messages/%s/", api.baseApiUrl, messageId)
 
 	reqData, err := json.Marshal(payload)
@@ -86,7 +76,7 @@ func (api *campaignsApi) UpdateCampaignMessage(ctx context.Context, messageId st
 	}
 
 	reqDataBuffer := bytes.NewBuffer(reqData)
-	req, err := http.NewRequest(http.MethodPatch, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +93,8 @@ func (api *campaignsApi) UpdateCampaignMessage(ctx context.Context, messageId st
 }
 
 func (api *campaignsApi) AssignCampaignMessageTemplate(ctx context.Context, payload AssignCampaignMessageTemplatePayload) (*models.CampaignMessageResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.AssignCampaignMessageTemplate")
+
 	url := fmt.Sprintf("%s/api/campaign-message-assign-template/", api.baseApiUrl)
 
 	reqData, err := json.Marshal(payload)
@@ -111,7 +103,7 @@ func (api *campaignsApi) AssignCampaignMessageTemplate(ctx context.Context, payl
 	}
 
 	reqDataBuffer := bytes.NewBuffer(reqData)
-	req, err := http.NewRequest(http.MethodPost, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
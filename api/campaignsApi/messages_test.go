@@ -2,6 +2,7 @@ package campaigns
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 
@@ -9,6 +10,8 @@ import (
 	"github.com/developertom01/klaviyo-go/exceptions"
 	"github.com/developertom01/klaviyo-go/models"
 	"github.com/developertom01/klaviyo-go/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -173,3 +176,23 @@ func TestCampaignsMessageApiTestSuite(t *testing.T) {
 	suite.Run(t, new(CampaignsMessageApiTestSuite))
 
 }
+
+func TestCreateCampaignMessagesDataAttributesContent(t *testing.T) {
+	bcc := "archive@example.com"
+	attributes := CreateCampaignMessagesDataAttributes{
+		Channel: models.ChannelEmail,
+		Content: models.EmailContent{BccEmail: &bcc},
+	}
+
+	data, err := json.Marshal(attributes)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"channel": "email", "content": {"bcc_email": "archive@example.com"}}`, string(data))
+
+	var decoded CreateCampaignMessagesDataAttributes
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, attributes, decoded)
+
+	data, err = json.Marshal(CreateCampaignMessagesDataAttributes{Channel: models.ChannelSms})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"channel": "sms"}`, string(data))
+}
@@ -1,7 +1,6 @@
 package campaigns
 
 import (
-	"encoding/json"
 	"time"
 
 	"github.com/developertom01/klaviyo-go/models"
@@ -108,7 +107,7 @@ func mockCreateCampaignRequestData() CreateCampaignRequestData {
 				Name: fake.Company().Name(),
 				SendStrategy: &CampaignDataAttributeSendStrategy{
 					Method: models.SendStrategyMethodSmartSendTime,
-					OptionsSto: OptionsSto{
+					OptionsSto: &OptionsSto{
 						Date: time.Now(),
 					},
 				},
@@ -140,7 +139,7 @@ func mockUpdateCampaignRequestData() UpdateCampaignRequestData {
 				Name: &name,
 				SendStrategy: &CampaignDataAttributeSendStrategy{
 					Method: models.SendStrategyMethodSmartSendTime,
-					OptionsSto: OptionsSto{
+					OptionsSto: &OptionsSto{
 						Date: time.Now(),
 					},
 				},
@@ -172,22 +171,18 @@ func mockCampaignMessage() models.CampaignMessage {
 
 	var emailSubject = fake.Lorem().Sentence(20)
 	var contentEmail = fake.Lorem().Sentence(20)
-	emailContent := models.MessageEmailContent{
+	emailContent := models.EmailContent{
 		Subject:   &emailSubject,
 		FromEmail: &contentEmail,
 	}
 
-	emailContentByte, _ := json.Marshal(emailContent)
-	var messageContent models.MessageContent
-	json.Unmarshal(emailContentByte, &messageContent)
-
 	return models.CampaignMessage{
 		Type: campaignMessageType,
 		ID:   fake.UUID().V4(),
 		Attributes: models.CampaignMessageAttributes{
 			Label:         fake.Lorem().Word(),
-			Channel:       "email",
-			Content:       messageContent,
+			Channel:       models.ChannelEmail,
+			Content:       emailContent,
 			RenderOptions: models.MessageRenderOptions{},
 			SendTimes:     []models.SendTime{},
 			CreatedAt:     time.Now(),
@@ -219,16 +214,15 @@ func mockUpdateCampaignMessagePayload() UpdateCampaignMessagePayload {
 	fake := faker.New()
 
 	smsBody := "text message"
-	smsContent := MessageSMSContent{
+	smsContent := models.SMSContent{
 		Body: &smsBody,
 	}
-	msgContent, _ := smsContent.ToMessageContent()
 
 	return UpdateCampaignMessagePayload{
 		Type: campaignMessageType,
 		ID:   fake.UUID().V4(),
 		Attributes: UpdateCampaignMessageAttributes{
-			Content: msgContent,
+			Content: smsContent,
 		},
 	}
 }
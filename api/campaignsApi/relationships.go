@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/instrumentation"
 	"github.com/developertom01/klaviyo-go/models"
 )
 
@@ -25,6 +26,8 @@ type CampaignRelationshipsAPi interface {
 }
 
 func (api *campaignsApi) GetCampaignMessageRelationshipsCampaign(ctx context.Context, messageId string) (*models.RelationshipData, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaignMessageRelationshipsCampaign")
+
 	url := fmt.Sprintf("%s/api/campaign-messages/%s/relationships/campaign/", api.baseApiUrl, messageId)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -44,6 +47,8 @@ func (api *campaignsApi) GetCampaignMessageRelationshipsCampaign(ctx context.Con
 }
 
 func (api *campaignsApi) GetCampaignMessageRelationshipsTemplate(ctx context.Context, messageId string) (*models.RelationshipData, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaignMessageRelationshipsTemplate")
+
 	url := fmt.Sprintf("%s/api/campaign-messages/%s/relationships/template/", api.baseApiUrl, messageId)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -63,6 +68,8 @@ func (api *campaignsApi) GetCampaignMessageRelationshipsTemplate(ctx context.Con
 }
 
 func (api *campaignsApi) GetCampaignRelationshipsTags(ctx context.Context, campaignId string) (*models.RelationshipDataCollection, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaignRelationshipsTags")
+
 	url := fmt.Sprintf("%s/api/campaigns/%s/relationships/tags/", api.baseApiUrl, campaignId)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -82,6 +89,8 @@ func (api *campaignsApi) GetCampaignRelationshipsTags(ctx context.Context, campa
 }
 
 func (api *campaignsApi) GetCampaignRelationshipsCampaignMessages(ctx context.Context, campaignId string) (*models.RelationshipDataCollection, error) {
+	ctx = instrumentation.WithOperation(ctx, "CampaignsApi.GetCampaignRelationshipsCampaignMessages")
+
 	url := fmt.Sprintf("%s/api/campaigns/%s/relationships/campaign-messages/", api.baseApiUrl, campaignId)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
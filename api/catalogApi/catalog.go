@@ -1,8 +1,6 @@
 package catalog
 
 import (
-	"net/http"
-
 	"github.com/developertom01/klaviyo-go/common"
 )
 
@@ -10,6 +8,8 @@ type (
 	CatalogApi interface {
 		//Catalog item API
 		CatalogItemApi
+		//Catalog variant API
+		CatalogVariantApi
 	}
 
 	catalogApi struct {
@@ -21,17 +21,12 @@ type (
 )
 
 func NewCatalogApi(session common.Session, httpClient common.HTTPClient) CatalogApi {
-	var client common.HTTPClient
-	if httpClient == nil {
-		client = http.DefaultClient
-	} else {
-		client = httpClient
-	}
+	opt := session.GetOptions()
 
 	return &catalogApi{
 		session:    session,
-		baseApiUrl: common.BASE_URL,
-		revision:   common.API_REVISION,
-		httpClient: client,
+		baseApiUrl: common.ResolveBaseUrl(opt),
+		revision:   common.ResolveRevision(opt),
+		httpClient: common.ResolveHTTPClient(opt, httpClient),
 	}
 }
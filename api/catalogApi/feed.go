@@ -0,0 +1,258 @@
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type (
+	// Row of a product feed. Values are keyed by CSV column, JSON key or XML element name,
+	// nested names joined with a dot, eg. shipping.price
+	FeedRow struct {
+		Line   int //Line the row starts at
+		Values map[string][]string
+	}
+
+	// Reads rows of a product feed
+	FeedReader interface {
+		// Returns the next row, io.EOF after the last one. A row that can not be read is reported
+		// as a FeedRowError, reading can go on with the next one
+		Read() (FeedRow, error)
+	}
+
+	csvFeedReader struct {
+		reader *csv.Reader
+		header []string
+	}
+
+	jsonLinesFeedReader struct {
+		reader *bufio.Reader
+		line   int
+	}
+
+	googleShoppingFeedReader struct {
+		decoder *xml.Decoder
+	}
+)
+
+// Returns the first value of `name`, empty when missing
+func (row FeedRow) Get(name string) string {
+	if values := row.Values[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (row FeedRow) add(name string, value string) {
+	value = strings.TrimSpace(value)
+	if value != "" {
+		row.Values[name] = append(row.Values[name], value)
+	}
+}
+
+// Returns reader of a CSV feed whose first record holds the column names. Empty cells are left out of rows
+func NewCSVFeedReader(r io.Reader) FeedReader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	return &csvFeedReader{reader: reader}
+}
+
+func (r *csvFeedReader) Read() (FeedRow, error) {
+	if r.header == nil {
+		header, err := r.reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				return FeedRow{}, io.EOF
+			}
+			return FeedRow{}, fmt.Errorf("catalog: reading CSV header: %w", err)
+		}
+
+		r.header = make([]string, len(header))
+		for i, name := range header {
+			r.header[i] = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		}
+	}
+
+	record, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return FeedRow{}, FeedRowError{Line: parseErr.StartLine, Err: err}
+		}
+		return FeedRow{}, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	row := FeedRow{Line: line, Values: map[string][]string{}}
+	if len(record) > len(r.header) {
+		return row, FeedRowError{Line: line, Err: fmt.Errorf("row has %d fields, the header has %d", len(record), len(r.header))}
+	}
+	for i, value := range record {
+		row.add(r.header[i], value)
+	}
+
+	return row, nil
+}
+
+// Returns reader of a JSON Lines feed holding an object per line. Nested objects are flattened
+// and arrays give a value per element, blank lines are skipped
+func NewJSONLinesFeedReader(r io.Reader) FeedReader {
+	return &jsonLinesFeedReader{reader: bufio.NewReader(r)}
+}
+
+func (r *jsonLinesFeedReader) Read() (FeedRow, error) {
+	for {
+		data, err := r.reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return FeedRow{}, err
+		}
+		if len(data) == 0 && err == io.EOF {
+			return FeedRow{}, io.EOF
+		}
+		r.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+
+		row := FeedRow{Line: r.line, Values: map[string][]string{}}
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var object map[string]any
+		if err := decoder.Decode(&object); err != nil {
+			return row, FeedRowError{Line: r.line, Err: fmt.Errorf("decoding JSON: %w", err)}
+		}
+		flattenFeedValue(row, "", object)
+
+		return row, nil
+	}
+}
+
+// Adds `value` to `row` under `name`, objects under their keys joined with a dot
+func flattenFeedValue(row FeedRow, name string, value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, member := range value {
+			if name != "" {
+				key = name + "." + key
+			}
+			flattenFeedValue(row, key, member)
+		}
+	case []any:
+		for _, element := range value {
+			flattenFeedValue(row, name, element)
+		}
+	case nil:
+	default:
+		row.add(name, fmt.Sprint(value))
+	}
+}
+
+// Returns reader of a Google Shopping feed, RSS 2.0 with an <item> or Atom with an <entry> per product.
+// Elements are keyed by local name, eg. g:price is price
+func NewGoogleShoppingFeedReader(r io.Reader) FeedReader {
+	return &googleShoppingFeedReader{decoder: xml.NewDecoder(r)}
+}
+
+func (r *googleShoppingFeedReader) Read() (FeedRow, error) {
+	for {
+		token, err := r.decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				return FeedRow{}, io.EOF
+			}
+			return FeedRow{}, fmt.Errorf("catalog: reading Google Shopping feed: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || (start.Name.Local != "item" && start.Name.Local != "entry") {
+			continue
+		}
+
+		line, _ := r.decoder.InputPos()
+		row := FeedRow{Line: line, Values: map[string][]string{}}
+		if _, _, err := r.readElement(row, ""); err != nil {
+			return FeedRow{}, fmt.Errorf("catalog: reading Google Shopping feed: %w", err)
+		}
+
+		return row, nil
+	}
+}
+
+// Reads the current element up to its end. Child elements are added to `row` under their name prefixed with `name`.
+// Returns the text of the element and whether it has child elements
+func (r *googleShoppingFeedReader) readElement(row FeedRow, name string) (text string, nested bool, err error) {
+	var content strings.Builder
+	for {
+		token, err := r.decoder.Token()
+		if err != nil {
+			return "", false, err
+		}
+
+		switch token := token.(type) {
+		case xml.CharData:
+			content.Write(token)
+		case xml.StartElement:
+			nested = true
+
+			childName := token.Name.Local
+			if name != "" {
+				childName = name + "." + childName
+			}
+
+			// Atom links carry their URL in href
+			for _, attr := range token.Attr {
+				if token.Name.Local == "link" && attr.Name.Local == "href" {
+					row.add(childName, attr.Value)
+				}
+			}
+
+			childText, childNested, err := r.readElement(row, childName)
+			if err != nil {
+				return "", false, err
+			}
+			if !childNested {
+				row.add(childName, childText)
+			}
+		case xml.EndElement:
+			return content.String(), nested, nil
+		}
+	}
+}
+
+// Row of a feed that could not be imported
+type FeedRowError struct {
+	Line       int
+	ExternalId string //External ID of the item or variant, empty when unknown
+	Field      string //Mapped field, eg. price, empty when the row as a whole is invalid
+	Err        error
+}
+
+func (e FeedRowError) Error() string {
+	var message strings.Builder
+	fmt.Fprintf(&message, "catalog: feed line %d", e.Line)
+	if e.ExternalId != "" {
+		fmt.Fprintf(&message, " (%s)", e.ExternalId)
+	}
+	if e.Field != "" {
+		fmt.Fprintf(&message, " %s", e.Field)
+	}
+	fmt.Fprintf(&message, ": %s", e.Err)
+
+	return message.String()
+}
+
+func (e FeedRowError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,287 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+type (
+	// Options of ImportFeed
+	FeedImportOptions struct {
+		DryRun          bool                //Maps and validates the rows without creating anything
+		MaxItemsPerJob  int                 //Items of a bulk job, at most MAX_BULK_JOB_ITEMS
+		MaxJobsInFlight int                 //Bulk jobs, and variant creations, in progress at one time, at most MAX_BULK_JOBS_IN_PROGRESS
+		Wait            *common.WaitOptions //Polling of the bulk jobs
+	}
+
+	// Outcome of ImportFeed. Items and variants are identified by external ID
+	FeedImportReport struct {
+		DryRun   bool
+		Rows     int //Rows read from the feed
+		Items    []string
+		Variants []string
+		Failed   []FeedRowError
+		Jobs     []string //IDs of the spawned bulk jobs
+	}
+
+	// Variant of a feed row, created once its item is
+	feedVariant struct {
+		line    int
+		itemId  string
+		payload CreateCatalogItemVariantAttributesPayload
+	}
+)
+
+func NewFeedImportOptionsWithDefaultValues() *FeedImportOptions {
+	syncOpt := NewSyncOptionsWithDefaultValues()
+
+	return &FeedImportOptions{
+		MaxItemsPerJob:  syncOpt.MaxItemsPerJob,
+		MaxJobsInFlight: syncOpt.MaxJobsInFlight,
+		Wait:            syncOpt.Wait,
+	}
+}
+
+// Creates the items and variants of a feed read from `reader`. Items are streamed into bulk create jobs while the feed
+// is read, a variant whose item is not in the feed gets one mapped from its first row once the feed is read. Variants are created one by one
+// once the jobs of their items completed, the catalog API has no bulk jobs of variants.
+// Returns an error when the feed can not be read, invalid rows and failed items are reported in FeedImportReport.Failed
+func ImportFeed(ctx context.Context, api CatalogApi, reader FeedReader, mapping FeedMapping, opt *FeedImportOptions) (*FeedImportReport, error) {
+	if opt == nil {
+		opt = NewFeedImportOptionsWithDefaultValues()
+	}
+	syncOpt := resolveSyncOptions(&SyncOptions{MaxItemsPerJob: opt.MaxItemsPerJob, MaxJobsInFlight: opt.MaxJobsInFlight, Wait: opt.Wait})
+
+	report := &FeedImportReport{DryRun: opt.DryRun}
+	jobs := &SyncReport{}
+	runner := newSyncRunner(api, syncOpt, jobs)
+	batcher := &syncBatcher[CreateCatalogItemPayload]{maxItems: syncOpt.MaxItemsPerJob}
+
+	// Line of each item and variant, by external ID
+	itemLines := map[string]int{}
+	variantLines := map[string]int{}
+	failedItems := map[string]bool{}
+	var variants []feedVariant
+
+	// First variant row of each item without a row so far, by external ID
+	pendingItems := map[string]FeedRow{}
+	var pendingItemIds []string
+
+	addItem := func(item CreateCatalogItemAttributesPayload, line int) {
+		itemLines[item.ExternalId] = line
+		if opt.DryRun {
+			report.Items = append(report.Items, item.ExternalId)
+			return
+		}
+
+		chunk, err := batcher.add(syncItem[CreateCatalogItemPayload]{externalId: item.ExternalId, payload: createCatalogItemPayloadOf(item)})
+		if err != nil {
+			failedItems[item.ExternalId] = true
+			report.Failed = append(report.Failed, FeedRowError{Line: line, ExternalId: item.ExternalId, Err: err})
+			return
+		}
+		if chunk != nil {
+			startSyncJob(ctx, runner, SyncOperationCreate, chunk, runner.create)
+		}
+	}
+
+	readErr := func() error {
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			row, err := reader.Read()
+			if err == io.EOF {
+				return nil
+			}
+			var rowErr FeedRowError
+			if errors.As(err, &rowErr) {
+				report.Rows++
+				report.Failed = append(report.Failed, rowErr)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			report.Rows++
+
+			if !mapping.IsVariant(row) {
+				item, err := mapping.Item(row)
+				if err == nil {
+					err = duplicateFeedRowError(row.Line, item.ExternalId, itemLines)
+				}
+				if err != nil {
+					report.Failed = append(report.Failed, feedRowErrorsOf(err)...)
+					continue
+				}
+
+				delete(pendingItems, item.ExternalId)
+				addItem(item, row.Line)
+				continue
+			}
+
+			variant, itemId, err := mapping.Variant(row)
+			if err == nil {
+				err = duplicateFeedRowError(row.Line, variant.ExternalId, variantLines)
+			}
+			if err != nil {
+				report.Failed = append(report.Failed, feedRowErrorsOf(err)...)
+				continue
+			}
+
+			_, hasItem := itemLines[itemId]
+			if _, pending := pendingItems[itemId]; !hasItem && !pending {
+				pendingItems[itemId] = row
+				pendingItemIds = append(pendingItemIds, itemId)
+			}
+
+			variantLines[variant.ExternalId] = row.Line
+			variants = append(variants, feedVariant{line: row.Line, itemId: itemId, payload: variant})
+		}
+	}()
+
+	// Items still without a row are mapped from the first row of their variants
+	unmappedItems := map[string]bool{}
+	for _, itemId := range pendingItemIds {
+		row, ok := pendingItems[itemId]
+		if !ok {
+			continue
+		}
+
+		item, err := mapping.Item(row)
+		if err != nil {
+			unmappedItems[itemId] = true
+			report.Failed = append(report.Failed, feedRowErrorsOf(err)...)
+			continue
+		}
+		item.ExternalId = itemId
+		addItem(item, row.Line)
+	}
+	variants = slices.DeleteFunc(variants, func(variant feedVariant) bool {
+		return unmappedItems[variant.itemId]
+	})
+
+	if opt.DryRun {
+		for _, variant := range variants {
+			report.Variants = append(report.Variants, variant.payload.ExternalId)
+		}
+		return report, readErr
+	}
+
+	if chunk := batcher.flush(); chunk != nil {
+		startSyncJob(ctx, runner, SyncOperationCreate, chunk, runner.create)
+	}
+	runner.wg.Wait()
+
+	report.Items = jobs.Created
+	report.Jobs = jobs.Jobs
+	for _, failure := range jobs.Failed {
+		failedItems[failure.ExternalId] = true
+		report.Failed = append(report.Failed, FeedRowError{Line: itemLines[failure.ExternalId], ExternalId: failure.ExternalId, Err: failure.Err})
+	}
+
+	createFeedVariants(ctx, api, syncOpt.MaxJobsInFlight, variants, failedItems, report)
+
+	sort.Strings(report.Items)
+	sort.Strings(report.Variants)
+	sort.Strings(report.Jobs)
+	sort.SliceStable(report.Failed, func(i, j int) bool {
+		return report.Failed[i].Line < report.Failed[j].Line
+	})
+
+	if readErr == nil {
+		readErr = ctx.Err()
+	}
+	return report, readErr
+}
+
+// Creates `variants` with at most `concurrency` calls in progress, skipping the ones whose item failed
+func createFeedVariants(ctx context.Context, api CatalogVariantApi, concurrency int, variants []feedVariant, failedItems map[string]bool, report *FeedImportReport) {
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		slots = make(chan struct{}, concurrency)
+	)
+
+	record := func(variant feedVariant, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			report.Failed = append(report.Failed, FeedRowError{Line: variant.line, ExternalId: variant.payload.ExternalId, Err: err})
+			return
+		}
+		report.Variants = append(report.Variants, variant.payload.ExternalId)
+	}
+
+	for _, variant := range variants {
+		if failedItems[variant.itemId] {
+			record(variant, fmt.Errorf("item %s was not created", variant.itemId))
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			record(variant, ctx.Err())
+			continue
+		case slots <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(variant feedVariant) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			_, err := api.CreateCatalogVariant(ctx, CreateCatalogItemVariantPayload{
+				Data: CreateCatalogItemVariantPayloadData{
+					Type:       "catalog-variant",
+					Attributes: variant.payload,
+					Relationships: CreateCatalogItemVariantDataRelationshipsPayload{
+						Item: models.RelationshipsRequestPayload{
+							Data: models.RelationshipData{Type: "catalog-item", ID: models.NewCatalogID(variant.itemId).String()},
+						},
+					},
+				},
+			})
+			record(variant, err)
+		}(variant)
+	}
+
+	wg.Wait()
+}
+
+// Returns an error when `externalId` was seen on an earlier line
+func duplicateFeedRowError(line int, externalId string, lines map[string]int) error {
+	if first, ok := lines[externalId]; ok {
+		return FeedRowError{Line: line, ExternalId: externalId, Field: "external_id", Err: fmt.Errorf("duplicates line %d", first)}
+	}
+	return nil
+}
+
+// Returns the FeedRowError values of `err`, joined ones unwrapped
+func feedRowErrorsOf(err error) []FeedRowError {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var rowErrs []FeedRowError
+		for _, err := range joined.Unwrap() {
+			rowErrs = append(rowErrs, feedRowErrorsOf(err)...)
+		}
+		return rowErrs
+	}
+
+	var rowErr FeedRowError
+	if errors.As(err, &rowErr) {
+		return []FeedRowError{rowErr}
+	}
+	return []FeedRowError{{Err: err}}
+}
@@ -0,0 +1,307 @@
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Maximum size of the custom metadata of a catalog item or variant
+const MAX_CUSTOM_METADATA_SIZE = 100 << 10
+
+// Matches a decimal price, optionally with a currency symbol or code, eg. $1,299.00, 15.99 USD or 1.299,00 €
+var feedPricePattern = regexp.MustCompile(`^(?:[^\d+-]*[^\d+\-.,])?([+-]?\d[\d,.]*)\s*(?:[A-Za-z]{3}|\pS)?$`)
+
+// Maps feed rows to catalog items and variants. Fields hold the name of the row value they are read from,
+// unmapped fields are left empty
+type FeedMapping struct {
+	ExternalId        string
+	Title             string
+	Description       string
+	Url               string
+	Price             string //Decimal price, optionally with a currency, eg. 15.99 USD
+	ImageFullUrl      string
+	ImageThumbnailUrl string
+	Images            string
+	Published         string            //true/false, yes/no or 1/0
+	CustomMetadata    map[string]string //Key of the custom metadata to the name of the value it is read from
+
+	ItemId            string //External ID of the item of a variant, eg. item_group_id. Rows holding it are variants
+	Sku               string
+	InventoryQuantity string
+	InventoryPolicy   string
+
+	PriceDecimals    int    //Decimals kept in the int64 price, eg. 2 to import 15.99 as 1599
+	DecimalSeparator string //Separates the decimals of prices, . or , and defaults to . The other one is read as a thousands separator
+	ListSeparator    string //Splits single values of Images, eg. | for a CSV column holding a list
+}
+
+// Mapping of Google Shopping product attributes. Products sharing an item_group_id are variants of an item with that ID
+func GoogleShoppingFeedMapping() FeedMapping {
+	return FeedMapping{
+		ExternalId:   "id",
+		Title:        "title",
+		Description:  "description",
+		Url:          "link",
+		Price:        "price",
+		ImageFullUrl: "image_link",
+		Images:       "additional_image_link",
+		CustomMetadata: map[string]string{
+			"brand":        "brand",
+			"gtin":         "gtin",
+			"mpn":          "mpn",
+			"condition":    "condition",
+			"availability": "availability",
+			"product_type": "product_type",
+		},
+		ItemId:        "item_group_id",
+		Sku:           "id",
+		PriceDecimals: 2,
+	}
+}
+
+// Reports whether `row` holds a variant, ie. the ItemId value is set
+func (m FeedMapping) IsVariant(row FeedRow) bool {
+	return m.ItemId != "" && row.Get(m.ItemId) != ""
+}
+
+// Maps `row` to a catalog item. Invalid fields are reported as FeedRowError, joined when there are several
+func (m FeedMapping) Item(row FeedRow) (CreateCatalogItemAttributesPayload, error) {
+	fields := feedFields{mapping: m, row: row}
+
+	item := CreateCatalogItemAttributesPayload{
+		ExternalId:  fields.required("external_id", m.ExternalId),
+		Title:       fields.required("title", m.Title),
+		Description: row.Get(m.Description),
+		Url:         fields.required("url", m.Url),
+		Price:       fields.price("price", m.Price),
+		Images:      fields.list(m.Images),
+		Published:   fields.bool("published", m.Published),
+	}
+	item.ImageFullUrl = fields.optional(m.ImageFullUrl)
+	item.ImageThumbnailUrl = fields.optional(m.ImageThumbnailUrl)
+	if metadata := fields.metadata(); metadata != nil {
+		item.CustomMetadata = &metadata
+	}
+
+	return item, fields.err(item.ExternalId)
+}
+
+// Maps `row` to a variant of the item returned by `itemId`. Invalid fields are reported as FeedRowError, joined when there are several
+func (m FeedMapping) Variant(row FeedRow) (variant CreateCatalogItemVariantAttributesPayload, itemId string, err error) {
+	fields := feedFields{mapping: m, row: row}
+
+	itemId = fields.required("item", m.ItemId)
+	variant = CreateCatalogItemVariantAttributesPayload{
+		ExternalId:      fields.required("external_id", m.ExternalId),
+		Title:           fields.required("title", m.Title),
+		Description:     row.Get(m.Description),
+		Sku:             fields.required("sku", m.Sku),
+		InventoryPolicy: fields.int("inventory_policy", m.InventoryPolicy),
+		Url:             fields.required("url", m.Url),
+		Images:          fields.list(m.Images),
+		Published:       fields.bool("published", m.Published),
+	}
+	if quantity := fields.int("inventory_quantity", m.InventoryQuantity); quantity != nil {
+		variant.InventoryQuantity = *quantity
+	}
+	if price := fields.price("price", m.Price); price != nil {
+		variant.Price = *price
+	} else if m.Price == "" || row.Get(m.Price) == "" {
+		fields.fail("price", errors.New("is required"))
+	}
+	variant.ImageFullUrl = fields.optional(m.ImageFullUrl)
+	variant.ImageThumbnailUrl = fields.optional(m.ImageThumbnailUrl)
+	if metadata := fields.metadata(); metadata != nil {
+		variant.CustomMetadata = &metadata
+	}
+
+	return variant, itemId, fields.err(variant.ExternalId)
+}
+
+// Reads mapped values of a row, collecting errors of invalid ones
+type feedFields struct {
+	mapping FeedMapping
+	row     FeedRow
+	errs    []FeedRowError
+}
+
+func (f *feedFields) fail(field string, err error) {
+	f.errs = append(f.errs, FeedRowError{Line: f.row.Line, Field: field, Err: err})
+}
+
+// Returns the errors joined, nil when there are none
+func (f *feedFields) err(externalId string) error {
+	errs := make([]error, len(f.errs))
+	for i, rowErr := range f.errs {
+		rowErr.ExternalId = externalId
+		errs[i] = rowErr
+	}
+	return errors.Join(errs...)
+}
+
+func (f *feedFields) required(field string, name string) string {
+	value := f.row.Get(name)
+	if value == "" {
+		if name == "" {
+			f.fail(field, errors.New("is not mapped"))
+		} else {
+			f.fail(field, fmt.Errorf("is required, %q is empty", name))
+		}
+	}
+	return value
+}
+
+func (f *feedFields) optional(name string) *string {
+	if value := f.row.Get(name); value != "" {
+		return &value
+	}
+	return nil
+}
+
+func (f *feedFields) list(name string) []string {
+	var values []string
+	for _, value := range f.row.Values[name] {
+		if f.mapping.ListSeparator == "" {
+			values = append(values, value)
+			continue
+		}
+		for _, part := range strings.Split(value, f.mapping.ListSeparator) {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+	}
+	return values
+}
+
+func (f *feedFields) price(field string, name string) *int64 {
+	value := f.row.Get(name)
+	if value == "" {
+		return nil
+	}
+
+	price, err := parseFeedPrice(value, f.mapping.PriceDecimals, f.mapping.DecimalSeparator)
+	if err != nil {
+		f.fail(field, err)
+		return nil
+	}
+	return &price
+}
+
+func (f *feedFields) int(field string, name string) *int64 {
+	value := f.row.Get(name)
+	if value == "" {
+		return nil
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		f.fail(field, fmt.Errorf("%q is not an integer", value))
+		return nil
+	}
+	return &number
+}
+
+func (f *feedFields) bool(field string, name string) *bool {
+	value := f.row.Get(name)
+	if value == "" {
+		return nil
+	}
+
+	var b bool
+	switch strings.ToLower(value) {
+	case "1", "t", "true", "y", "yes":
+		b = true
+	case "0", "f", "false", "n", "no":
+		b = false
+	default:
+		f.fail(field, fmt.Errorf("%q is not a boolean", value))
+		return nil
+	}
+	return &b
+}
+
+// Returns the custom metadata, nil when no value is set. A single value is kept as a string, several as a list
+func (f *feedFields) metadata() map[string]any {
+	var metadata map[string]any
+	for key, name := range f.mapping.CustomMetadata {
+		values := f.row.Values[name]
+		if len(values) == 0 {
+			continue
+		}
+
+		if metadata == nil {
+			metadata = map[string]any{}
+		}
+		if len(values) == 1 {
+			metadata[key] = values[0]
+		} else {
+			metadata[key] = values
+		}
+	}
+
+	if data, err := json.Marshal(metadata); err == nil && len(data) > MAX_CUSTOM_METADATA_SIZE {
+		f.fail("custom_metadata", fmt.Errorf("exceeds %d bytes", MAX_CUSTOM_METADATA_SIZE))
+		return nil
+	}
+	return metadata
+}
+
+// Converts a decimal price to an integer keeping `decimals` decimals, rounding half up. Thousands separators must
+// group digits by three, so that 15,99 is rejected rather than read as 1599 when the decimal separator is .
+func parseFeedPrice(value string, decimals int, decimalSeparator string) (int64, error) {
+	match := feedPricePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, fmt.Errorf("%q is not a price", value)
+	}
+
+	var thousandsSeparator string
+	switch decimalSeparator {
+	case "", ".":
+		decimalSeparator, thousandsSeparator = ".", ","
+	case ",":
+		thousandsSeparator = "."
+	default:
+		return 0, fmt.Errorf("decimal separator %q is not . or ,", decimalSeparator)
+	}
+
+	number := match[1]
+	if strings.HasPrefix(number, "-") {
+		return 0, fmt.Errorf("%q is negative", value)
+	}
+	number = strings.TrimPrefix(number, "+")
+
+	whole, fraction, _ := strings.Cut(number, decimalSeparator)
+	if strings.ContainsAny(fraction, ".,") || fraction == "" && strings.HasSuffix(number, decimalSeparator) {
+		return 0, fmt.Errorf("%q is not a price", value)
+	}
+	if groups := strings.Split(whole, thousandsSeparator); len(groups) > 1 {
+		for i, group := range groups {
+			// Only the first group may be shorter than three digits
+			if valid := len(group) == 3 || i == 0 && len(group) > 0 && len(group) < 3; !valid {
+				return 0, fmt.Errorf("%q has a misplaced %s, the decimal separator is %s", value, thousandsSeparator, decimalSeparator)
+			}
+		}
+		whole = strings.Join(groups, "")
+	}
+
+	roundUp := false
+	if len(fraction) > decimals {
+		roundUp = fraction[decimals] >= '5'
+		fraction = fraction[:decimals]
+	}
+	fraction += strings.Repeat("0", decimals-len(fraction))
+
+	price, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is out of range", value)
+	}
+	if roundUp {
+		price++
+	}
+	return price, nil
+}
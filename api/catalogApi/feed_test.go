@@ -0,0 +1,322 @@
+package catalog_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	catalog "github.com/developertom01/klaviyo-go/api/catalogApi"
+	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/klaviyotest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const csvFeed = "\ufeffid,title,url,price,images,on_sale\n" +
+	"SKU-1,\"Shirt, blue\",https://shop.example.com/sku-1,\"$1,299.50\",https://img/1.png|https://img/2.png,yes\n" +
+	"SKU-2,Hat,https://shop.example.com/sku-2,,,\n" +
+	"SKU-3,Scarf,,9.99,,maybe\n" +
+	"SKU-4,Socks,https://shop.example.com/sku-4,4.995 USD,,no,extra\n" +
+	"SKU-1,Shirt again,https://shop.example.com/sku-1,1,,\n"
+
+const googleShoppingFeed = `<?xml version="1.0"?>
+<rss version="2.0" xmlns:g="http://base.google.com/ns/1.0">
+  <channel>
+    <title>Shop</title>
+    <item>
+      <g:id>MUG-1</g:id>
+      <title>Mug</title>
+      <link>https://shop.example.com/mug</link>
+      <g:price>12.00 USD</g:price>
+      <g:brand>Acme</g:brand>
+    </item>
+    <item>
+      <g:id>TEE-S</g:id>
+      <g:item_group_id>TEE</g:item_group_id>
+      <title>Tee</title>
+      <link>https://shop.example.com/tee?size=s</link>
+      <g:price>20.00 USD</g:price>
+      <g:image_link>https://img/tee.png</g:image_link>
+      <g:additional_image_link>https://img/tee-1.png</g:additional_image_link>
+      <g:additional_image_link>https://img/tee-2.png</g:additional_image_link>
+      <g:shipping>
+        <g:country>US</g:country>
+        <g:price>4.99 USD</g:price>
+      </g:shipping>
+    </item>
+    <item>
+      <g:id>TEE-M</g:id>
+      <g:item_group_id>TEE</g:item_group_id>
+      <title>Tee</title>
+      <link>https://shop.example.com/tee?size=m</link>
+      <g:price>22.00 USD</g:price>
+    </item>
+  </channel>
+</rss>`
+
+func csvMapping() catalog.FeedMapping {
+	return catalog.FeedMapping{
+		ExternalId:     "id",
+		Title:          "title",
+		Url:            "url",
+		Price:          "price",
+		Images:         "images",
+		Published:      "on_sale",
+		CustomMetadata: map[string]string{"sale": "on_sale"},
+		PriceDecimals:  2,
+		ListSeparator:  "|",
+	}
+}
+
+func readAll(t *testing.T, reader catalog.FeedReader) ([]catalog.FeedRow, []catalog.FeedRowError) {
+	var (
+		rows   []catalog.FeedRow
+		failed []catalog.FeedRowError
+	)
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return rows, failed
+		}
+
+		var rowErr catalog.FeedRowError
+		if errors.As(err, &rowErr) {
+			failed = append(failed, rowErr)
+			continue
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func TestCSVFeedReader(t *testing.T) {
+	rows, failed := readAll(t, catalog.NewCSVFeedReader(strings.NewReader(csvFeed)))
+
+	require.Len(t, rows, 4)
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, "SKU-1", rows[0].Get("id"))
+	assert.Equal(t, "Shirt, blue", rows[0].Get("title"))
+	assert.NotContains(t, rows[1].Values, "price")
+
+	require.Len(t, failed, 1)
+	assert.Equal(t, 5, failed[0].Line)
+}
+
+func TestJSONLinesFeedReader(t *testing.T) {
+	feed := `{"id": "SKU-1", "price": 10.5, "tags": ["a", "b"], "brand": {"name": "Acme"}, "gtin": null}
+
+not json
+{"id": "SKU-2", "published": false}
+`
+	rows, failed := readAll(t, catalog.NewJSONLinesFeedReader(strings.NewReader(feed)))
+
+	require.Len(t, rows, 2)
+	assert.Equal(t, 1, rows[0].Line)
+	assert.Equal(t, "10.5", rows[0].Get("price"))
+	assert.Equal(t, []string{"a", "b"}, rows[0].Values["tags"])
+	assert.Equal(t, "Acme", rows[0].Get("brand.name"))
+	assert.NotContains(t, rows[0].Values, "gtin")
+	assert.Equal(t, 4, rows[1].Line)
+	assert.Equal(t, "false", rows[1].Get("published"))
+
+	require.Len(t, failed, 1)
+	assert.Equal(t, 3, failed[0].Line)
+}
+
+func TestGoogleShoppingFeedReader(t *testing.T) {
+	rows, failed := readAll(t, catalog.NewGoogleShoppingFeedReader(strings.NewReader(googleShoppingFeed)))
+
+	require.Len(t, rows, 3)
+	assert.Empty(t, failed)
+	assert.Equal(t, "MUG-1", rows[0].Get("id"))
+	assert.Equal(t, "https://shop.example.com/mug", rows[0].Get("link"))
+	assert.Equal(t, []string{"https://img/tee-1.png", "https://img/tee-2.png"}, rows[1].Values["additional_image_link"])
+	assert.Equal(t, "4.99 USD", rows[1].Get("shipping.price"))
+	assert.Equal(t, "20.00 USD", rows[1].Get("price"))
+
+	_, err := catalog.NewGoogleShoppingFeedReader(strings.NewReader("<rss><item><title>Mug</item>")).Read()
+	assert.Error(t, err)
+}
+
+func TestFeedMappingItem(t *testing.T) {
+	rows, _ := readAll(t, catalog.NewCSVFeedReader(strings.NewReader(csvFeed)))
+
+	item, err := csvMapping().Item(rows[0])
+	require.NoError(t, err)
+	assert.Equal(t, "SKU-1", item.ExternalId)
+	assert.EqualValues(t, 129950, *item.Price)
+	assert.Equal(t, []string{"https://img/1.png", "https://img/2.png"}, item.Images)
+	assert.True(t, *item.Published)
+	assert.Equal(t, map[string]any{"sale": "yes"}, *item.CustomMetadata)
+
+	item, err = csvMapping().Item(rows[1])
+	require.NoError(t, err)
+	assert.Nil(t, item.Price)
+	assert.Nil(t, item.CustomMetadata)
+
+	_, err = csvMapping().Item(rows[2])
+	var rowErr catalog.FeedRowError
+	require.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, "SKU-3", rowErr.ExternalId)
+	assert.ErrorContains(t, err, "url: is required")
+	assert.ErrorContains(t, err, `published: "maybe" is not a boolean`)
+}
+
+func TestFeedMappingPrice(t *testing.T) {
+	for value, expected := range map[string]int64{
+		"15.99":     1599,
+		"$1,299.5":  129950,
+		"15.99 USD": 1599,
+		"EUR 7":     700,
+		"4.995":     500,
+		"4.994":     499,
+		"Rs. 100":   10000,
+	} {
+		row := catalog.FeedRow{Values: map[string][]string{"id": {"SKU"}, "title": {"T"}, "url": {"U"}, "price": {value}}}
+		item, err := csvMapping().Item(row)
+		if assert.NoError(t, err, value) {
+			assert.Equal(t, expected, *item.Price, value)
+		}
+	}
+
+	for _, value := range []string{"-5", "free", "1.2.3", "15,99", "1,29,900", "15.", ",99"} {
+		row := catalog.FeedRow{Values: map[string][]string{"id": {"SKU"}, "title": {"T"}, "url": {"U"}, "price": {value}}}
+		_, err := csvMapping().Item(row)
+		assert.ErrorContains(t, err, "price", value)
+	}
+}
+
+func TestFeedMappingDecimalComma(t *testing.T) {
+	mapping := csvMapping()
+	mapping.DecimalSeparator = ","
+
+	for value, expected := range map[string]int64{
+		"15,99":       1599,
+		"1.299,5 EUR": 129950,
+		"1.299":       129900,
+		"7":           700,
+	} {
+		row := catalog.FeedRow{Values: map[string][]string{"id": {"SKU"}, "title": {"T"}, "url": {"U"}, "price": {value}}}
+		item, err := mapping.Item(row)
+		if assert.NoError(t, err, value) {
+			assert.Equal(t, expected, *item.Price, value)
+		}
+	}
+
+	row := catalog.FeedRow{Values: map[string][]string{"id": {"SKU"}, "title": {"T"}, "url": {"U"}, "price": {"15.99"}}}
+	_, err := mapping.Item(row)
+	assert.ErrorContains(t, err, `"15.99" has a misplaced .`)
+}
+
+type FeedImportTestSuite struct {
+	suite.Suite
+	server *klaviyotest.Server
+}
+
+func (suit *FeedImportTestSuite) SetupTest() {
+	suit.server = klaviyotest.NewServer().WithJobPolls(1)
+}
+
+func (suit *FeedImportTestSuite) TearDownTest() {
+	suit.server.Close()
+}
+
+func (suit *FeedImportTestSuite) options() *catalog.FeedImportOptions {
+	opt := catalog.NewFeedImportOptionsWithDefaultValues()
+	opt.MaxItemsPerJob = 1
+	opt.Wait = &common.WaitOptions{Interval: time.Millisecond}
+
+	return opt
+}
+
+func (suit *FeedImportTestSuite) TestImportCSVFeed() {
+	reader := catalog.NewCSVFeedReader(strings.NewReader(csvFeed))
+
+	report, err := catalog.ImportFeed(context.Background(), suit.server.Client().Catalog, reader, csvMapping(), suit.options())
+	suit.Require().NoError(err)
+
+	suit.Equal(5, report.Rows)
+	suit.Equal([]string{"SKU-1", "SKU-2"}, report.Items)
+	suit.Len(report.Jobs, 2)
+
+	var lines []int
+	for _, failure := range report.Failed {
+		lines = append(lines, failure.Line)
+	}
+	// Scarf lacks an url and has an invalid boolean, socks have an extra field, the last row duplicates SKU-1
+	suit.Equal([]int{4, 4, 5, 6}, lines)
+	suit.Equal("external_id", report.Failed[3].Field)
+
+	item, ok := suit.server.CatalogItems().Get("$custom:::$default:::SKU-1")
+	suit.Require().True(ok)
+	suit.EqualValues(129950, *item.Attributes.Price)
+}
+
+func (suit *FeedImportTestSuite) TestImportGoogleShoppingFeed() {
+	reader := catalog.NewGoogleShoppingFeedReader(strings.NewReader(googleShoppingFeed))
+
+	report, err := catalog.ImportFeed(context.Background(), suit.server.Client().Catalog, reader, catalog.GoogleShoppingFeedMapping(), suit.options())
+	suit.Require().NoError(err)
+
+	suit.Empty(report.Failed)
+	suit.Equal([]string{"MUG-1", "TEE"}, report.Items)
+	suit.Equal([]string{"TEE-M", "TEE-S"}, report.Variants)
+
+	mug, ok := suit.server.CatalogItems().Get("$custom:::$default:::MUG-1")
+	suit.Require().True(ok)
+	suit.Equal(map[string]any{"brand": "Acme"}, *mug.Attributes.CustomMetadata)
+
+	variant, ok := suit.server.CatalogVariants().Get("$custom:::$default:::TEE-S")
+	suit.Require().True(ok)
+	suit.EqualValues(2000, *variant.Attributes.Price)
+	suit.Equal("$custom:::$default:::TEE", variant.Relationships.Item.Data[0].ID)
+}
+
+func (suit *FeedImportTestSuite) TestImportVariantBeforeItem() {
+	feed := `{"id": "TEE-S", "group": "TEE", "title": "Tee S", "url": "https://shop.example.com/tee?size=s", "price": "20.00"}
+{"id": "TEE", "title": "Tee", "url": "https://shop.example.com/tee"}
+`
+	mapping := catalog.FeedMapping{ExternalId: "id", Title: "title", Url: "url", Price: "price", ItemId: "group", Sku: "id", PriceDecimals: 2}
+
+	report, err := catalog.ImportFeed(context.Background(), suit.server.Client().Catalog, catalog.NewJSONLinesFeedReader(strings.NewReader(feed)), mapping, suit.options())
+	suit.Require().NoError(err)
+
+	suit.Empty(report.Failed)
+	suit.Equal([]string{"TEE"}, report.Items)
+	suit.Equal([]string{"TEE-S"}, report.Variants)
+
+	item, ok := suit.server.CatalogItems().Get("$custom:::$default:::TEE")
+	suit.Require().True(ok)
+	suit.Equal("Tee", *item.Attributes.Title)
+	suit.Nil(item.Attributes.Price)
+}
+
+func (suit *FeedImportTestSuite) TestImportDryRun() {
+	opt := suit.options()
+	opt.DryRun = true
+	reader := catalog.NewGoogleShoppingFeedReader(strings.NewReader(googleShoppingFeed))
+
+	report, err := catalog.ImportFeed(context.Background(), suit.server.Client().Catalog, reader, catalog.GoogleShoppingFeedMapping(), opt)
+	suit.Require().NoError(err)
+
+	suit.True(report.DryRun)
+	suit.Equal([]string{"MUG-1", "TEE"}, report.Items)
+	suit.Equal([]string{"TEE-S", "TEE-M"}, report.Variants)
+	suit.Empty(suit.server.Requests())
+}
+
+func (suit *FeedImportTestSuite) TestImportStopsOnUnreadableFeed() {
+	reader := catalog.NewGoogleShoppingFeedReader(strings.NewReader(strings.TrimSuffix(googleShoppingFeed, "</rss>")[:700]))
+
+	report, err := catalog.ImportFeed(context.Background(), suit.server.Client().Catalog, reader, catalog.GoogleShoppingFeedMapping(), suit.options())
+	suit.Error(err)
+	suit.Equal([]string{"MUG-1"}, report.Items)
+}
+
+func TestFeedImportTestSuite(t *testing.T) {
+	suite.Run(t, new(FeedImportTestSuite))
+}
@@ -0,0 +1,38 @@
+package catalog
+
+import (
+	"github.com/developertom01/klaviyo-go/common/filter"
+)
+
+var (
+	// Filter accepted by GetCatalogItems
+	GetCatalogItemsFilterSchema = filter.Schema{
+		Endpoint: "GET /api/catalog-items/",
+		Fields: map[string][]filter.Operator{
+			"ids":         {filter.OperatorAny},
+			"category.id": {filter.OperatorEquals},
+			"title":       {filter.OperatorContains},
+			"published":   {filter.OperatorEquals},
+		},
+	}
+
+	// Filter accepted by GetCatalogVariants
+	GetCatalogVariantsFilterSchema = filter.Schema{
+		Endpoint: "GET /api/catalog-variants/",
+		Fields: map[string][]filter.Operator{
+			"ids":       {filter.OperatorAny},
+			"item.id":   {filter.OperatorEquals},
+			"sku":       {filter.OperatorEquals},
+			"title":     {filter.OperatorContains},
+			"published": {filter.OperatorEquals},
+		},
+	}
+
+	// Filter accepted by GetCreateItemsJobs, GetUpdateItemsJobs and GetDeleteItemsJobs
+	GetBulkItemsJobsFilterSchema = filter.Schema{
+		Endpoint: "GET /api/catalog-item-bulk-jobs/",
+		Fields: map[string][]filter.Operator{
+			"status": {filter.OperatorEquals},
+		},
+	}
+)
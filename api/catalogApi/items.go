@@ -6,9 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/instrumentation"
 	"github.com/developertom01/klaviyo-go/models"
 )
 
@@ -21,13 +21,13 @@ type (
 		//Create a new catalog item.
 		CreateCatalogItem(ctx context.Context, payload CreateCatalogItemPayload) (*models.CatalogItemResource, error)
 		//Get a specific catalog item with the given item ID.
-		//CatalogItemId: The catalog item ID is a compound ID (string), with format: {integration}:::{catalog}:::{external_id}. Currently, the only supported integration type is $custom, and the only supported catalog is $default.
-		GetCatalogItem(ctx context.Context, catalogItemId string, options *GetCatalogItemApiOptions) (*models.CatalogItemResource, error)
+		//CatalogItemId: The catalog item ID is a compound ID, with format: {integration}:::{catalog}:::{external_id}, eg. models.NewCatalogID(externalId). Currently, the only supported integration type is $custom, and the only supported catalog is $default.
+		GetCatalogItem(ctx context.Context, catalogItemId models.CatalogID, options *GetCatalogItemApiOptions) (*models.CatalogItemResource, error)
 		//Update a catalog item with the given item ID.
-		UpdateCatalogItem(ctx context.Context, catalogItemId string, payload UpdateCatalogItemPayload) (*models.CatalogItemResource, error)
+		UpdateCatalogItem(ctx context.Context, catalogItemId models.CatalogID, payload UpdateCatalogItemPayload) (*models.CatalogItemResource, error)
 		//Delete a catalog item with the given item ID.
-		//The catalog item ID is a compound ID (string), with format: {integration}:::{catalog}:::{external_id}. Currently, the only supported integration type is $custom, and the only supported catalog is $default.
-		DeleteCatalogItem(ctx context.Context, catalogItemId string) error
+		//The catalog item ID is a compound ID, with format: {integration}:::{catalog}:::{external_id}, eg. models.NewCatalogID(externalId). Currently, the only supported integration type is $custom, and the only supported catalog is $default.
+		DeleteCatalogItem(ctx context.Context, catalogItemId models.CatalogID) error
 		//Get all catalog item bulk create jobs.
 		//Returns a maximum of 100 jobs per request.
 		GetCreateItemsJobs(ctx context.Context, options *GetBulkItemsJobsOptions) (*models.CatalogItemBulkJobCollectionResource, error)
@@ -61,51 +61,39 @@ type (
 )
 
 type CatalogItemApiOptions struct {
-	CatalogItemFields    []models.CatalogItemField         //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
-	CatalogVariantFields []models.CatalogVariantField      //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
-	PageCursor           *string                           //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#pagination
-	SortField            *models.CatalogItemSortField      //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sorting
-	Include              []models.CatalogItemIncludedField //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#relationships
+	CatalogItemFields    models.Fields[models.CatalogItemField]    //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
+	CatalogVariantFields models.Fields[models.CatalogVariantField] //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
+	PageCursor           *string                                   //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#pagination
+	SortField            models.Sort[models.CatalogItemSortField]  //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sorting
+	Include              []models.CatalogItemIncludedField         //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#relationships
 }
 
-func buildCatalogItemApiOptionsParams(filterString string, options *CatalogItemApiOptions) string {
+func buildCatalogItemApiOptionsParams(filterString string, options *CatalogItemApiOptions) *common.Query {
+	query := common.NewQuery().Filter(filterString)
 	if options == nil {
-		return filterString
+		return query
 	}
-	var params = []string{filterString}
 
-	if options.CatalogItemFields != nil {
-		params = append(params, models.BuildCatalogItemFieldParams(options.CatalogItemFields))
-	}
-
-	if options.CatalogVariantFields != nil {
-		params = append(params, models.BuildCatalogVariantFieldParams((options.CatalogVariantFields)))
-	}
-
-	if options.Include != nil {
-		var includedStr = make([]string, 0)
-
-		for _, inc := range options.Include {
-			includedStr = append(includedStr, string(inc))
-		}
-		params = append(params, fmt.Sprintf("include=%s", strings.Join(includedStr, ",")))
-	}
-
-	if options.SortField != nil {
-		params = append(params, fmt.Sprintf("sort=%s", *options.SortField))
-	}
+	query.
+		Apply(options.CatalogItemFields, options.CatalogVariantFields, options.SortField).
+		Include(common.StringsOf(options.Include)...)
 
 	if options.PageCursor != nil {
-		params = append(params, fmt.Sprintf("page[cursor]=%s", *options.PageCursor))
-
+		query.PageCursor(*options.PageCursor)
 	}
 
-	return strings.Join(params, "&")
+	return query
 }
 
 func (api catalogApi) GetCatalogItems(ctx context.Context, filterString string, options *CatalogItemApiOptions) (*models.CatalogItemCollectionResource, error) {
-	queryParams := buildCatalogItemApiOptionsParams(filterString, options)
-	url := fmt.Sprintf("%s/api/catalog-items/?%s", api.baseApiUrl, queryParams)
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.GetCatalogItems")
+
+	if err := common.ValidateFilter(api.session, GetCatalogItemsFilterSchema, filterString); err != nil {
+		return nil, err
+	}
+
+	query := buildCatalogItemApiOptionsParams(filterString, options)
+	url := query.URL(fmt.Sprintf("%s/api/catalog-items/", api.baseApiUrl))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
@@ -125,6 +113,8 @@ func (api catalogApi) GetCatalogItems(ctx context.Context, filterString string,
 }
 
 func (api *catalogApi) CreateCatalogItem(ctx context.Context, payload CreateCatalogItemPayload) (*models.CatalogItemResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.CreateCatalogItem")
+
 	url := fmt.Sprintf("%s/api/catalog-items/", api.baseApiUrl)
 
 	reqData, err := json.Marshal(payload)
@@ -133,7 +123,7 @@ func (api *catalogApi) CreateCatalogItem(ctx context.Context, payload CreateCata
 	}
 	reqDataBuffer := bytes.NewBuffer(reqData)
 
-	req, err := http.NewRequest(http.MethodPost, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -150,40 +140,31 @@ func (api *catalogApi) CreateCatalogItem(ctx context.Context, payload CreateCata
 }
 
 type GetCatalogItemApiOptions struct {
-	CatalogItemFields    []models.CatalogItemField         //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
-	CatalogVariantFields []models.CatalogVariantField      //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
-	Include              []models.CatalogItemIncludedField //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#relationships
+	CatalogItemFields    models.Fields[models.CatalogItemField]    //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
+	CatalogVariantFields models.Fields[models.CatalogVariantField] //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
+	Include              []models.CatalogItemIncludedField         //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#relationships
 }
 
-func buildGetCatalogItemApiOptionsParams(options *GetCatalogItemApiOptions) string {
+func buildGetCatalogItemApiOptionsParams(options *GetCatalogItemApiOptions) *common.Query {
+	query := common.NewQuery()
 	if options == nil {
-		return ""
+		return query
 	}
-	var params = []string{}
 
-	if options.CatalogItemFields != nil {
-		params = append(params, models.BuildCatalogItemFieldParams(options.CatalogItemFields))
-	}
-
-	if options.CatalogVariantFields != nil {
-		params = append(params, models.BuildCatalogVariantFieldParams((options.CatalogVariantFields)))
-	}
+	return query.
+		Apply(options.CatalogItemFields, options.CatalogVariantFields).
+		Include(common.StringsOf(options.Include)...)
+}
 
-	if options.Include != nil {
-		var includedStr = make([]string, 0)
+func (api *catalogApi) GetCatalogItem(ctx context.Context, catalogItemId models.CatalogID, options *GetCatalogItemApiOptions) (*models.CatalogItemResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.GetCatalogItem")
 
-		for _, inc := range options.Include {
-			includedStr = append(includedStr, string(inc))
-		}
-		params = append(params, fmt.Sprintf("include=%s", strings.Join(includedStr, ",")))
+	if err := catalogItemId.Validate(); err != nil {
+		return nil, err
 	}
 
-	return strings.Join(params, "&")
-}
-
-func (api *catalogApi) GetCatalogItem(ctx context.Context, catalogItemId string, options *GetCatalogItemApiOptions) (*models.CatalogItemResource, error) {
-	queryParams := buildGetCatalogItemApiOptionsParams(options)
-	url := fmt.Sprintf("%s/api/catalog-items/%s/?%s", api.baseApiUrl, catalogItemId, queryParams)
+	query := buildGetCatalogItemApiOptionsParams(options)
+	url := query.URL(fmt.Sprintf("%s/api/catalog-items/%s/", api.baseApiUrl, catalogItemId.PathEscape()))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
@@ -202,8 +183,14 @@ func (api *catalogApi) GetCatalogItem(ctx context.Context, catalogItemId string,
 	return &catalogItem, nil
 }
 
-func (api catalogApi) UpdateCatalogItem(ctx context.Context, catalogItemId string, payload UpdateCatalogItemPayload) (*models.CatalogItemResource, error) {
-	url := fmt.Sprintf("%s/api/catalog-items/%s/", api.baseApiUrl, catalogItemId)
+func (api catalogApi) UpdateCatalogItem(ctx context.Context, catalogItemId models.CatalogID, payload UpdateCatalogItemPayload) (*models.CatalogItemResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.UpdateCatalogItem")
+
+	if err := catalogItemId.Validate(); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/api/catalog-items/%s/", api.baseApiUrl, catalogItemId.PathEscape())
 
 	reqData, err := json.Marshal(payload)
 	if err != nil {
@@ -211,7 +198,7 @@ func (api catalogApi) UpdateCatalogItem(ctx context.Context, catalogItemId strin
 	}
 	reqDataBuffer := bytes.NewBuffer(reqData)
 
-	req, err := http.NewRequest(http.MethodPatch, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -227,9 +214,15 @@ func (api catalogApi) UpdateCatalogItem(ctx context.Context, catalogItemId strin
 	return &catalogItemResource, err
 }
 
-func (api *catalogApi) DeleteCatalogItem(ctx context.Context, catalogItemId string) error {
-	url := fmt.Sprintf("%s/api/catalog-items/%s/", api.baseApiUrl, catalogItemId)
-	req, err := http.NewRequest(http.MethodDelete, url, nil)
+func (api *catalogApi) DeleteCatalogItem(ctx context.Context, catalogItemId models.CatalogID) error {
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.DeleteCatalogItem")
+
+	if err := catalogItemId.Validate(); err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/catalog-items/%s/", api.baseApiUrl, catalogItemId.PathEscape())
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return err
 	}
@@ -239,36 +232,40 @@ func (api *catalogApi) DeleteCatalogItem(ctx context.Context, catalogItemId stri
 }
 
 type GetBulkItemsJobsOptions struct {
-	Filter         *string                          //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#filtering Allowed field(s)/operator(s):status: equals
-	PageCursor     *string                          //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#pagination
-	ItemJobsFields []models.CatalogItemBulkJobField //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
+	Filter         *string                                       //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#filtering Allowed field(s)/operator(s):status: equals, validated before sending
+	PageCursor     *string                                       //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#pagination
+	ItemJobsFields models.Fields[models.CatalogItemBulkJobField] //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
 }
 
-// func
-func buildGetBulkItemsJobsOptionsParams(options *GetBulkItemsJobsOptions) string {
+// Builds query of bulk jobs listing. `jobType` is the resource type of the jobs, eg. catalog-item-bulk-create-job
+func buildGetBulkItemsJobsOptionsParams(jobType string, options *GetBulkItemsJobsOptions) *common.Query {
+	query := common.NewQuery()
 	if options == nil {
-		return ""
+		return query
 	}
-	var params = []string{}
 
 	if options.Filter != nil {
-		params = append(params, *options.Filter)
+		query.Filter(*options.Filter)
 	}
 
 	if options.PageCursor != nil {
-		params = append(params, fmt.Sprintf("page[cursor]=%s", *options.PageCursor))
-	}
-
-	if options.ItemJobsFields != nil {
-		params = append(params, models.BuildCatalogItemBulkJobFieldParams(options.ItemJobsFields))
+		query.PageCursor(*options.PageCursor)
 	}
 
-	return strings.Join(params, "&")
+	return query.Fields(jobType, options.ItemJobsFields.Strings()...)
 }
 
 func (api catalogApi) GetCreateItemsJobs(ctx context.Context, options *GetBulkItemsJobsOptions) (*models.CatalogItemBulkJobCollectionResource, error) {
-	queryParams := buildGetBulkItemsJobsOptionsParams(options)
-	url := fmt.Sprintf("%s/api/catalog-item-bulk-create-jobs/?%s", api.baseApiUrl, queryParams)
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.GetCreateItemsJobs")
+
+	if options != nil && options.Filter != nil {
+		if err := common.ValidateFilter(api.session, GetBulkItemsJobsFilterSchema, *options.Filter); err != nil {
+			return nil, err
+		}
+	}
+
+	query := buildGetBulkItemsJobsOptionsParams("catalog-item-bulk-create-job", options)
+	url := query.URL(fmt.Sprintf("%s/api/catalog-item-bulk-create-jobs/", api.baseApiUrl))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
@@ -288,6 +285,8 @@ func (api catalogApi) GetCreateItemsJobs(ctx context.Context, options *GetBulkIt
 }
 
 func (api *catalogApi) SpawnCreateItemsJob(ctx context.Context, payload SpawnCreateItemsJobPayload) (*models.CatalogItemBulkJobResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.SpawnCreateItemsJob")
+
 	url := fmt.Sprintf("%s/api/catalog-item-bulk-create-jobs/", api.baseApiUrl)
 
 	reqData, err := json.Marshal(payload)
@@ -296,7 +295,7 @@ func (api *catalogApi) SpawnCreateItemsJob(ctx context.Context, payload SpawnCre
 	}
 	reqDataBuffer := bytes.NewBuffer(reqData)
 
-	req, err := http.NewRequest(http.MethodPost, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -313,41 +312,30 @@ func (api *catalogApi) SpawnCreateItemsJob(ctx context.Context, payload SpawnCre
 }
 
 type GetBulkItemsJobOptions struct {
-	ItemJobsFields     []models.CatalogItemBulkJobField        //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
-	CatalogItemsFields []models.CatalogItemField               //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
-	Include            []models.CatalogItemBulkJobIncludeField //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#relationships
+	ItemJobsFields     models.Fields[models.CatalogItemBulkJobField] //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
+	CatalogItemsFields models.Fields[models.CatalogItemField]        //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
+	Include            []models.CatalogItemBulkJobIncludeField       //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#relationships
 
 }
 
-func buildGetBulkItemsJobOptionsParams(options *GetBulkItemsJobOptions) string {
+// Builds query of a bulk job. `jobType` is the resource type of the job, eg. catalog-item-bulk-create-job
+func buildGetBulkItemsJobOptionsParams(jobType string, options *GetBulkItemsJobOptions) *common.Query {
+	query := common.NewQuery()
 	if options == nil {
-		return ""
+		return query
 	}
-	var params = []string{}
 
-	if options.ItemJobsFields != nil {
-		params = append(params, models.BuildCatalogItemBulkJobFieldParams(options.ItemJobsFields))
-	}
-
-	if options.CatalogItemsFields != nil {
-		params = append(params, models.BuildCatalogItemFieldParams((options.CatalogItemsFields)))
-	}
-
-	if options.Include != nil {
-		var includedStr = make([]string, 0)
-
-		for _, inc := range options.Include {
-			includedStr = append(includedStr, string(inc))
-		}
-		params = append(params, fmt.Sprintf("include=%s", strings.Join(includedStr, ",")))
-	}
-
-	return strings.Join(params, "&")
+	return query.
+		Fields(jobType, options.ItemJobsFields.Strings()...).
+		Apply(options.CatalogItemsFields).
+		Include(common.StringsOf(options.Include)...)
 }
 
 func (api *catalogApi) GetCreateItemsJob(ctx context.Context, createBuildItemJobId string, options *GetBulkItemsJobOptions) (*models.CatalogItemBulkJobResource, error) {
-	queryParams := buildGetBulkItemsJobOptionsParams(options)
-	url := fmt.Sprintf("%s/api/catalog-item-bulk-create-jobs/%s/?%s", api.baseApiUrl, createBuildItemJobId, queryParams)
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.GetCreateItemsJob")
+
+	query := buildGetBulkItemsJobOptionsParams("catalog-item-bulk-create-job", options)
+	url := query.URL(fmt.Sprintf("%s/api/catalog-item-bulk-create-jobs/%s/", api.baseApiUrl, createBuildItemJobId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
@@ -367,8 +355,16 @@ func (api *catalogApi) GetCreateItemsJob(ctx context.Context, createBuildItemJob
 }
 
 func (api *catalogApi) GetUpdateItemsJobs(ctx context.Context, options *GetBulkItemsJobsOptions) (*models.CatalogItemBulkJobCollectionResource, error) {
-	queryParams := buildGetBulkItemsJobsOptionsParams(options)
-	url := fmt.Sprintf("%s/api/catalog-item-bulk-update-jobs/?%s", api.baseApiUrl, queryParams)
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.GetUpdateItemsJobs")
+
+	if options != nil && options.Filter != nil {
+		if err := common.ValidateFilter(api.session, GetBulkItemsJobsFilterSchema, *options.Filter); err != nil {
+			return nil, err
+		}
+	}
+
+	query := buildGetBulkItemsJobsOptionsParams("catalog-item-bulk-update-job", options)
+	url := query.URL(fmt.Sprintf("%s/api/catalog-item-bulk-update-jobs/", api.baseApiUrl))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
@@ -388,6 +384,8 @@ func (api *catalogApi) GetUpdateItemsJobs(ctx context.Context, options *GetBulkI
 }
 
 func (api *catalogApi) SpawnUpdateItemsJob(ctx context.Context, payload SpawnUpdateItemsJobPayload) (*models.CatalogItemBulkJobResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.SpawnUpdateItemsJob")
+
 	url := fmt.Sprintf("%s/api/catalog-item-bulk-update-jobs/", api.baseApiUrl)
 
 	reqData, err := json.Marshal(payload)
@@ -396,7 +394,7 @@ func (api *catalogApi) SpawnUpdateItemsJob(ctx context.Context, payload SpawnUpd
 	}
 	reqDataBuffer := bytes.NewBuffer(reqData)
 
-	req, err := http.NewRequest(http.MethodPost, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -413,8 +411,10 @@ func (api *catalogApi) SpawnUpdateItemsJob(ctx context.Context, payload SpawnUpd
 }
 
 func (api *catalogApi) GetUpdateItemsJob(ctx context.Context, buildUpdateJobId string, options *GetBulkItemsJobOptions) (*models.CatalogItemBulkJobResource, error) {
-	queryParams := buildGetBulkItemsJobOptionsParams(options)
-	url := fmt.Sprintf("%s/api/catalog-item-bulk-update-jobs/%s/?%s", api.baseApiUrl, buildUpdateJobId, queryParams)
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.GetUpdateItemsJob")
+
+	query := buildGetBulkItemsJobOptionsParams("catalog-item-bulk-update-job", options)
+	url := query.URL(fmt.Sprintf("%s/api/catalog-item-bulk-update-jobs/%s/", api.baseApiUrl, buildUpdateJobId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
@@ -434,8 +434,16 @@ func (api *catalogApi) GetUpdateItemsJob(ctx context.Context, buildUpdateJobId s
 }
 
 func (api *catalogApi) GetDeleteItemsJobs(ctx context.Context, options *GetBulkItemsJobsOptions) (*models.CatalogItemBulkJobCollectionResource, error) {
-	queryParams := buildGetBulkItemsJobsOptionsParams(options)
-	url := fmt.Sprintf("%s/api/catalog-item-bulk-delete-jobs/?%s", api.baseApiUrl, queryParams)
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.GetDeleteItemsJobs")
+
+	if options != nil && options.Filter != nil {
+		if err := common.ValidateFilter(api.session, GetBulkItemsJobsFilterSchema, *options.Filter); err != nil {
+			return nil, err
+		}
+	}
+
+	query := buildGetBulkItemsJobsOptionsParams("catalog-item-bulk-delete-job", options)
+	url := query.URL(fmt.Sprintf("%s/api/catalog-item-bulk-delete-jobs/", api.baseApiUrl))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
@@ -455,6 +463,8 @@ func (api *catalogApi) GetDeleteItemsJobs(ctx context.Context, options *GetBulkI
 }
 
 func (api *catalogApi) SpawnDeleteItemsJob(ctx context.Context, payload SpawnDeleteItemsJobPayload) (*models.CatalogItemBulkJobResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.SpawnDeleteItemsJob")
+
 	url := fmt.Sprintf("%s/api/catalog-item-bulk-delete-jobs/", api.baseApiUrl)
 
 	reqData, err := json.Marshal(payload)
@@ -463,7 +473,7 @@ func (api *catalogApi) SpawnDeleteItemsJob(ctx context.Context, payload SpawnDel
 	}
 	reqDataBuffer := bytes.NewBuffer(reqData)
 
-	req, err := http.NewRequest(http.MethodPost, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -480,8 +490,10 @@ func (api *catalogApi) SpawnDeleteItemsJob(ctx context.Context, payload SpawnDel
 }
 
 func (api *catalogApi) GetDeleteItemsJob(ctx context.Context, buildDeleteJobId string, options *GetBulkItemsJobOptions) (*models.CatalogItemBulkJobResource, error) {
-	queryParams := buildGetBulkItemsJobOptionsParams(options)
-	url := fmt.Sprintf("%s/api/catalog-item-bulk-delete-jobs/%s/?%s", api.baseApiUrl, buildDeleteJobId, queryParams)
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.GetDeleteItemsJob")
+
+	query := buildGetBulkItemsJobOptionsParams("catalog-item-bulk-delete-job", options)
+	url := query.URL(fmt.Sprintf("%s/api/catalog-item-bulk-delete-jobs/%s/", api.baseApiUrl, buildDeleteJobId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
@@ -0,0 +1,72 @@
+package catalog_test
+
+import (
+	"context"
+	catalog "github.com/developertom01/klaviyo-go/api/catalogApi"
+	"testing"
+
+	"github.com/developertom01/klaviyo-go/fixtures"
+	"github.com/developertom01/klaviyo-go/klaviyotest"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogItemIdIsPathEscaped(t *testing.T) {
+	server := klaviyotest.NewServer()
+	defer server.Close()
+
+	item := fixtures.New(fixtures.DEFAULT_SEED).CatalogItem().WithExternalID("shirts/blue M").Build()
+	server.CatalogItems().Put(item)
+	id := models.NewCatalogID("shirts/blue M")
+
+	res, err := server.Client().Catalog.GetCatalogItem(context.Background(), id, nil)
+	require.NoError(t, err)
+	assert.Equal(t, id.String(), res.Data.ID)
+
+	requests := server.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "/api/catalog-items/"+id.String()+"/", requests[0].Path)
+
+	require.NoError(t, server.Client().Catalog.DeleteCatalogItem(context.Background(), id))
+	assert.Equal(t, 0, server.CatalogItems().Len())
+}
+
+func TestCatalogItemIdIsValidated(t *testing.T) {
+	server := klaviyotest.NewServer()
+	defer server.Close()
+
+	_, err := server.Client().Catalog.GetCatalogItem(context.Background(), models.CatalogID{}, nil)
+	assert.ErrorIs(t, err, models.ErrInvalidCatalogID)
+	assert.Empty(t, server.Requests())
+}
+
+func TestGetCatalogItemIncludesVariants(t *testing.T) {
+	api, client := newMockedCatalogApi(t, `{"data": {
+		"type": "catalog-item", "id": "$custom:::$default:::shirt", "attributes": {},
+		"relationships": {"variants": {"data": [{"type": "catalog-variant", "id": "$custom:::$default:::shirt-blue"}]}}
+	}}`)
+
+	res, err := api.GetCatalogItem(context.Background(), models.NewCatalogID("shirt"), &catalog.GetCatalogItemApiOptions{
+		Include: []models.CatalogItemIncludedField{models.CatalogItemIncludedFieldVariant},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "variants", sentRequest(client).URL.Query().Get("include"))
+	assert.Equal(t, []models.RelationshipData{{Type: "catalog-variant", ID: "$custom:::$default:::shirt-blue"}}, res.Data.Relationship.Variant.Data)
+}
+
+func TestGetCreateItemsJobIncludesItems(t *testing.T) {
+	api, client := newMockedCatalogApi(t, `{"data": {
+		"type": "catalog-item-bulk-create-job", "id": "job-id", "attributes": {"status": "complete"},
+		"relationships": {"items": {"data": [{"type": "catalog-item", "id": "$custom:::$default:::shirt"}]}}
+	}}`)
+
+	res, err := api.GetCreateItemsJob(context.Background(), "job-id", &catalog.GetBulkItemsJobOptions{
+		Include: []models.CatalogItemBulkJobIncludeField{models.CatalogItemBulkJobIncludeFieldItem},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "items", sentRequest(client).URL.Query().Get("include"))
+	assert.Equal(t, []models.RelationshipData{{Type: "catalog-item", ID: "$custom:::$default:::shirt"}}, res.Data.Relationships.Items.Data)
+}
@@ -37,7 +37,7 @@ type (
 
 type (
 	CreateCatalogItemVariantPayload struct {
-		Data CreateCatalogItemVariantPayloadData
+		Data CreateCatalogItemVariantPayloadData `json:"data"`
 	}
 
 	CreateCatalogItemVariantPayloadData struct {
@@ -47,7 +47,7 @@ type (
 	}
 
 	CreateCatalogItemVariantDataRelationshipsPayload struct {
-		Item models.RelationshipsRequestPayload `json:"items"`
+		Item models.RelationshipsRequestPayload `json:"item"`
 	}
 
 	CreateCatalogItemVariantAttributesPayload struct {
@@ -64,7 +64,7 @@ type (
 		InventoryPolicy   *int64          `json:"inventory_policy,omitempty"`
 		InventoryQuantity int64           `json:"inventory_quantity"`            //The quantity of the catalog item variant currently in stock.
 		Price             int64           `json:"price"`                         //This field can be used to set the price on the catalog item variant, which is what gets displayed for the item variant when included in emails. For most price-update use cases, you will also want to update the price on any parent items using the Update Catalog Item Endpoint.
-		Url               int64           `json:"url"`                           //URL pointing to the location of the catalog item variant on your website.
+		Url               string          `json:"url"`                           //URL pointing to the location of the catalog item variant on your website.
 		ImageFullUrl      *string         `json:"image_full_url,omitempty"`      // URL pointing to the location of a full image of the catalog item variant.
 		ImageThumbnailUrl *string         `json:"image_thumbnail_url,omitempty"` //URL pointing to the location of an image thumbnail of the catalog item variant
 		Images            []string        `json:"images,omitempty"`              //List of URLs pointing to the locations of images of the catalog item variant.
@@ -80,7 +80,7 @@ type (
 	}
 	UpdateCatalogItemPayloadData struct {
 		Type         string                               `json:"type"` // catalog-item
-		ID           string                               `json:"id"`   //The catalog item ID is a compound ID (string), with format: {integration}:::{catalog}:::{external_id}. Currently, the only supported integration type is $custom, and the only supported catalog is $default.
+		ID           models.CatalogID                     `json:"id"`   //The catalog item ID is a compound ID, with format: {integration}:::{catalog}:::{external_id}. Currently, the only supported integration type is $custom, and the only supported catalog is $default.
 		Attributes   UpdateCatalogItemPayloadAttributes   `json:"attributes"`
 		Relationship UpdateCatalogItemRelationshipPayload `json:"relationships"`
 	}
@@ -147,8 +147,8 @@ type (
 	}
 
 	DeleteCatalogItemPayload struct {
-		Type string `json:"type"` //catalog-item
-		ID   string `json:"id"`   //The catalog item ID is a compound ID (string), with format: {integration}:::{catalog}:::{external_id}. Currently, the only supported integration type is $custom, and the only supported catalog is $default.
+		Type string           `json:"type"` //catalog-item
+		ID   models.CatalogID `json:"id"`   //The catalog item ID is a compound ID, with format: {integration}:::{catalog}:::{external_id}. Currently, the only supported integration type is $custom, and the only supported catalog is $default.
 	}
 )
 
@@ -158,7 +158,7 @@ type (
 	}
 	UpdateCatalogVariantPayloadData struct {
 		Type       string                                `json:"type"` // catalog-variant
-		ID         string                                `json:"id"`   //The catalog variant ID is a compound ID (string), with format: {integration}:::{catalog}:::{external_id}. Currently, the only supported integration type is $custom, and the only supported catalog is $default.
+		ID         models.CatalogID                      `json:"id"`   //The catalog variant ID is a compound ID, with format: {integration}:::{catalog}:::{external_id}. Currently, the only supported integration type is $custom, and the only supported catalog is $default.
 		Attributes UpdateCatalogVariantPayloadAttributes `json:"attributes"`
 	}
 
@@ -0,0 +1,581 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/exceptions"
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Limits of catalog item bulk jobs, see SpawnCreateItemsJob
+const (
+	MAX_BULK_JOB_ITEMS        = 100
+	MAX_BULK_JOB_PAYLOAD_SIZE = 5 << 20
+	MAX_BULK_JOBS_IN_PROGRESS = 500
+)
+
+// Bytes of a bulk job payload besides its items
+const bulkJobEnvelopeSize = 1 << 10
+
+var errOversizedItem = fmt.Errorf("catalog: item exceeds the bulk job payload size of %d bytes", MAX_BULK_JOB_PAYLOAD_SIZE)
+
+// Matches the item index of a bulk job error pointer, eg. /data/attributes/items/data/3
+var bulkJobItemPointer = regexp.MustCompile(`^/data/attributes/(?:items/)?(?:data/)?(\d+)`)
+
+type SyncOperation string
+
+const (
+	SyncOperationCreate SyncOperation = "create"
+	SyncOperationUpdate SyncOperation = "update"
+	SyncOperationDelete SyncOperation = "delete"
+)
+
+type (
+	// Source of the items a catalog should hold, eg. a database table
+	SyncSource interface {
+		CatalogItems(ctx context.Context) ([]CreateCatalogItemAttributesPayload, error)
+	}
+
+	// Adapts a function to a SyncSource
+	SyncSourceFunc func(ctx context.Context) ([]CreateCatalogItemAttributesPayload, error)
+
+	// Options of Sync
+	SyncOptions struct {
+		DryRun          bool                //Diffs the catalog without spawning jobs
+		DeleteMissing   bool                //Deletes items missing from the source. Off by default, an empty source would empty the catalog
+		MaxItemsPerJob  int                 //Items of a bulk job, at most MAX_BULK_JOB_ITEMS
+		MaxJobsInFlight int                 //Bulk jobs in progress at one time, at most MAX_BULK_JOBS_IN_PROGRESS
+		Wait            *common.WaitOptions //Polling of the bulk jobs
+	}
+
+	// Outcome of Sync. Items are identified by external ID
+	SyncReport struct {
+		DryRun    bool
+		Created   []string
+		Updated   []string
+		Deleted   []string
+		Unchanged []string
+		Failed    []SyncFailure
+		Jobs      []string //IDs of the spawned bulk jobs
+	}
+
+	// Item that could not be synced
+	SyncFailure struct {
+		ExternalId string
+		Operation  SyncOperation
+		Err        error
+	}
+
+	// Item of the catalog before a Sync
+	existingCatalogItem struct {
+		id         models.CatalogID
+		attributes models.CatalogItemAttributes
+	}
+
+	// Bulk job operation of a single item
+	syncItem[T any] struct {
+		externalId string
+		payload    T
+	}
+)
+
+func (f SyncSourceFunc) CatalogItems(ctx context.Context) ([]CreateCatalogItemAttributesPayload, error) {
+	return f(ctx)
+}
+
+func NewSyncOptionsWithDefaultValues() *SyncOptions {
+	return &SyncOptions{
+		MaxItemsPerJob:  MAX_BULK_JOB_ITEMS,
+		MaxJobsInFlight: 10,
+		Wait:            common.NewWaitOptionsWithDefaultValues(),
+	}
+}
+
+func (f SyncFailure) Error() string {
+	return fmt.Sprintf("%s %s: %s", f.Operation, f.ExternalId, f.Err)
+}
+
+func (f SyncFailure) Unwrap() error {
+	return f.Err
+}
+
+// Syncs the $custom integration and $default catalog with the items of `source`. Existing items are fetched with GetCatalogItems
+// and diffed by ID, then created, updated and, when SyncOptions.DeleteMissing is set, deleted with bulk jobs respecting the limits of
+// SpawnCreateItemsJob. Items of other integrations and catalogs are left as they are.
+// Returns an error when the source or the catalog can not be read, failures of single items are reported in SyncReport.Failed
+func Sync(ctx context.Context, api CatalogItemApi, source SyncSource, opt *SyncOptions) (*SyncReport, error) {
+	opt = resolveSyncOptions(opt)
+
+	items, err := source.CatalogItems(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("catalog: reading sync source: %w", err)
+	}
+
+	existing, err := listCatalogItems(ctx, api)
+	if err != nil {
+		return nil, fmt.Errorf("catalog: listing catalog items: %w", err)
+	}
+
+	report := &SyncReport{DryRun: opt.DryRun}
+	creates, updates, deletes, err := diffCatalogItems(items, existing, opt.DeleteMissing, report)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.DryRun {
+		for _, item := range creates {
+			report.Created = append(report.Created, item.externalId)
+		}
+		for _, item := range updates {
+			report.Updated = append(report.Updated, item.externalId)
+		}
+		for _, item := range deletes {
+			report.Deleted = append(report.Deleted, item.externalId)
+		}
+		return report, nil
+	}
+
+	runner := newSyncRunner(api, opt, report)
+	scheduleSyncJobs(ctx, runner, SyncOperationCreate, creates, runner.create)
+	scheduleSyncJobs(ctx, runner, SyncOperationUpdate, updates, runner.update)
+	scheduleSyncJobs(ctx, runner, SyncOperationDelete, deletes, runner.delete)
+	runner.wg.Wait()
+
+	sort.Strings(report.Created)
+	sort.Strings(report.Updated)
+	sort.Strings(report.Deleted)
+	sort.Strings(report.Jobs)
+	sort.Slice(report.Failed, func(i, j int) bool {
+		return report.Failed[i].ExternalId < report.Failed[j].ExternalId
+	})
+
+	return report, ctx.Err()
+}
+
+func resolveSyncOptions(opt *SyncOptions) *SyncOptions {
+	defaults := NewSyncOptionsWithDefaultValues()
+	if opt == nil {
+		return defaults
+	}
+
+	resolved := *opt
+	if resolved.MaxItemsPerJob <= 0 || resolved.MaxItemsPerJob > MAX_BULK_JOB_ITEMS {
+		resolved.MaxItemsPerJob = defaults.MaxItemsPerJob
+	}
+	if resolved.MaxJobsInFlight <= 0 {
+		resolved.MaxJobsInFlight = defaults.MaxJobsInFlight
+	}
+	resolved.MaxJobsInFlight = min(resolved.MaxJobsInFlight, MAX_BULK_JOBS_IN_PROGRESS)
+	if resolved.Wait == nil {
+		resolved.Wait = defaults.Wait
+	}
+
+	return &resolved
+}
+
+// Returns the catalog items of the $custom integration and $default catalog keyed by ID
+func listCatalogItems(ctx context.Context, api CatalogItemApi) (map[models.CatalogID]existingCatalogItem, error) {
+	items := map[models.CatalogID]existingCatalogItem{}
+
+	options := &CatalogItemApiOptions{}
+	for {
+		page, err := api.GetCatalogItems(ctx, "", options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Data {
+			id, err := item.CatalogID()
+			if err != nil {
+				return nil, err
+			}
+			if !isSyncedCatalogID(id) {
+				continue
+			}
+			items[id] = existingCatalogItem{id: id, attributes: item.Attributes}
+		}
+
+		if page.Links.Next == nil || *page.Links.Next == "" {
+			return items, nil
+		}
+		options.PageCursor = page.Links.Next
+	}
+}
+
+// Reports whether `id` is in the integration and catalog Sync creates items in
+func isSyncedCatalogID(id models.CatalogID) bool {
+	return id.Integration == models.CATALOG_INTEGRATION_CUSTOM && id.Catalog == models.CATALOG_DEFAULT
+}
+
+// Splits source items into creates and updates of changed items, and existing items missing from the source into deletes
+func diffCatalogItems(
+	items []CreateCatalogItemAttributesPayload,
+	existing map[models.CatalogID]existingCatalogItem,
+	deleteMissing bool,
+	report *SyncReport,
+) (creates []syncItem[CreateCatalogItemPayload], updates []syncItem[UpdateCatalogItemPayload], deletes []syncItem[DeleteCatalogItemPayload], err error) {
+	seen := make(map[models.CatalogID]bool, len(items))
+	for i, item := range items {
+		if item.ExternalId == "" {
+			return nil, nil, nil, fmt.Errorf("catalog: sync source item %d has no external ID", i)
+		}
+		id := models.NewCatalogID(item.ExternalId)
+		if item.IntegrationType != nil && *item.IntegrationType != "" {
+			id.Integration = *item.IntegrationType
+		}
+		if item.CatalogType != nil && *item.CatalogType != "" {
+			id.Catalog = *item.CatalogType
+		}
+		if !isSyncedCatalogID(id) {
+			return nil, nil, nil, fmt.Errorf("catalog: sync source item %q is not in the %s integration and %s catalog", item.ExternalId, models.CATALOG_INTEGRATION_CUSTOM, models.CATALOG_DEFAULT)
+		}
+		if seen[id] {
+			return nil, nil, nil, fmt.Errorf("catalog: sync source holds external ID %q more than once", item.ExternalId)
+		}
+		seen[id] = true
+
+		current, ok := existing[id]
+		if !ok {
+			creates = append(creates, syncItem[CreateCatalogItemPayload]{externalId: item.ExternalId, payload: createCatalogItemPayloadOf(item)})
+			continue
+		}
+
+		attributes, changed, err := catalogItemChanges(item, current.attributes)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("catalog: diffing item %q: %w", item.ExternalId, err)
+		}
+		if !changed {
+			report.Unchanged = append(report.Unchanged, item.ExternalId)
+			continue
+		}
+		updates = append(updates, syncItem[UpdateCatalogItemPayload]{
+			externalId: item.ExternalId,
+			payload: UpdateCatalogItemPayload{
+				Data: UpdateCatalogItemPayloadData{Type: "catalog-item", ID: current.id, Attributes: attributes},
+			},
+		})
+	}
+
+	if deleteMissing {
+		for id, current := range existing {
+			if !seen[id] {
+				externalId := id.ExternalID
+				if current.attributes.ExternalId != nil {
+					externalId = *current.attributes.ExternalId
+				}
+				deletes = append(deletes, syncItem[DeleteCatalogItemPayload]{
+					externalId: externalId,
+					payload:    DeleteCatalogItemPayload{Type: "catalog-item", ID: current.id},
+				})
+			}
+		}
+		sort.Slice(deletes, func(i, j int) bool { return deletes[i].externalId < deletes[j].externalId })
+	}
+	sort.Strings(report.Unchanged)
+
+	return creates, updates, deletes, nil
+}
+
+// Returns bulk job payload creating `item`
+func createCatalogItemPayloadOf(item CreateCatalogItemAttributesPayload) CreateCatalogItemPayload {
+	return CreateCatalogItemPayload{
+		Data: CreateCatalogItemPayloadData{
+			Type:       "catalog-item",
+			Attributes: item,
+			Relationships: CreateCatalogItemDataRelationshipsPayload{
+				Categories: models.RelationshipsCollectionRequestPayload{Data: []models.RelationshipData{}},
+			},
+		},
+	}
+}
+
+// Returns update attributes holding the fields of `item` that differ from `current`
+func catalogItemChanges(item CreateCatalogItemAttributesPayload, current models.CatalogItemAttributes) (UpdateCatalogItemPayloadAttributes, bool, error) {
+	var (
+		attributes UpdateCatalogItemPayloadAttributes
+		changed    bool
+	)
+
+	if current.Title == nil || *current.Title != item.Title {
+		attributes.Title, changed = &item.Title, true
+	}
+	if current.Description == nil || *current.Description != item.Description {
+		attributes.Description, changed = &item.Description, true
+	}
+	if current.Url == nil || *current.Url != item.Url {
+		attributes.Url, changed = &item.Url, true
+	}
+	if item.Price != nil && (current.Price == nil || *current.Price != *item.Price) {
+		attributes.Price, changed = item.Price, true
+	}
+	if item.ImageFullUrl != nil && (current.ImageFullUrl == nil || *current.ImageFullUrl != *item.ImageFullUrl) {
+		attributes.ImageFullUrl, changed = item.ImageFullUrl, true
+	}
+	if item.ImageThumbnailUrl != nil && (current.ImageThumbnailUrl == nil || *current.ImageThumbnailUrl != *item.ImageThumbnailUrl) {
+		attributes.ImageThumbnailUrl, changed = item.ImageThumbnailUrl, true
+	}
+	if item.Images != nil && !slices.Equal(item.Images, current.Images) {
+		attributes.Images, changed = item.Images, true
+	}
+	if item.Published != nil && (current.Published == nil || *current.Published != *item.Published) {
+		attributes.Published, changed = item.Published, true
+	}
+
+	if item.CustomMetadata != nil {
+		var currentMetadata map[string]any
+		if current.CustomMetadata != nil {
+			currentMetadata = *current.CustomMetadata
+		}
+
+		// Compared as JSON as decoded numbers are float64
+		want, err := json.Marshal(*item.CustomMetadata)
+		if err != nil {
+			return attributes, false, err
+		}
+		got, err := json.Marshal(currentMetadata)
+		if err != nil {
+			return attributes, false, err
+		}
+		if string(want) != string(got) {
+			attributes.CustomMetadata, changed = *item.CustomMetadata, true
+		}
+	}
+
+	return attributes, changed, nil
+}
+
+// Groups items into chunks of at most `maxItems` items and MAX_BULK_JOB_PAYLOAD_SIZE bytes
+type syncBatcher[T any] struct {
+	maxItems int
+	chunk    []syncItem[T]
+	size     int
+}
+
+// Adds `item`, returning the current chunk when it is full. Fails when `item` does not fit in a bulk job on its own
+func (b *syncBatcher[T]) add(item syncItem[T]) ([]syncItem[T], error) {
+	data, err := json.Marshal(item.payload)
+	if err != nil {
+		return nil, fmt.Errorf("catalog: serializing item %q: %w", item.externalId, err)
+	}
+
+	itemSize := len(data) + 1
+	if bulkJobEnvelopeSize+itemSize > MAX_BULK_JOB_PAYLOAD_SIZE {
+		return nil, errOversizedItem
+	}
+
+	var full []syncItem[T]
+	if len(b.chunk) == b.maxItems || bulkJobEnvelopeSize+b.size+itemSize > MAX_BULK_JOB_PAYLOAD_SIZE {
+		full = b.flush()
+	}
+	b.chunk = append(b.chunk, item)
+	b.size += itemSize
+
+	return full, nil
+}
+
+// Returns the current chunk, nil when empty, and starts a new one
+func (b *syncBatcher[T]) flush() []syncItem[T] {
+	chunk := b.chunk
+	b.chunk, b.size = nil, 0
+	return chunk
+}
+
+// Runs bulk jobs of a Sync, at most SyncOptions.MaxJobsInFlight at one time
+type syncRunner struct {
+	api    CatalogItemApi
+	opt    *SyncOptions
+	slots  chan struct{}
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	report *SyncReport
+}
+
+func newSyncRunner(api CatalogItemApi, opt *SyncOptions, report *SyncReport) *syncRunner {
+	return &syncRunner{api: api, opt: opt, report: report, slots: make(chan struct{}, opt.MaxJobsInFlight)}
+}
+
+// Spawns a bulk job and waits for it
+type syncJob[T any] func(ctx context.Context, chunk []syncItem[T]) (*models.CatalogItemBulkJob, error)
+
+// Runs a bulk job per chunk of `items` in the background
+func scheduleSyncJobs[T any](ctx context.Context, runner *syncRunner, operation SyncOperation, items []syncItem[T], run syncJob[T]) {
+	batcher := &syncBatcher[T]{maxItems: runner.opt.MaxItemsPerJob}
+	for _, item := range items {
+		chunk, err := batcher.add(item)
+		if err != nil {
+			recordSyncFailures(runner, operation, []syncItem[T]{item}, err)
+			continue
+		}
+		if chunk != nil {
+			startSyncJob(ctx, runner, operation, chunk, run)
+		}
+	}
+
+	if chunk := batcher.flush(); chunk != nil {
+		startSyncJob(ctx, runner, operation, chunk, run)
+	}
+}
+
+// Runs the bulk job of `chunk` in the background once fewer than SyncOptions.MaxJobsInFlight jobs are in progress
+func startSyncJob[T any](ctx context.Context, runner *syncRunner, operation SyncOperation, chunk []syncItem[T], run syncJob[T]) {
+	select {
+	case <-ctx.Done():
+		recordSyncFailures(runner, operation, chunk, ctx.Err())
+		return
+	case runner.slots <- struct{}{}:
+	}
+
+	runner.wg.Add(1)
+	go func() {
+		defer func() {
+			<-runner.slots
+			runner.wg.Done()
+		}()
+
+		job, err := run(ctx, chunk)
+		recordSyncJob(runner, operation, chunk, job, err)
+	}()
+}
+
+// Records the outcome of the bulk job of `chunk`
+func recordSyncJob[T any](runner *syncRunner, operation SyncOperation, chunk []syncItem[T], job *models.CatalogItemBulkJob, err error) {
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+
+	report := runner.report
+	if job != nil && job.ID != "" {
+		report.Jobs = append(report.Jobs, job.ID)
+	}
+
+	itemErrs, attributed := bulkJobItemErrors(err, len(chunk))
+	for i, item := range chunk {
+		itemErr := itemErrs[i]
+		if err != nil && !attributed {
+			itemErr = err
+		}
+		if itemErr != nil {
+			report.Failed = append(report.Failed, SyncFailure{ExternalId: item.externalId, Operation: operation, Err: itemErr})
+			continue
+		}
+
+		switch operation {
+		case SyncOperationCreate:
+			report.Created = append(report.Created, item.externalId)
+		case SyncOperationUpdate:
+			report.Updated = append(report.Updated, item.externalId)
+		case SyncOperationDelete:
+			report.Deleted = append(report.Deleted, item.externalId)
+		}
+	}
+}
+
+// Returns errors of a bulk job keyed by the chunk index of the item they point at.
+// Reports false when `err` is not a job error or holds errors that can not be attributed to an item
+func bulkJobItemErrors(err error, size int) (map[int]error, bool) {
+	var jobErr exceptions.JobError
+	if !errors.As(err, &jobErr) || jobErr.Cancelled || len(jobErr.ApiErrors()) == 0 {
+		return nil, false
+	}
+
+	itemErrs := make(map[int]error)
+	for _, apiErr := range jobErr.ApiErrors() {
+		index, ok := bulkJobItemIndex(apiErr, size)
+		if !ok {
+			return nil, false
+		}
+		itemErrs[index] = errors.Join(itemErrs[index], fmt.Errorf("%s: %s", apiErr.Code, apiErr.Detail))
+	}
+
+	return itemErrs, true
+}
+
+// Returns the chunk index of the item an error of a bulk job points at
+func bulkJobItemIndex(apiErr exceptions.ApiError, size int) (int, bool) {
+	if apiErr.Source == nil || apiErr.Source.Pointer == nil {
+		return 0, false
+	}
+
+	match := bulkJobItemPointer.FindStringSubmatch(*apiErr.Source.Pointer)
+	if match == nil {
+		return 0, false
+	}
+	index, err := strconv.Atoi(match[1])
+	if err != nil || index >= size {
+		return 0, false
+	}
+
+	return index, true
+}
+
+func recordSyncFailures[T any](runner *syncRunner, operation SyncOperation, items []syncItem[T], err error) {
+	runner.mu.Lock()
+	defer runner.mu.Unlock()
+
+	for _, item := range items {
+		runner.report.Failed = append(runner.report.Failed, SyncFailure{ExternalId: item.externalId, Operation: operation, Err: err})
+	}
+}
+
+func (runner *syncRunner) create(ctx context.Context, chunk []syncItem[CreateCatalogItemPayload]) (*models.CatalogItemBulkJob, error) {
+	payload := SpawnCreateItemsJobPayload{Data: SpawnCreateItemsJobPayloadData{Type: "catalog-item-bulk-create-job"}}
+	for _, item := range chunk {
+		payload.Data.Attributes.Data = append(payload.Data.Attributes.Data, item.payload)
+	}
+
+	job, err := runner.api.SpawnCreateItemsJob(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	return runner.wait(ctx, job, runner.api.GetCreateItemsJob)
+}
+
+func (runner *syncRunner) update(ctx context.Context, chunk []syncItem[UpdateCatalogItemPayload]) (*models.CatalogItemBulkJob, error) {
+	payload := SpawnUpdateItemsJobPayload{Data: SpawnUpdateItemsJobPayloadData{Type: "catalog-item-bulk-update-job"}}
+	for _, item := range chunk {
+		payload.Data.Attributes.Data = append(payload.Data.Attributes.Data, item.payload)
+	}
+
+	job, err := runner.api.SpawnUpdateItemsJob(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	return runner.wait(ctx, job, runner.api.GetUpdateItemsJob)
+}
+
+func (runner *syncRunner) delete(ctx context.Context, chunk []syncItem[DeleteCatalogItemPayload]) (*models.CatalogItemBulkJob, error) {
+	payload := SpawnDeleteItemsJobPayload{Data: SpawnDeleteItemsJobPayloadData{Type: "catalog-item-bulk-delete-job"}}
+	for _, item := range chunk {
+		payload.Data.Attributes.Items = append(payload.Data.Attributes.Items, item.payload)
+	}
+
+	job, err := runner.api.SpawnDeleteItemsJob(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	return runner.wait(ctx, job, runner.api.GetDeleteItemsJob)
+}
+
+// Waits for a spawned bulk job with `get`, eg. GetCreateItemsJob
+func (runner *syncRunner) wait(
+	ctx context.Context,
+	spawned *models.CatalogItemBulkJobResource,
+	get func(ctx context.Context, jobId string, options *GetBulkItemsJobOptions) (*models.CatalogItemBulkJobResource, error),
+) (*models.CatalogItemBulkJob, error) {
+	job, err := common.WaitForJob(ctx, func(ctx context.Context) (*models.CatalogItemBulkJobResource, error) {
+		return get(ctx, spawned.Data.ID, nil)
+	}, runner.opt.Wait)
+	if job.ID == "" {
+		job = spawned.Data
+	}
+
+	return &job, err
+}
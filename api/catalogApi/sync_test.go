@@ -0,0 +1,244 @@
+package catalog_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	catalog "github.com/developertom01/klaviyo-go/api/catalogApi"
+	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/fixtures"
+	"github.com/developertom01/klaviyo-go/klaviyotest"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type SyncTestSuite struct {
+	suite.Suite
+	server   *klaviyotest.Server
+	existing []models.CatalogItem
+}
+
+func (suit *SyncTestSuite) SetupTest() {
+	suit.server = klaviyotest.NewServer().WithJobPolls(2)
+
+	generator := fixtures.New(fixtures.DEFAULT_SEED)
+	suit.existing = nil
+	for i := 0; i < 25; i++ {
+		item := generator.CatalogItem().WithExternalID(fmt.Sprintf("SKU-%02d", i)).Build()
+		suit.existing = append(suit.existing, suit.server.CatalogItems().Put(item))
+	}
+}
+
+func (suit *SyncTestSuite) TearDownTest() {
+	suit.server.Close()
+}
+
+func (suit *SyncTestSuite) options() *catalog.SyncOptions {
+	opt := catalog.NewSyncOptionsWithDefaultValues()
+	opt.MaxItemsPerJob = 2
+	opt.MaxJobsInFlight = 3
+	opt.Wait = &common.WaitOptions{Interval: time.Millisecond}
+
+	return opt
+}
+
+// Returns source holding the existing items SKU-00 to SKU-19, SKU-05 retitled, and new items SKU-25 to SKU-29
+func (suit *SyncTestSuite) source() catalog.SyncSource {
+	var items []catalog.CreateCatalogItemAttributesPayload
+	for _, item := range suit.existing[:20] {
+		items = append(items, sourceItemOf(item))
+	}
+	items[5].Title = "Retitled"
+
+	for i := 25; i < 30; i++ {
+		items = append(items, catalog.CreateCatalogItemAttributesPayload{
+			ExternalId: fmt.Sprintf("SKU-%02d", i),
+			Title:      fmt.Sprintf("Item %d", i),
+			Url:        fmt.Sprintf("https://shop.example.com/products/sku-%02d", i),
+		})
+	}
+
+	return catalog.SyncSourceFunc(func(ctx context.Context) ([]catalog.CreateCatalogItemAttributesPayload, error) {
+		return items, nil
+	})
+}
+
+func sourceItemOf(item models.CatalogItem) catalog.CreateCatalogItemAttributesPayload {
+	return catalog.CreateCatalogItemAttributesPayload{
+		ExternalId:        *item.Attributes.ExternalId,
+		Title:             *item.Attributes.Title,
+		Description:       *item.Attributes.Description,
+		Price:             item.Attributes.Price,
+		Url:               *item.Attributes.Url,
+		ImageFullUrl:      item.Attributes.ImageFullUrl,
+		ImageThumbnailUrl: item.Attributes.ImageThumbnailUrl,
+		Published:         item.Attributes.Published,
+	}
+}
+
+func externalIds(from int, to int) []string {
+	var ids []string
+	for i := from; i < to; i++ {
+		ids = append(ids, fmt.Sprintf("SKU-%02d", i))
+	}
+	return ids
+}
+
+func (suit *SyncTestSuite) bulkJobRequests() []klaviyotest.Request {
+	var requests []klaviyotest.Request
+	for _, request := range suit.server.Requests() {
+		if request.Method == http.MethodPost && strings.HasPrefix(request.Path, "/api/catalog-item-bulk-") {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// Stores a $shopify item sharing the external ID of SKU-20
+func (suit *SyncTestSuite) putShopifyItem() models.CatalogItem {
+	item := fixtures.CatalogItem().WithExternalID("SKU-20").Build()
+	item.ID = "$shopify:::$default:::SKU-20"
+	return suit.server.CatalogItems().Put(item)
+}
+
+// Stores `item` before the first create job is spawned, as another writer would between listing and creating items
+type racingCatalog struct {
+	catalog.CatalogItemApi
+	server *klaviyotest.Server
+	item   models.CatalogItem
+	once   sync.Once
+}
+
+func (c *racingCatalog) SpawnCreateItemsJob(ctx context.Context, payload catalog.SpawnCreateItemsJobPayload) (*models.CatalogItemBulkJobResource, error) {
+	c.once.Do(func() { c.server.CatalogItems().Put(c.item) })
+	return c.CatalogItemApi.SpawnCreateItemsJob(ctx, payload)
+}
+
+func (suit *SyncTestSuite) TestSync() {
+	opt := suit.options()
+	opt.DeleteMissing = true
+
+	report, err := catalog.Sync(context.Background(), suit.server.Client().Catalog, suit.source(), opt)
+	suit.Require().NoError(err)
+
+	suit.False(report.DryRun)
+	suit.Equal(externalIds(25, 30), report.Created)
+	suit.Equal([]string{"SKU-05"}, report.Updated)
+	suit.Equal(externalIds(20, 25), report.Deleted)
+	suit.Len(report.Unchanged, 19)
+	suit.Empty(report.Failed)
+	// 3 create, 1 update and 3 delete jobs of at most 2 items
+	suit.Len(report.Jobs, 7)
+	suit.Len(suit.bulkJobRequests(), 7)
+
+	suit.Equal(25, suit.server.CatalogItems().Len())
+	retitled, ok := suit.server.CatalogItems().Get("$custom:::$default:::SKU-05")
+	suit.Require().True(ok)
+	suit.Equal("Retitled", *retitled.Attributes.Title)
+	_, ok = suit.server.CatalogItems().Get("$custom:::$default:::SKU-29")
+	suit.True(ok)
+	_, ok = suit.server.CatalogItems().Get("$custom:::$default:::SKU-20")
+	suit.False(ok)
+}
+
+func (suit *SyncTestSuite) TestSyncDryRun() {
+	opt := suit.options()
+	opt.DryRun = true
+	opt.DeleteMissing = true
+
+	report, err := catalog.Sync(context.Background(), suit.server.Client().Catalog, suit.source(), opt)
+	suit.Require().NoError(err)
+
+	suit.True(report.DryRun)
+	suit.Equal(externalIds(25, 30), report.Created)
+	suit.Equal([]string{"SKU-05"}, report.Updated)
+	suit.Equal(externalIds(20, 25), report.Deleted)
+	suit.Empty(report.Jobs)
+	suit.Empty(suit.bulkJobRequests())
+	suit.Equal(25, suit.server.CatalogItems().Len())
+}
+
+func (suit *SyncTestSuite) TestSyncKeepsMissingItems() {
+	for name, opt := range map[string]*catalog.SyncOptions{
+		"nil":        nil,
+		"zero value": {},
+		"defaults":   catalog.NewSyncOptionsWithDefaultValues(),
+	} {
+		suit.Run(name, func() {
+			suit.SetupTest()
+			defer suit.TearDownTest()
+
+			report, err := catalog.Sync(context.Background(), suit.server.Client().Catalog, suit.source(), opt)
+			suit.Require().NoError(err)
+
+			suit.Empty(report.Deleted)
+			suit.Equal(30, suit.server.CatalogItems().Len())
+			for _, request := range suit.bulkJobRequests() {
+				suit.NotContains(request.Path, "catalog-item-bulk-delete-jobs")
+			}
+		})
+	}
+}
+
+func (suit *SyncTestSuite) TestSyncIgnoresOtherIntegrations() {
+	shopify := suit.putShopifyItem()
+	opt := suit.options()
+	opt.DeleteMissing = true
+
+	report, err := catalog.Sync(context.Background(), suit.server.Client().Catalog, suit.source(), opt)
+	suit.Require().NoError(err)
+
+	suit.Equal(externalIds(20, 25), report.Deleted)
+	suit.Len(report.Unchanged, 19)
+	stored, ok := suit.server.CatalogItems().Get(shopify.ID)
+	suit.Require().True(ok)
+	suit.Equal(shopify, stored)
+}
+
+func (suit *SyncTestSuite) TestSyncRejectsItemsOfOtherIntegrations() {
+	integration := "$shopify"
+	source := catalog.SyncSourceFunc(func(ctx context.Context) ([]catalog.CreateCatalogItemAttributesPayload, error) {
+		return []catalog.CreateCatalogItemAttributesPayload{{ExternalId: "SKU-20", IntegrationType: &integration}}, nil
+	})
+
+	_, err := catalog.Sync(context.Background(), suit.server.Client().Catalog, source, suit.options())
+	suit.ErrorContains(err, `"SKU-20" is not in the $custom integration`)
+	suit.Empty(suit.bulkJobRequests())
+}
+
+func (suit *SyncTestSuite) TestSyncReportsFailedItems() {
+	// SKU-25 is stored after the items are listed, so creating it conflicts
+	api := &racingCatalog{
+		CatalogItemApi: suit.server.Client().Catalog,
+		server:         suit.server,
+		item:           fixtures.CatalogItem().WithExternalID("SKU-25").Build(),
+	}
+
+	report, err := catalog.Sync(context.Background(), api, suit.source(), suit.options())
+	suit.Require().NoError(err)
+
+	suit.Equal(externalIds(26, 30), report.Created)
+	suit.Require().Len(report.Failed, 1)
+	suit.Equal("SKU-25", report.Failed[0].ExternalId)
+	suit.Equal(catalog.SyncOperationCreate, report.Failed[0].Operation)
+	suit.ErrorContains(report.Failed[0], "already exists")
+}
+
+func (suit *SyncTestSuite) TestSyncRejectsDuplicateExternalIds() {
+	source := catalog.SyncSourceFunc(func(ctx context.Context) ([]catalog.CreateCatalogItemAttributesPayload, error) {
+		return []catalog.CreateCatalogItemAttributesPayload{{ExternalId: "SKU-00"}, {ExternalId: "SKU-00"}}, nil
+	})
+
+	_, err := catalog.Sync(context.Background(), suit.server.Client().Catalog, source, suit.options())
+	suit.ErrorContains(err, `"SKU-00" more than once`)
+	suit.Empty(suit.bulkJobRequests())
+}
+
+func TestSyncTestSuite(t *testing.T) {
+	suite.Run(t, new(SyncTestSuite))
+}
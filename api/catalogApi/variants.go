@@ -6,9 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/instrumentation"
 	"github.com/developertom01/klaviyo-go/models"
 )
 
@@ -22,59 +22,59 @@ type (
 		//Create a new variant for a related catalog item.
 		CreateCatalogVariant(ctx context.Context, payload CreateCatalogItemVariantPayload) (*models.CatalogVariantResource, error)
 		//Get a catalog item variant with the given variant ID.
-		//The catalog variant ID is a compound ID (string), with format: {integration}:::{catalog}:::{external_id}. Currently, the only supported integration type is $custom, and the only supported catalog is $default.
-		GetCatalogVariant(ctx context.Context, catalogVariantId string, options *GetCatalogVariantApiOptions) (*models.CatalogVariantResource, error)
+		//The catalog variant ID is a compound ID, with format: {integration}:::{catalog}:::{external_id}, eg. models.NewCatalogID(externalId). Currently, the only supported integration type is $custom, and the only supported catalog is $default.
+		GetCatalogVariant(ctx context.Context, catalogVariantId models.CatalogID, options *GetCatalogVariantApiOptions) (*models.CatalogVariantResource, error)
 		//Update a catalog item variant with the given variant ID.
-		UpdateCatalogVariant(ctx context.Context, catalogVariantId string, payload UpdateCatalogVariantPayload) (*models.CatalogVariantResource, error)
+		UpdateCatalogVariant(ctx context.Context, catalogVariantId models.CatalogID, payload UpdateCatalogVariantPayload) (*models.CatalogVariantResource, error)
 		//Delete a catalog item variant with the given variant ID.
-		DeleteCatalogVariant(ctx context.Context, catalogVariantId string) error
+		DeleteCatalogVariant(ctx context.Context, catalogVariantId models.CatalogID) error
 	}
 )
 
 type CatalogVariantsApiOptions struct {
-	CatalogVariantFields []models.CatalogVariantField    //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
-	PageCursor           *string                         //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#pagination
-	SortField            *models.CatalogVariantSortField //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sorting
-	//Allowed field(s)/operator(s):
-	//integration.name: equals
-	//integration.category: equals
+	CatalogVariantFields models.Fields[models.CatalogVariantField]   //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
+	PageCursor           *string                                     //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#pagination
+	SortField            models.Sort[models.CatalogVariantSortField] //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sorting
+	//Allowed field(s)/operator(s), validated before sending, see GetCatalogVariantsFilterSchema:
+	//ids: any
+	//item.id: equals
+	//sku: equals
+	//title: contains
+	//published: equals
 	//For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#filtering
-	filterString *string
+	FilterString *string
 }
 
-func buildCatalogVariantApiOptionsParams(options *CatalogVariantsApiOptions) string {
+func buildCatalogVariantApiOptionsParams(options *CatalogVariantsApiOptions) *common.Query {
+	query := common.NewQuery()
 	if options == nil {
-		return ""
+		return query
 	}
 
-	var params = []string{}
-
-	if options.filterString != nil {
-		params = append(params, *options.filterString)
-	}
-
-	if options.CatalogVariantFields != nil {
-		params = append(params, models.BuildCatalogVariantFieldParams(options.CatalogVariantFields))
+	if options.FilterString != nil {
+		query.Filter(*options.FilterString)
 	}
 
-	if options.CatalogVariantFields != nil {
-		params = append(params, models.BuildCatalogVariantFieldParams((options.CatalogVariantFields)))
-	}
-
-	if options.SortField != nil {
-		params = append(params, fmt.Sprintf("sort=%s", *options.SortField))
-	}
+	query.Apply(options.CatalogVariantFields, options.SortField)
 
 	if options.PageCursor != nil {
-		params = append(params, fmt.Sprintf("page[cursor]=%s", *options.PageCursor))
+		query.PageCursor(*options.PageCursor)
 	}
 
-	return strings.Join(params, "&")
+	return query
 }
 
 func (api *catalogApi) GetCatalogVariants(ctx context.Context, options *CatalogVariantsApiOptions) (*models.CatalogVariantCollectionResource, error) {
-	queryParams := buildCatalogVariantApiOptionsParams(options)
-	url := fmt.Sprintf("%s/api/catalog-variants/?%s", api.baseApiUrl, queryParams)
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.GetCatalogVariants")
+
+	if options != nil && options.FilterString != nil {
+		if err := common.ValidateFilter(api.session, GetCatalogVariantsFilterSchema, *options.FilterString); err != nil {
+			return nil, err
+		}
+	}
+
+	query := buildCatalogVariantApiOptionsParams(options)
+	url := query.URL(fmt.Sprintf("%s/api/catalog-variants/", api.baseApiUrl))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
@@ -94,6 +94,8 @@ func (api *catalogApi) GetCatalogVariants(ctx context.Context, options *CatalogV
 }
 
 func (api *catalogApi) CreateCatalogVariant(ctx context.Context, payload CreateCatalogItemVariantPayload) (*models.CatalogVariantResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.CreateCatalogVariant")
+
 	url := fmt.Sprintf("%s/api/catalog-variants/", api.baseApiUrl)
 
 	reqData, err := json.Marshal(payload)
@@ -102,7 +104,7 @@ func (api *catalogApi) CreateCatalogVariant(ctx context.Context, payload CreateC
 	}
 	reqDataBuffer := bytes.NewBuffer(reqData)
 
-	req, err := http.NewRequest(http.MethodPost, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -119,25 +121,27 @@ func (api *catalogApi) CreateCatalogVariant(ctx context.Context, payload CreateC
 }
 
 type GetCatalogVariantApiOptions struct {
-	CatalogVariantFields []models.CatalogVariantField //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
+	CatalogVariantFields models.Fields[models.CatalogVariantField] //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#sparse-fieldsets
 }
 
-func buildGetCatalogVariantApiOptionsParams(options *GetCatalogVariantApiOptions) string {
+func buildGetCatalogVariantApiOptionsParams(options *GetCatalogVariantApiOptions) *common.Query {
+	query := common.NewQuery()
 	if options == nil {
-		return ""
-	}
-	var params = []string{}
-
-	if options.CatalogVariantFields != nil {
-		params = append(params, models.BuildCatalogVariantFieldParams(options.CatalogVariantFields))
+		return query
 	}
 
-	return strings.Join(params, "&")
+	return query.Apply(options.CatalogVariantFields)
 }
 
-func (api *catalogApi) GetCatalogVariant(ctx context.Context, catalogVariantId string, options *GetCatalogVariantApiOptions) (*models.CatalogVariantResource, error) {
-	queryParams := buildGetCatalogVariantApiOptionsParams(options)
-	url := fmt.Sprintf("%s/api/catalog-variants/%s/?%s", api.baseApiUrl, catalogVariantId, queryParams)
+func (api *catalogApi) GetCatalogVariant(ctx context.Context, catalogVariantId models.CatalogID, options *GetCatalogVariantApiOptions) (*models.CatalogVariantResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.GetCatalogVariant")
+
+	if err := catalogVariantId.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := buildGetCatalogVariantApiOptionsParams(options)
+	url := query.URL(fmt.Sprintf("%s/api/catalog-variants/%s/", api.baseApiUrl, catalogVariantId.PathEscape()))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
@@ -156,8 +160,14 @@ func (api *catalogApi) GetCatalogVariant(ctx context.Context, catalogVariantId s
 	return &catalogVariant, nil
 }
 
-func (api *catalogApi) UpdateCatalogVariant(ctx context.Context, catalogVariantId string, payload UpdateCatalogVariantPayload) (*models.CatalogVariantResource, error) {
-	url := fmt.Sprintf("%s/api/catalog-variants/%s/", api.baseApiUrl, catalogVariantId)
+func (api *catalogApi) UpdateCatalogVariant(ctx context.Context, catalogVariantId models.CatalogID, payload UpdateCatalogVariantPayload) (*models.CatalogVariantResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.UpdateCatalogVariant")
+
+	if err := catalogVariantId.Validate(); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/api/catalog-variants/%s/", api.baseApiUrl, catalogVariantId.PathEscape())
 
 	reqData, err := json.Marshal(payload)
 	if err != nil {
@@ -165,7 +175,7 @@ func (api *catalogApi) UpdateCatalogVariant(ctx context.Context, catalogVariantI
 	}
 	reqDataBuffer := bytes.NewBuffer(reqData)
 
-	req, err := http.NewRequest(http.MethodPatch, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -181,9 +191,15 @@ func (api *catalogApi) UpdateCatalogVariant(ctx context.Context, catalogVariantI
 	return &catalogVariantsResource, err
 }
 
-func (api *catalogApi) DeleteCatalogVariant(ctx context.Context, catalogVariantId string) error {
-	url := fmt.Sprintf("%s/api/catalog-variants/%s/", api.baseApiUrl, catalogVariantId)
-	req, err := http.NewRequest(http.MethodDelete, url, nil)
+func (api *catalogApi) DeleteCatalogVariant(ctx context.Context, catalogVariantId models.CatalogID) error {
+	ctx = instrumentation.WithOperation(ctx, "CatalogApi.DeleteCatalogVariant")
+
+	if err := catalogVariantId.Validate(); err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/catalog-variants/%s/", api.baseApiUrl, catalogVariantId.PathEscape())
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return err
 	}
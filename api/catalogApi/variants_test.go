@@ -0,0 +1,59 @@
+package catalog_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	catalog "github.com/developertom01/klaviyo-go/api/catalogApi"
+	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/developertom01/klaviyo-go/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Returns an api sending requests to a mocked client responding with `body`
+func newMockedCatalogApi(t *testing.T, body string) (catalog.CatalogApi, *common.MockHTTPClient) {
+	opt := options.NewOptionsWithDefaultValues().WithApiKey("test-key")
+	client := common.NewMockHTTPClient()
+	require.NoError(t, common.PrepareMockResponse(http.StatusOK, json.RawMessage(body), client))
+
+	return catalog.NewCatalogApi(common.NewApiKeySession(opt, common.NewRetryOptionsWithDefaultValues()), client), client
+}
+
+func sentRequest(client *common.MockHTTPClient) *http.Request {
+	return client.Calls[0].Arguments.Get(0).(*http.Request)
+}
+
+func TestCreateCatalogVariantSendsItemRelationship(t *testing.T) {
+	api, client := newMockedCatalogApi(t, `{"data": {
+		"type": "catalog-variant", "id": "$custom:::$default:::shirt-blue", "attributes": {},
+		"relationships": {"item": {"data": {"type": "catalog-item", "id": "$custom:::$default:::shirt"}}}
+	}}`)
+
+	res, err := api.CreateCatalogVariant(context.Background(), catalog.CreateCatalogItemVariantPayload{
+		Data: catalog.CreateCatalogItemVariantPayloadData{
+			Type: "catalog-variant",
+			Relationships: catalog.CreateCatalogItemVariantDataRelationshipsPayload{
+				Item: models.RelationshipsRequestPayload{Data: models.RelationshipData{Type: "catalog-item", ID: "$custom:::$default:::shirt"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []models.RelationshipData{{Type: "catalog-item", ID: "$custom:::$default:::shirt"}}, res.Data.Relationships.Item.Data)
+
+	body, err := io.ReadAll(sentRequest(client).Body)
+	require.NoError(t, err)
+
+	var sent struct {
+		Data struct {
+			Relationships map[string]json.RawMessage `json:"relationships"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(body, &sent))
+	assert.JSONEq(t, `{"data": {"type": "catalog-item", "id": "$custom:::$default:::shirt"}}`, string(sent.Data.Relationships["item"]))
+	assert.NotContains(t, sent.Data.Relationships, "items")
+}
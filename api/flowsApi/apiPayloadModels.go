@@ -1,9 +1,7 @@
 package flows
 
 import (
-	"fmt"
-	"strings"
-
+	"github.com/developertom01/klaviyo-go/common"
 	"github.com/developertom01/klaviyo-go/models"
 )
 
@@ -18,26 +16,13 @@ type FlowsActionIncludeField string
 
 const (
 	FlowsActionIncludeFieldFlow        FlowsActionIncludeField = "flow"
-	FlowsActionIncludeFieldFlowMessage FlowsActionIncludeField = "flow-message"
+	FlowsActionIncludeFieldFlowMessage FlowsActionIncludeField = "flow-messages"
 )
 
-func buildIncludeFieldParam[T FlowsIncludeField | FlowsActionIncludeField | FlowMessageIncludeFieldParam](fields []T) string {
-	if len(fields) == 0 {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("include=%s", strings.Join(formattedFields, ","))
-}
-
 type FlowPaginationOptions struct {
 	PageSize *int    //Default: 50. Min: 1. Max: 50.
 	Cursor   *string //For more information please visit
-	Sort     *FlowSortField
+	Sort     models.Sort[FlowSortField]
 }
 
 // ---- FlowSortField
@@ -61,13 +46,6 @@ const (
 	FlowSortFieldUpdatedDESC FlowSortField = "-updated"
 )
 
-func buildFlowSortFieldParam[T FlowSortField | FlowActionSortField | FlowActionMessageSortField](sField *T) string {
-	if sField == nil {
-		return ""
-	}
-	return fmt.Sprintf("sort=%s", *sField)
-}
-
 // ---- UpdateFlowStatusPayload
 
 type (
@@ -116,31 +94,15 @@ const (
 type FlowActionPaginationOptions struct {
 	PageSize *int    //Default: 50. Min: 1. Max: 50.
 	Cursor   *string //For more information please visit
-	Sort     *FlowActionSortField
+	Sort     models.Sort[FlowActionSortField]
 }
 
-func buildGetFlowActionsPaginationOptionsQueryParams(opt *FlowActionPaginationOptions) string {
+func buildGetFlowActionsPaginationOptionsQueryParams(query *common.Query, opt *FlowActionPaginationOptions) *common.Query {
 	if opt == nil {
-		return ""
+		return query
 	}
-	var params = make([]string, 0)
 
-	if opt.PageSize == nil {
-		pageSize := 50
-		opt.PageSize = &pageSize
-	}
-
-	params = append(params, fmt.Sprintf("page[size]=%d", *opt.PageSize))
-
-	if opt.Sort != nil {
-		params = append(params, buildFlowSortFieldParam(opt.Sort))
-	}
-
-	if opt.Cursor != nil {
-		params = append(params, fmt.Sprintf("page[cursor]=%s", *opt.Cursor))
-	}
-
-	return strings.Join(params, "&")
+	return applyPagination(query, opt.PageSize, opt.Cursor, opt.Sort)
 }
 
 type FlowActionMessageSortField string
@@ -163,29 +125,30 @@ const (
 type FlowActionMessagePaginationOptions struct {
 	PageSize *int    //Default: 50. Min: 1. Max: 50.
 	Cursor   *string //For more information please visit
-	Sort     *FlowActionMessageSortField
+	Sort     models.Sort[FlowActionMessageSortField]
 }
 
-func buildFlowActionMessagePaginationOptionsQueryParams(opt *FlowActionMessagePaginationOptions) string {
+func buildFlowActionMessagePaginationOptionsQueryParams(query *common.Query, opt *FlowActionMessagePaginationOptions) *common.Query {
 	if opt == nil {
-		return ""
-	}
-	var params = make([]string, 0)
-
-	if opt.PageSize == nil {
-		pageSize := 50
-		opt.PageSize = &pageSize
+		return query
 	}
 
-	params = append(params, fmt.Sprintf("page[size]=%d", *opt.PageSize))
+	return applyPagination(query, opt.PageSize, opt.Cursor, opt.Sort)
+}
 
-	if opt.Sort != nil {
-		params = append(params, buildFlowSortFieldParam(opt.Sort))
+// Sets page size, defaulting to 50, cursor and sort of flow endpoints
+func applyPagination[S FlowSortField | FlowActionSortField | FlowActionMessageSortField](query *common.Query, pageSize *int, cursor *string, sort models.Sort[S]) *common.Query {
+	if pageSize != nil {
+		query.PageSize(*pageSize)
+	} else {
+		query.PageSize(50)
 	}
 
-	if opt.Cursor != nil {
-		params = append(params, fmt.Sprintf("page[cursor]=%s", *opt.Cursor))
+	query.Apply(sort)
+
+	if cursor != nil {
+		query.PageCursor(*cursor)
 	}
 
-	return strings.Join(params, "&")
+	return query
 }
@@ -0,0 +1,42 @@
+package flows
+
+import (
+	"github.com/developertom01/klaviyo-go/common/filter"
+)
+
+var (
+	// Filter accepted by GetFlows
+	GetFlowsFilterSchema = filter.Schema{
+		Endpoint: "GET /api/flows/",
+		Fields: map[string][]filter.Operator{
+			"id":           {filter.OperatorAny},
+			"name":         {filter.OperatorContains, filter.OperatorEndsWith, filter.OperatorEquals, filter.OperatorStartsWith},
+			"status":       {filter.OperatorEquals},
+			"archived":     {filter.OperatorEquals},
+			"created":      append([]filter.Operator{filter.OperatorEquals}, filter.DateTimeOperators...),
+			"updated":      append([]filter.Operator{filter.OperatorEquals}, filter.DateTimeOperators...),
+			"trigger_type": {filter.OperatorEquals},
+		},
+	}
+
+	// Filter accepted by GetFlowRelationshipsFlowActions
+	GetFlowActionsFilterSchema = filter.Schema{
+		Endpoint: "GET /api/flows/{id}/relationships/flow-actions/",
+		Fields: map[string][]filter.Operator{
+			"action_type": {filter.OperatorEquals},
+			"status":      {filter.OperatorEquals},
+			"created":     append([]filter.Operator{filter.OperatorEquals}, filter.DateTimeOperators...),
+			"updated":     append([]filter.Operator{filter.OperatorEquals}, filter.DateTimeOperators...),
+		},
+	}
+
+	// Filter accepted by GetFlowActionMessages and GetFlowActionRelationshipsMessages
+	GetFlowMessagesFilterSchema = filter.Schema{
+		Endpoint: "GET /api/flow-actions/{id}/flow-messages/",
+		Fields: map[string][]filter.Operator{
+			"name":    {filter.OperatorContains, filter.OperatorEndsWith, filter.OperatorEquals, filter.OperatorStartsWith},
+			"created": append([]filter.Operator{filter.OperatorEquals}, filter.DateTimeOperators...),
+			"updated": append([]filter.Operator{filter.OperatorEquals}, filter.DateTimeOperators...),
+		},
+	}
+)
@@ -6,9 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/instrumentation"
 	"github.com/developertom01/klaviyo-go/models"
 )
 
@@ -35,16 +35,16 @@ type (
 		GetFlowFlowActions(ctx context.Context, flowId string, opt *GetFlowActionOptions, paginationOpt *FlowActionPaginationOptions) (*models.FlowActionCollectionResource, error)
 
 		//Return all tags associated with the given flow ID.
-		GetFlowTags(ctx context.Context, flowId string, tagFields []models.TagField) (*models.FlowTagCollectionResource, error)
+		GetFlowTags(ctx context.Context, flowId string, tagFields models.Fields[models.TagField]) (*models.FlowTagCollectionResource, error)
 
 		//Get the flow associated with the given action ID.
-		GetFlowForFlowAction(ctx context.Context, flowActionId string, flowsFields []models.FlowField) (*models.FlowActionResource, error)
+		GetFlowForFlowAction(ctx context.Context, flowActionId string, flowsFields models.Fields[models.FlowField]) (*models.FlowActionResource, error)
 
 		//Get all flow messages associated with the given action ID.
 		GetFlowActionMessages(ctx context.Context, flowActionId string, filterStr *string, paginationOpt *FlowActionMessagePaginationOptions) (*models.FlowActionMessageCollectionResource, error)
 
 		//Get the flow action for a flow message with the given message ID.
-		GetFlowActionForMessage(ctx context.Context, actionMessageId string, flowActionFields []models.FlowActionField) (*models.FlowActionResource, error)
+		GetFlowActionForMessage(ctx context.Context, actionMessageId string, flowActionFields models.Fields[models.FlowActionField]) (*models.FlowActionResource, error)
 
 		// Flows Relationship API
 		FlowRelationshipsApi
@@ -59,85 +59,58 @@ type (
 )
 
 func NewFlowsApi(session common.Session, httpClient common.HTTPClient) FlowsApi {
-	var client common.HTTPClient
-	if httpClient == nil {
-		client = http.DefaultClient
-	} else {
-		client = httpClient
-	}
+	opt := session.GetOptions()
 
 	return &flowsApi{
 		session:    session,
-		baseApiUrl: common.BASE_URL,
-		revision:   common.API_REVISION,
-		httpClient: client,
+		baseApiUrl: common.ResolveBaseUrl(opt),
+		revision:   common.ResolveRevision(opt),
+		httpClient: common.ResolveHTTPClient(opt, httpClient),
 	}
 }
 
 type GetFlowsOptions struct {
-	FlowActionFields []models.FlowActionField
-	FlowFields       []models.FlowField
-	TagFields        []models.TagField
+	FlowActionFields models.Fields[models.FlowActionField]
+	FlowFields       models.Fields[models.FlowField]
+	TagFields        models.Fields[models.TagField]
 	Include          []FlowsIncludeField
 }
 
-func buildGetFlowsOptionsQueryParams(filter *string, opt *GetFlowsOptions) string {
-	if opt == nil {
-		return ""
-	}
-
-	var params = make([]string, 0)
+func buildGetFlowsOptionsQueryParams(filter *string, opt *GetFlowsOptions) *common.Query {
+	query := common.NewQuery()
 
 	if filter != nil {
-		params = append(params, *filter)
-	}
-
-	if opt.FlowActionFields != nil {
-		params = append(params, models.BuildFlowActionFieldsParam(opt.FlowActionFields))
-	}
-
-	if opt.FlowFields != nil {
-		params = append(params, models.BuildFlowFieldsParam(opt.FlowFields))
-	}
-
-	if opt.TagFields != nil {
-		params = append(params, models.BuildTagFieldParam(opt.TagFields))
-
+		query.Filter(*filter)
 	}
 
-	if opt.Include != nil {
-		params = append(params, buildIncludeFieldParam(opt.Include))
+	if opt == nil {
+		return query
 	}
 
-	return strings.Join(params, "&")
+	return query.
+		Apply(opt.FlowActionFields, opt.FlowFields, opt.TagFields).
+		Include(common.StringsOf(opt.Include)...)
 }
 
-func buildGetFlowsPaginationOptionsQueryParams(opt *FlowPaginationOptions) string {
+func buildGetFlowsPaginationOptionsQueryParams(query *common.Query, opt *FlowPaginationOptions) *common.Query {
 	if opt == nil {
-		return ""
-	}
-	var params = make([]string, 0)
-
-	if opt.PageSize == nil {
-		pageSize := 50
-		opt.PageSize = &pageSize
+		return query
 	}
 
-	params = append(params, fmt.Sprintf("page[size]=%d", *opt.PageSize))
+	return applyPagination(query, opt.PageSize, opt.Cursor, opt.Sort)
+}
 
-	if opt.Sort != nil {
-		params = append(params, buildFlowSortFieldParam(opt.Sort))
-	}
+func (api *flowsApi) GetFlows(ctx context.Context, filterStr *string, options *GetFlowsOptions, paginationOpt *FlowPaginationOptions) (*models.FlowCollectionResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlows")
 
-	if opt.Cursor != nil {
-		params = append(params, fmt.Sprintf("page[cursor]=%s", *opt.Cursor))
+	if filterStr != nil {
+		if err := common.ValidateFilter(api.session, GetFlowsFilterSchema, *filterStr); err != nil {
+			return nil, err
+		}
 	}
 
-	return strings.Join(params, "&")
-}
-
-func (api *flowsApi) GetFlows(ctx context.Context, filterStr *string, options *GetFlowsOptions, paginationOpt *FlowPaginationOptions) (*models.FlowCollectionResource, error) {
-	url := fmt.Sprintf("%s/api/flows/?%s&%s", api.baseApiUrl, buildGetFlowsOptionsQueryParams(filterStr, options), buildGetFlowsPaginationOptionsQueryParams(paginationOpt))
+	query := buildGetFlowsPaginationOptionsQueryParams(buildGetFlowsOptionsQueryParams(filterStr, options), paginationOpt)
+	url := query.URL(fmt.Sprintf("%s/api/flows/", api.baseApiUrl))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -156,7 +129,10 @@ func (api *flowsApi) GetFlows(ctx context.Context, filterStr *string, options *G
 }
 
 func (api flowsApi) GetFlow(ctx context.Context, flowId string, options *GetFlowsOptions) (*models.FlowResource, error) {
-	url := fmt.Sprintf("%s/api/flows/%s/?%s", api.baseApiUrl, flowId, buildGetFlowsOptionsQueryParams(nil, options))
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlow")
+
+	query := buildGetFlowsOptionsQueryParams(nil, options)
+	url := query.URL(fmt.Sprintf("%s/api/flows/%s/", api.baseApiUrl, flowId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -176,6 +152,8 @@ func (api flowsApi) GetFlow(ctx context.Context, flowId string, options *GetFlow
 }
 
 func (api *flowsApi) UpdateFlowStatus(ctx context.Context, flowId string, payload UpdateFlowStatusPayload) (*models.FlowResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.UpdateFlowStatus")
+
 	url := fmt.Sprintf("%s/api/flows/%s", api.baseApiUrl, flowId)
 
 	reqData, err := json.Marshal(payload)
@@ -184,7 +162,7 @@ func (api *flowsApi) UpdateFlowStatus(ctx context.Context, flowId string, payloa
 	}
 
 	reqDataBuffer := bytes.NewBuffer(reqData)
-	req, err := http.NewRequest(http.MethodPatch, url, reqDataBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, reqDataBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -201,42 +179,28 @@ func (api *flowsApi) UpdateFlowStatus(ctx context.Context, flowId string, payloa
 }
 
 type GetFlowActionOptions struct {
-	FlowActionFields []models.FlowActionField
-	FlowFields       []models.FlowField
-	FlowMessageField []models.FlowMessageField
+	FlowActionFields models.Fields[models.FlowActionField]
+	FlowFields       models.Fields[models.FlowField]
+	FlowMessageField models.Fields[models.FlowMessageField]
 	Include          []FlowsActionIncludeField
 }
 
-func buildGetFlowActionOptionsQueryParams(opt *GetFlowActionOptions) string {
+func buildGetFlowActionOptionsQueryParams(opt *GetFlowActionOptions) *common.Query {
+	query := common.NewQuery()
 	if opt == nil {
-		return ""
-	}
-
-	var params = make([]string, 0)
-
-	if opt.FlowActionFields != nil {
-		params = append(params, models.BuildFlowActionFieldsParam(opt.FlowActionFields))
-	}
-
-	if opt.FlowFields != nil {
-		params = append(params, models.BuildFlowFieldsParam(opt.FlowFields))
-	}
-
-	if opt.FlowMessageField != nil {
-		params = append(params, models.BuildFlowMessageFieldsParam(opt.FlowMessageField))
-
+		return query
 	}
 
-	if opt.Include != nil {
-		params = append(params, buildIncludeFieldParam(opt.Include))
-	}
-
-	return strings.Join(params, "&")
+	return query.
+		Apply(opt.FlowActionFields, opt.FlowFields, opt.FlowMessageField).
+		Include(common.StringsOf(opt.Include)...)
 }
 
 func (api flowsApi) GetFlowAction(ctx context.Context, flowId string, opt *GetFlowActionOptions) (*models.FlowActionResource, error) {
-	params := buildGetFlowActionOptionsQueryParams(opt)
-	url := fmt.Sprintf("%s/api/flows-actions/%s/?%s", api.baseApiUrl, flowId, params)
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlowAction")
+
+	query := buildGetFlowActionOptionsQueryParams(opt)
+	url := query.URL(fmt.Sprintf("%s/api/flow-actions/%s/", api.baseApiUrl, flowId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -255,38 +219,28 @@ func (api flowsApi) GetFlowAction(ctx context.Context, flowId string, opt *GetFl
 }
 
 type GetFlowMessageOptions struct {
-	FlowActionFields  []models.FlowActionField
-	FlowMessageFields []models.FlowMessageField
-	TemplateFields    []models.TemplateField
+	FlowActionFields  models.Fields[models.FlowActionField]
+	FlowMessageFields models.Fields[models.FlowMessageField]
+	TemplateFields    models.Fields[models.TemplateField]
 	Include           []FlowMessageIncludeFieldParam
 }
 
-func buildGetFlowMessageOptionsQueryParams(opt *GetFlowMessageOptions) string {
+func buildGetFlowMessageOptionsQueryParams(opt *GetFlowMessageOptions) *common.Query {
+	query := common.NewQuery()
 	if opt == nil {
-		return ""
+		return query
 	}
 
-	var params = make([]string, 0)
-
-	if opt.FlowActionFields != nil {
-		params = append(params, models.BuildFlowActionFieldsParam(opt.FlowActionFields))
-	}
-
-	if opt.FlowMessageFields != nil {
-		params = append(params, models.BuildTemplateFieldParam(opt.TemplateFields))
-
-	}
-
-	if opt.Include != nil {
-		params = append(params, buildIncludeFieldParam(opt.Include))
-	}
-
-	return strings.Join(params, "&")
+	return query.
+		Apply(opt.FlowActionFields, opt.FlowMessageFields, opt.TemplateFields).
+		Include(common.StringsOf(opt.Include)...)
 }
 
 func (api *flowsApi) GetFlowMessage(ctx context.Context, flowMessageID string, opt *GetFlowMessageOptions) (*models.FlowMessageResource, error) {
-	params := buildGetFlowMessageOptionsQueryParams(opt)
-	url := fmt.Sprintf("%s/api/flow-messages/%s/?%s", api.baseApiUrl, flowMessageID, params)
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlowMessage")
+
+	query := buildGetFlowMessageOptionsQueryParams(opt)
+	url := query.URL(fmt.Sprintf("%s/api/flow-messages/%s/", api.baseApiUrl, flowMessageID))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -305,17 +259,10 @@ func (api *flowsApi) GetFlowMessage(ctx context.Context, flowMessageID string, o
 }
 
 func (api *flowsApi) GetFlowFlowActions(ctx context.Context, flowId string, opt *GetFlowActionOptions, paginationOpt *FlowActionPaginationOptions) (*models.FlowActionCollectionResource, error) {
-	params := make([]string, 0)
-	optParams := buildGetFlowActionOptionsQueryParams(opt)
-	paginationOptParams := buildGetFlowActionsPaginationOptionsQueryParams(paginationOpt)
-	if optParams != "" {
-		params = append(params, optParams)
-	}
-	if paginationOptParams != "" {
-		params = append(params, paginationOptParams)
-	}
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlowFlowActions")
 
-	url := fmt.Sprintf("%s/api/flows/%s/flow-actions/?%s", api.baseApiUrl, flowId, strings.Join(params, "&"))
+	query := buildGetFlowActionsPaginationOptionsQueryParams(buildGetFlowActionOptionsQueryParams(opt), paginationOpt)
+	url := query.URL(fmt.Sprintf("%s/api/flows/%s/flow-actions/", api.baseApiUrl, flowId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -334,15 +281,11 @@ func (api *flowsApi) GetFlowFlowActions(ctx context.Context, flowId string, opt
 
 }
 
-func (api *flowsApi) GetFlowTags(ctx context.Context, flowId string, tagFields []models.TagField) (*models.FlowTagCollectionResource, error) {
-	var params = ""
+func (api *flowsApi) GetFlowTags(ctx context.Context, flowId string, tagFields models.Fields[models.TagField]) (*models.FlowTagCollectionResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlowTags")
 
-	tagsParam := models.BuildTagFieldParam(tagFields)
-	if tagsParam != "" {
-		params = fmt.Sprintf("?%s", tagsParam)
-	}
-
-	url := fmt.Sprintf("%s/api/flows/%s/%s", api.baseApiUrl, flowId, params)
+	query := common.NewQuery().Apply(tagFields)
+	url := query.URL(fmt.Sprintf("%s/api/flows/%s/tags/", api.baseApiUrl, flowId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -360,15 +303,11 @@ func (api *flowsApi) GetFlowTags(ctx context.Context, flowId string, tagFields [
 	return &tags, err
 }
 
-func (api *flowsApi) GetFlowForFlowAction(ctx context.Context, flowActionId string, flowsFields []models.FlowField) (*models.FlowActionResource, error) {
-	var params = ""
+func (api *flowsApi) GetFlowForFlowAction(ctx context.Context, flowActionId string, flowsFields models.Fields[models.FlowField]) (*models.FlowActionResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlowForFlowAction")
 
-	flowsFieldParam := models.BuildFlowFieldsParam(flowsFields)
-	if flowsFieldParam != "" {
-		params = fmt.Sprintf("?%s", flowsFieldParam)
-	}
-
-	url := fmt.Sprintf("%s/api/flow-actions/%s/%s", api.baseApiUrl, flowActionId, params)
+	query := common.NewQuery().Apply(flowsFields)
+	url := query.URL(fmt.Sprintf("%s/api/flow-actions/%s/flow/", api.baseApiUrl, flowActionId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -387,16 +326,21 @@ func (api *flowsApi) GetFlowForFlowAction(ctx context.Context, flowActionId stri
 }
 
 func (api *flowsApi) GetFlowActionMessages(ctx context.Context, flowActionId string, filterStr *string, paginationOpt *FlowActionMessagePaginationOptions) (*models.FlowActionMessageCollectionResource, error) {
-	params := make([]string, 0)
-	paginationParams := buildFlowActionMessagePaginationOptionsQueryParams(paginationOpt)
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlowActionMessages")
+
 	if filterStr != nil {
-		params = append(params, *filterStr)
+		if err := common.ValidateFilter(api.session, GetFlowMessagesFilterSchema, *filterStr); err != nil {
+			return nil, err
+		}
 	}
-	if paginationParams != "" {
-		params = append(params, paginationParams)
+
+	query := common.NewQuery()
+	if filterStr != nil {
+		query.Filter(*filterStr)
 	}
+	buildFlowActionMessagePaginationOptionsQueryParams(query, paginationOpt)
 
-	url := fmt.Sprintf("%s/api/flow-actions/%s/flow-messages/%s", api.baseApiUrl, flowActionId, strings.Join(params, "&"))
+	url := query.URL(fmt.Sprintf("%s/api/flow-actions/%s/flow-messages/", api.baseApiUrl, flowActionId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -414,15 +358,11 @@ func (api *flowsApi) GetFlowActionMessages(ctx context.Context, flowActionId str
 	return &messages, err
 }
 
-func (api *flowsApi) GetFlowActionForMessage(ctx context.Context, actionMessageId string, flowActionFields []models.FlowActionField) (*models.FlowActionResource, error) {
-	var params = ""
-
-	flowsFieldParam := models.BuildFlowActionFieldsParam(flowActionFields)
-	if flowsFieldParam != "" {
-		params = fmt.Sprintf("?%s", flowsFieldParam)
-	}
+func (api *flowsApi) GetFlowActionForMessage(ctx context.Context, actionMessageId string, flowActionFields models.Fields[models.FlowActionField]) (*models.FlowActionResource, error) {
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlowActionForMessage")
 
-	url := fmt.Sprintf("%s/api/flow-messages/%s/%s", api.baseApiUrl, actionMessageId, params)
+	query := common.NewQuery().Apply(flowActionFields)
+	url := query.URL(fmt.Sprintf("%s/api/flow-messages/%s/flow-action/", api.baseApiUrl, actionMessageId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -2,6 +2,7 @@ package flows
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 
@@ -46,7 +47,7 @@ func (suit *FlowsApiTestSuite) TestGetFlowsMessageServerError() {
 	var sortField FlowSortField = FlowSortFieldCreatedAtDESC
 	paginationOpt := FlowPaginationOptions{
 		PageSize: &pageSize,
-		Sort:     &sortField,
+		Sort:     models.SortBy(sortField),
 	}
 
 	_, err = suit.api.GetFlows(context.Background(), nil, opt, &paginationOpt)
@@ -73,7 +74,7 @@ func (suit *FlowsApiTestSuite) TestGetFlowsMessageBadRequest() {
 	var sortField FlowSortField = FlowSortFieldCreatedAtDESC
 	paginationOpt := FlowPaginationOptions{
 		PageSize: &pageSize,
-		Sort:     &sortField,
+		Sort:     models.SortBy(sortField),
 	}
 
 	_, err = suit.api.GetFlows(context.Background(), nil, opt, &paginationOpt)
@@ -100,7 +101,7 @@ func (suit *FlowsApiTestSuite) TestGetFlowsMessageStatusOk() {
 	var sortField FlowSortField = FlowSortFieldCreatedAtDESC
 	paginationOpt := FlowPaginationOptions{
 		PageSize: &pageSize,
-		Sort:     &sortField,
+		Sort:     models.SortBy(sortField),
 	}
 
 	res, err := suit.api.GetFlows(context.Background(), nil, opt, &paginationOpt)
@@ -200,6 +201,29 @@ func (suit *FlowsApiTestSuite) TestUpdateFlowStatusStatusOk() {
 	suit.Equal(mockedRespData.Data.ID, res.Data.ID)
 }
 
+func (suit *FlowsApiTestSuite) TestGetFlowActionIncludesFlowMessages() {
+	err := common.PrepareMockResponse(http.StatusOK, json.RawMessage(`{"data": {
+		"type": "flow-action", "id": "action-id",
+		"relationships": {
+			"flow": {"data": {"type": "flow", "id": "flow-id"}},
+			"flow-messages": {"data": [{"type": "flow-message", "id": "message-id"}]}
+		}
+	}}`), suit.mockedClient)
+	if err != nil {
+		suit.T().Fatal(err)
+	}
+
+	res, err := suit.api.GetFlowAction(context.Background(), "action-id", &GetFlowActionOptions{
+		Include: []FlowsActionIncludeField{FlowsActionIncludeFieldFlowMessage},
+	})
+
+	suit.Nil(err)
+	req := suit.mockedClient.Calls[0].Arguments.Get(0).(*http.Request)
+	suit.Equal("flow-messages", req.URL.Query().Get("include"))
+	suit.Equal([]models.RelationshipData{{Type: "flow", ID: "flow-id"}}, res.Data.Relationships.Flow.Data)
+	suit.Equal([]models.RelationshipData{{Type: "flow-message", ID: "message-id"}}, res.Data.Relationships.FlowMessage.Data)
+}
+
 func TestFlowsApiTestSuite(t *testing.T) {
 	suite.Run(t, new(FlowsApiTestSuite))
 
@@ -5,9 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/instrumentation"
 	"github.com/developertom01/klaviyo-go/models"
 )
 
@@ -29,22 +29,26 @@ type (
 		GetFlowMessageRelationshipsAction(ctx context.Context, flowMessageId string) (*models.RelationshipData, error)
 
 		//Returns the ID of the related template
-		GetFlowMessageRelationshipsTemplate(ctx context.Context, flowMessageId string, templateFields []models.TemplateField) (*models.TemplateResponse, error)
+		GetFlowMessageRelationshipsTemplate(ctx context.Context, flowMessageId string, templateFields models.Fields[models.TemplateField]) (*models.TemplateResponse, error)
 	}
 )
 
 func (api *flowsApi) GetFlowRelationshipsFlowActions(ctx context.Context, flowId string, filterStr *string, paginationOption *FlowActionPaginationOptions) (*models.RelationshipDataCollection, error) {
-	params := make([]string, 0)
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlowRelationshipsFlowActions")
+
 	if filterStr != nil {
-		params = append(params, *filterStr)
+		if err := common.ValidateFilter(api.session, GetFlowActionsFilterSchema, *filterStr); err != nil {
+			return nil, err
+		}
 	}
 
-	paginationPrams := buildGetFlowActionsPaginationOptionsQueryParams(paginationOption)
-	if paginationPrams != "" {
-		params = append(params, paginationPrams)
+	query := common.NewQuery()
+	if filterStr != nil {
+		query.Filter(*filterStr)
 	}
+	buildGetFlowActionsPaginationOptionsQueryParams(query, paginationOption)
 
-	url := fmt.Sprintf("%s/api/flows/%s/relationships/flow-actions/%s", api.baseApiUrl, flowId, strings.Join(params, "&"))
+	url := query.URL(fmt.Sprintf("%s/api/flows/%s/relationships/flow-actions/", api.baseApiUrl, flowId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -63,6 +67,8 @@ func (api *flowsApi) GetFlowRelationshipsFlowActions(ctx context.Context, flowId
 }
 
 func (api *flowsApi) GetFlowRelationshipsTags(ctx context.Context, flowId string) (*models.RelationshipDataCollection, error) {
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlowRelationshipsTags")
+
 	url := fmt.Sprintf("%s/api/flows/%s/relationships/tags/", api.baseApiUrl, flowId)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -82,6 +88,8 @@ func (api *flowsApi) GetFlowRelationshipsTags(ctx context.Context, flowId string
 }
 
 func (api *flowsApi) GetFlowActionRelationshipsFlow(ctx context.Context, flowActionId string) (*models.RelationshipData, error) {
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlowActionRelationshipsFlow")
+
 	url := fmt.Sprintf("%s/api/flow-actions/%s/relationships/flow/", api.baseApiUrl, flowActionId)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -101,17 +109,21 @@ func (api *flowsApi) GetFlowActionRelationshipsFlow(ctx context.Context, flowAct
 }
 
 func (api *flowsApi) GetFlowActionRelationshipsMessages(ctx context.Context, flowId string, filterStr *string, paginationOption *FlowActionMessagePaginationOptions) (*models.RelationshipDataCollection, error) {
-	params := make([]string, 0)
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlowActionRelationshipsMessages")
+
 	if filterStr != nil {
-		params = append(params, *filterStr)
+		if err := common.ValidateFilter(api.session, GetFlowMessagesFilterSchema, *filterStr); err != nil {
+			return nil, err
+		}
 	}
 
-	paginationPrams := buildFlowActionMessagePaginationOptionsQueryParams(paginationOption)
-	if paginationPrams != "" {
-		params = append(params, paginationPrams)
+	query := common.NewQuery()
+	if filterStr != nil {
+		query.Filter(*filterStr)
 	}
+	buildFlowActionMessagePaginationOptionsQueryParams(query, paginationOption)
 
-	url := fmt.Sprintf("%s/api/flow-actions/%s/relationships/flow-messages/%s", api.baseApiUrl, flowId, strings.Join(params, "&"))
+	url := query.URL(fmt.Sprintf("%s/api/flow-actions/%s/relationships/flow-messages/", api.baseApiUrl, flowId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -130,6 +142,8 @@ func (api *flowsApi) GetFlowActionRelationshipsMessages(ctx context.Context, flo
 }
 
 func (api *flowsApi) GetFlowMessageRelationshipsAction(ctx context.Context, flowMessageId string) (*models.RelationshipData, error) {
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlowMessageRelationshipsAction")
+
 	url := fmt.Sprintf("%s/api/flow-actions/%s/relationships/flow/", api.baseApiUrl, flowMessageId)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -148,15 +162,11 @@ func (api *flowsApi) GetFlowMessageRelationshipsAction(ctx context.Context, flow
 	return &relationships, err
 }
 
-func (api *flowsApi) GetFlowMessageRelationshipsTemplate(ctx context.Context, flowMessageId string, templateFields []models.TemplateField) (*models.TemplateResponse, error) {
-	var params = ""
-
-	templateFieldsParam := models.BuildTemplateFieldParam(templateFields)
-	if templateFieldsParam != "" {
-		params = fmt.Sprintf("?%s", templateFieldsParam)
-	}
+func (api *flowsApi) GetFlowMessageRelationshipsTemplate(ctx context.Context, flowMessageId string, templateFields models.Fields[models.TemplateField]) (*models.TemplateResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "FlowsApi.GetFlowMessageRelationshipsTemplate")
 
-	url := fmt.Sprintf("%s/api/flow-messages/%s/template/%s", api.baseApiUrl, flowMessageId, params)
+	query := common.NewQuery().Apply(templateFields)
+	url := query.URL(fmt.Sprintf("%s/api/flow-messages/%s/template/", api.baseApiUrl, flowMessageId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
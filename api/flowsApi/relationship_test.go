@@ -34,7 +34,7 @@ func (suit *FlowsRelationshipsApiTestSuite) TestGetFlowRelationshipsFlowActions(
 		suit.T().Fatal(err)
 	}
 
-	filter := common.NewFilterBuilder().Contains("name", "flow-1")
+	filter := common.NewFilterBuilder().Equal("status", "live")
 	filterPram := filter.Build()
 	res, err := suit.api.GetFlowRelationshipsFlowActions(context.Background(), flowId, &filterPram, nil)
 
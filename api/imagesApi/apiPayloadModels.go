@@ -1,8 +1,12 @@
 package images
 
+import "github.com/developertom01/klaviyo-go/common"
+
 type UploadImageFromFilePayload struct {
 	Name   *string `json:"name,omitempty"`   //A name for the image. Defaults to the filename if not provided. If the name matches an existing image, a suffix will be added.
 	Hidden *bool   `json:"hidden,omitempty"` //If true, this image is not shown in the asset library.
+
+	OnProgress func(progress common.UploadProgress) `json:"-"` //Called as the file is sent
 }
 
 type (
@@ -25,7 +29,8 @@ type (
 	}
 
 	UpdateImagePayload struct {
-		Type       string                       `json:"type"`
-		Attributes UploadImageFromUrlAttributes `json:"attributes"`
+		Type       string                `json:"type"` //image
+		ID         string                `json:"id"`   //The ID of the image
+		Attributes UpdateImageAttributes `json:"attributes"`
 	}
 )
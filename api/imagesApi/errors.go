@@ -6,3 +6,9 @@ import (
 
 var serializationError = errors.New("Serializing data failed")
 var urlSerializationError = errors.New("Serializing url failed")
+
+// Returned when an uploaded file is not a jpeg, png or gif image
+var ErrUnsupportedImageFormat = errors.New("Unsupported image format, expected jpeg, png or gif")
+
+// Returned by SyncImages when ImageSyncOptions.HideStale is set without a Prefix, which could hide images of other syncs
+var ErrImageSyncPrefixRequired = errors.New("Hiding stale images requires a prefix")
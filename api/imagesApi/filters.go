@@ -0,0 +1,18 @@
+package images
+
+import (
+	"github.com/developertom01/klaviyo-go/common/filter"
+)
+
+// Filter accepted by GetImages
+var GetImagesFilterSchema = filter.Schema{
+	Endpoint: "GET /api/images/",
+	Fields: map[string][]filter.Operator{
+		"id":         {filter.OperatorAny, filter.OperatorEquals},
+		"updated_at": filter.DateTimeOperators,
+		"format":     {filter.OperatorAny, filter.OperatorEquals},
+		"name":       {filter.OperatorAny, filter.OperatorContains, filter.OperatorEndsWith, filter.OperatorEquals, filter.OperatorStartsWith},
+		"size":       {filter.OperatorGreaterOrEqual, filter.OperatorGreaterThan, filter.OperatorLessOrEqual, filter.OperatorLessThan},
+		"hidden":     {filter.OperatorAny, filter.OperatorEquals},
+	},
+}
@@ -7,19 +7,29 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 
 	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/instrumentation"
 	"github.com/developertom01/klaviyo-go/models"
 )
 
+// Maximum size of an uploaded image in bytes
+const MAX_IMAGE_SIZE = 5 << 20
+
+// Formats of uploaded images by content type
+var imageFormats = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
 type (
 	ImagesApi interface {
 		//Get all images in an account.
 		GetImages(ctx context.Context, filterString string, options *GetImagesOptions) (*models.ImageCollectionResponse, error)
 		//Get the image with the given image ID.
-		GetImage(ctx context.Context, imageId string, fields []models.ImageField) (*models.ImageResponse, error)
-		//Upload an image from a file.
+		GetImage(ctx context.Context, imageId string, fields models.Fields[models.ImageField]) (*models.ImageResponse, error)
+		//Upload a jpeg, png or gif image of at most 5MB from a file. The file is streamed, and resent on failure when it is an io.Seeker, eg. *os.File.
 		//If you want to import an image from an existing url or a data uri, use the UploadImageFromUrl instead.
 		UploadImageFromFile(ctx context.Context, file io.Reader, payload UploadImageFromFilePayload) (*models.ImageResponse, error)
 		//Import an image from a url or data uri.
@@ -38,54 +48,50 @@ type (
 
 	GetImagesOptions struct {
 		PageCursor *string //For more information please visit https://developers.klaviyo.com/en/v2024-02-15/reference/api-overview#pagination
-		Sort       *models.ImageSortField
-		pageSize   *int //Default: 20. Min: 1. Max: 100.
-		Fields     []models.ImageField
+		Sort       models.Sort[models.ImageSortField]
+		PageSize   *int //Default: 20. Min: 1. Max: 100.
+		Fields     models.Fields[models.ImageField]
 	}
 )
 
 func NewImagesApi(session common.Session, httpClient common.HTTPClient) ImagesApi {
-	var client common.HTTPClient
-	if httpClient == nil {
-		client = http.DefaultClient
-	} else {
-		client = httpClient
-	}
+	opt := session.GetOptions()
 
 	return &imageApi{
 		session:    session,
-		baseApiUrl: common.BASE_URL,
-		revision:   common.API_REVISION,
-		httpClient: client}
+		baseApiUrl: common.ResolveBaseUrl(opt),
+		revision:   common.ResolveRevision(opt),
+		httpClient: common.ResolveHTTPClient(opt, httpClient)}
 }
 
-func buildGetImagesOptionsParams(filter string, opt *GetImagesOptions) string {
+func buildGetImagesOptionsParams(filter string, opt *GetImagesOptions) *common.Query {
+	query := common.NewQuery().Filter(filter)
 	if opt == nil {
-		return filter
+		return query
 	}
-	var params = []string{filter}
 
-	if opt.Fields != nil {
-		params = append(params, models.BuildImageFieldParam(opt.Fields))
-	}
+	query.Apply(opt.Fields, opt.Sort)
 
 	if opt.PageCursor != nil {
-		params = append(params, fmt.Sprintf("page[cursor]=%s", *opt.PageCursor))
-	}
-	if opt.Sort != nil {
-		params = append(params, fmt.Sprintf("sort=%s", *opt.Sort))
+		query.PageCursor(*opt.PageCursor)
 	}
 
-	if opt.pageSize != nil {
-		params = append(params, fmt.Sprintf("page[size]=%d", *opt.pageSize))
+	if opt.PageSize != nil {
+		query.PageSize(*opt.PageSize)
 	}
 
-	return strings.Join(params, "&")
+	return query
 }
 
 func (api *imageApi) GetImages(ctx context.Context, filterString string, options *GetImagesOptions) (*models.ImageCollectionResponse, error) {
-	params := buildGetImagesOptionsParams(filterString, options)
-	url := fmt.Sprintf("%s/api/images/?%s", api.baseApiUrl, params)
+	ctx = instrumentation.WithOperation(ctx, "ImagesApi.GetImages")
+
+	if err := common.ValidateFilter(api.session, GetImagesFilterSchema, filterString); err != nil {
+		return nil, err
+	}
+
+	query := buildGetImagesOptionsParams(filterString, options)
+	url := query.URL(fmt.Sprintf("%s/api/images/", api.baseApiUrl))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -103,9 +109,11 @@ func (api *imageApi) GetImages(ctx context.Context, filterString string, options
 	return &imageCollection, err
 }
 
-func (api *imageApi) GetImage(ctx context.Context, imageId string, fields []models.ImageField) (*models.ImageResponse, error) {
-	params := models.BuildImageFieldParam(fields)
-	url := fmt.Sprintf("%s/api/images/%s/?%s", api.baseApiUrl, imageId, params)
+func (api *imageApi) GetImage(ctx context.Context, imageId string, fields models.Fields[models.ImageField]) (*models.ImageResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "ImagesApi.GetImage")
+
+	query := common.NewQuery().Apply(fields)
+	url := query.URL(fmt.Sprintf("%s/api/images/%s/", api.baseApiUrl, imageId))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -124,13 +132,20 @@ func (api *imageApi) GetImage(ctx context.Context, imageId string, fields []mode
 }
 
 func (api *imageApi) UploadImageFromFile(ctx context.Context, file io.Reader, payload UploadImageFromFilePayload) (*models.ImageResponse, error) {
-	url := fmt.Sprintf("%s/api/image-upload/", api.baseApiUrl)
+	ctx = instrumentation.WithOperation(ctx, "ImagesApi.UploadImageFromFile")
 
-	var imageFieldName = "image"
+	file, contentType, err := sniffImage(file)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/api/image-upload/", api.baseApiUrl)
 
+	fileName := "image." + imageFormats[contentType]
 	requestMeta := make(map[string]string)
 
 	if payload.Name != nil {
+		fileName = *payload.Name
 		requestMeta["name"] = *payload.Name
 	}
 	if payload.Hidden != nil {
@@ -143,9 +158,12 @@ func (api *imageApi) UploadImageFromFile(ctx context.Context, file io.Reader, pa
 
 	multipartOptions := common.MultipartOptions{
 		File:          file,
-		FileFieldName: *payload.Name,
-		FileName:      imageFieldName,
+		FileFieldName: "file",
+		FileName:      fileName,
+		ContentType:   contentType,
+		MaxSize:       MAX_IMAGE_SIZE,
 		Meta:          requestMeta,
+		OnProgress:    payload.OnProgress,
 	}
 	requestOptions := common.MultipartRequestOption{
 		HttpClient: api.httpClient,
@@ -165,7 +183,33 @@ func (api *imageApi) UploadImageFromFile(ctx context.Context, file io.Reader, pa
 	return &imageUploadResponse, err
 }
 
+// Detects the format of `file` from its first bytes. Returns a reader of the whole file and its content type,
+// or an error wrapping ErrUnsupportedImageFormat when it is not a jpeg, png or gif image
+func sniffImage(file io.Reader) (io.Reader, string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, "", err
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	if _, ok := imageFormats[contentType]; !ok {
+		return nil, "", fmt.Errorf("%w, detected %s", ErrUnsupportedImageFormat, contentType)
+	}
+
+	// Seeking back keeps the file seekable, so that the upload can be resent
+	if seeker, ok := file.(io.Seeker); ok {
+		if _, err := seeker.Seek(int64(-n), io.SeekCurrent); err == nil {
+			return file, contentType, nil
+		}
+	}
+	return io.MultiReader(bytes.NewReader(head), file), contentType, nil
+}
+
 func (api *imageApi) UploadImageFromURL(ctx context.Context, payload UploadImageFromUrlPayload) (*models.ImageResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "ImagesApi.UploadImageFromURL")
+
 	url := fmt.Sprintf("%s/api/images/", api.baseApiUrl)
 
 	reqPayload, err := json.Marshal(payload)
@@ -174,7 +218,7 @@ func (api *imageApi) UploadImageFromURL(ctx context.Context, payload UploadImage
 	}
 
 	reqPayloadBuffer := bytes.NewBuffer(reqPayload)
-	req, err := http.NewRequest(http.MethodPost, url, reqPayloadBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqPayloadBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -191,6 +235,8 @@ func (api *imageApi) UploadImageFromURL(ctx context.Context, payload UploadImage
 }
 
 func (api *imageApi) UpdateImage(ctx context.Context, imageId string, payload UpdateImagePayload) (*models.ImageResponse, error) {
+	ctx = instrumentation.WithOperation(ctx, "ImagesApi.UpdateImage")
+
 	url := fmt.Sprintf("%s/api/images/%s/", api.baseApiUrl, imageId)
 
 	reqPayload, err := json.Marshal(payload)
@@ -199,7 +245,7 @@ func (api *imageApi) UpdateImage(ctx context.Context, imageId string, payload Up
 	}
 
 	reqPayloadBuffer := bytes.NewBuffer(reqPayload)
-	req, err := http.NewRequest(http.MethodPatch, url, reqPayloadBuffer)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, reqPayloadBuffer)
 	if err != nil {
 		return nil, err
 	}
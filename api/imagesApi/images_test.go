@@ -1,7 +1,9 @@
 package images
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"net/http"
 	"strings"
 	"testing"
@@ -9,9 +11,13 @@ import (
 	"github.com/developertom01/klaviyo-go/common"
 	"github.com/developertom01/klaviyo-go/models"
 	"github.com/developertom01/klaviyo-go/options"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
+// Signature of a png file
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
 type ImagesApiTestSuite struct {
 	suite.Suite
 	api          ImagesApi
@@ -65,21 +71,44 @@ func (suit *ImagesApiTestSuite) TestUploadImageFromFile() {
 
 	mockedResponse := models.MockImageResponse()
 
-	multipartFile := strings.NewReader("Some test reader")
+	multipartFile := strings.NewReader(pngHeader + "Some test reader")
+	var progress []common.UploadProgress
 	payload := UploadImageFromFilePayload{
 		Name:   &name,
 		Hidden: &hidden,
+		OnProgress: func(p common.UploadProgress) {
+			progress = append(progress, p)
+		},
 	}
 
 	err := common.PrepareMockResponse(http.StatusOK, mockedResponse, suit.mockedClient)
 	if err != nil {
 		suit.T().Fatal(err)
 	}
+	// Reads the streamed form, as a transport would
+	suit.mockedClient.ExpectedCalls[0].Run(func(args mock.Arguments) {
+		io.Copy(io.Discard, args.Get(0).(*http.Request).Body)
+	})
 
 	resp, err := suit.api.UploadImageFromFile(context.Background(), multipartFile, payload)
 
 	suit.Nil(err)
 	suit.NotNil(resp)
+
+	req := suit.mockedClient.Calls[0].Arguments.Get(0).(*http.Request)
+	suit.True(strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data; boundary="))
+	suit.Equal([]common.UploadProgress{{Sent: int64(len(pngHeader + "Some test reader")), Total: int64(len(pngHeader + "Some test reader"))}}, progress)
+}
+
+func (suit *ImagesApiTestSuite) TestUploadImageFromFileValidatesImage() {
+	_, err := suit.api.UploadImageFromFile(context.Background(), strings.NewReader("Some test reader"), UploadImageFromFilePayload{})
+	suit.ErrorIs(err, ErrUnsupportedImageFormat)
+
+	large := bytes.NewReader(append([]byte(pngHeader), make([]byte, MAX_IMAGE_SIZE)...))
+	_, err = suit.api.UploadImageFromFile(context.Background(), large, UploadImageFromFilePayload{})
+	suit.ErrorIs(err, common.ErrFileTooLarge)
+
+	suit.mockedClient.AssertNotCalled(suit.T(), "Do", mock.Anything)
 }
 
 func TestImagesApiTestSuite(t *testing.T) {
@@ -0,0 +1,353 @@
+package images
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/common/filter"
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Length of the content hash in the names of synced images
+const imageHashLength = 12
+
+// Matches the name of a synced image, eg. banners/summer.3f2a9c1b0d4e.png, capturing the name without the hash
+var syncedImageNamePattern = regexp.MustCompile(`^(.*)\.[0-9a-f]{12}(\.[^./]*)?$`)
+
+type (
+	// Options of SyncImages
+	ImageSyncOptions struct {
+		DryRun             bool   //Reports the changes without uploading, showing or hiding images
+		HideStale          bool   //Hides synced images whose file was changed or removed. Requires a Prefix
+		Prefix             string //Prepended to the names of the images, eg. assets/. Only images named with it are matched and hidden
+		MaxUploadsInFlight int    //Uploads, and updates of images, in progress at one time
+	}
+
+	// Outcome of SyncImages. Files are identified by their slash-separated path
+	ImageSyncReport struct {
+		DryRun    bool
+		Uploaded  []string
+		Unchanged []string //Files matching an existing image, shown again when it was hidden
+		Hidden    []string //Names of the hidden stale images
+		Failed    []ImageSyncFailure
+		Manifest  map[string]string //Path of each file to the URL of its image. In a dry run, files to upload have none
+	}
+
+	// Failure of a single file or stale image
+	ImageSyncFailure struct {
+		Path string //Path of the file, or name of the stale image
+		Err  error
+	}
+
+	// File of a synced folder
+	imageFile struct {
+		path string
+		name string //Name of the image of the file, holding the hash of its content
+	}
+)
+
+func NewImageSyncOptionsWithDefaultValues() *ImageSyncOptions {
+	return &ImageSyncOptions{
+		MaxUploadsInFlight: 4,
+	}
+}
+
+func (f ImageSyncFailure) Error() string {
+	return fmt.Sprintf("%s: %v", f.Path, f.Err)
+}
+
+func (f ImageSyncFailure) Unwrap() error {
+	return f.Err
+}
+
+// Mirrors the images of `dir` into the asset library, see SyncImages
+func SyncImagesFromDir(ctx context.Context, api ImagesApi, dir string, opt *ImageSyncOptions) (*ImageSyncReport, error) {
+	return SyncImages(ctx, api, os.DirFS(dir), opt)
+}
+
+// Mirrors the images of `fsys` into the asset library. Images are named after the path of their file with a hash of
+// its content, eg. banners/summer.3f2a9c1b0d4e.png, so that a file matches an existing image by name and content,
+// and only new or changed files are uploaded. Hidden files and folders, eg. .DS_Store, are skipped.
+// Returns an error when the files or the images can not be listed, failures of single files are in ImageSyncReport.Failed.
+// Returns ErrImageSyncPrefixRequired when stale images are hidden without a prefix
+func SyncImages(ctx context.Context, api ImagesApi, fsys fs.FS, opt *ImageSyncOptions) (*ImageSyncReport, error) {
+	if opt == nil {
+		opt = NewImageSyncOptionsWithDefaultValues()
+	}
+	if opt.HideStale && opt.Prefix == "" {
+		return nil, ErrImageSyncPrefixRequired
+	}
+	concurrency := max(opt.MaxUploadsInFlight, 1)
+
+	report := &ImageSyncReport{DryRun: opt.DryRun, Manifest: map[string]string{}}
+
+	files, err := hashImageFiles(fsys, opt.Prefix, report)
+	if err != nil {
+		return report, err
+	}
+
+	existing, err := listSyncedImages(ctx, api, opt.Prefix)
+	if err != nil {
+		return report, err
+	}
+
+	var (
+		mu       sync.Mutex
+		uploads  []imageFile
+		restores []models.Image
+		current  = map[string]bool{}
+	)
+	for _, file := range files {
+		current[file.name] = true
+
+		image, ok := existing[file.name]
+		if !ok {
+			uploads = append(uploads, file)
+			continue
+		}
+
+		report.Unchanged = append(report.Unchanged, file.path)
+		report.Manifest[file.path] = image.Attributes.ImageUrl
+		if image.Attributes.Hidden {
+			restores = append(restores, image)
+		}
+	}
+
+	// Appends `name` to `list`, or to the failures when `err` is set
+	record := func(name string, err error, list *[]string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			report.Failed = append(report.Failed, ImageSyncFailure{Path: name, Err: err})
+		} else if list != nil {
+			*list = append(*list, name)
+		}
+	}
+
+	if opt.DryRun {
+		for _, file := range uploads {
+			report.Uploaded = append(report.Uploaded, file.path)
+		}
+	} else {
+		inFlight(ctx, concurrency, len(uploads), func(i int) {
+			file := uploads[i]
+			image, err := uploadImageFile(ctx, api, fsys, file)
+			if err == nil {
+				mu.Lock()
+				report.Manifest[file.path] = image.Attributes.ImageUrl
+				mu.Unlock()
+			}
+			record(file.path, err, &report.Uploaded)
+		})
+
+		inFlight(ctx, concurrency, len(restores), func(i int) {
+			_, err := api.UpdateImage(ctx, restores[i].ID, hiddenImagePayload(restores[i].ID, false))
+			if err != nil {
+				err = fmt.Errorf("showing image: %w", err)
+			}
+			record(restores[i].Attributes.Name, err, nil)
+		})
+	}
+
+	if opt.HideStale {
+		// Stale versions of files whose upload failed are kept, so that their images remain available
+		failed := map[string]bool{}
+		for _, failure := range report.Failed {
+			failed[failure.Path] = true
+		}
+
+		var stale []models.Image
+		for name, image := range existing {
+			if current[name] || image.Attributes.Hidden {
+				continue
+			}
+			match := syncedImageNamePattern.FindStringSubmatch(name)
+			if match == nil || failed[strings.TrimPrefix(match[1]+match[2], opt.Prefix)] {
+				continue
+			}
+			stale = append(stale, image)
+		}
+
+		if opt.DryRun {
+			for _, image := range stale {
+				report.Hidden = append(report.Hidden, image.Attributes.Name)
+			}
+		} else {
+			inFlight(ctx, concurrency, len(stale), func(i int) {
+				_, err := api.UpdateImage(ctx, stale[i].ID, hiddenImagePayload(stale[i].ID, true))
+				record(stale[i].Attributes.Name, err, &report.Hidden)
+			})
+		}
+	}
+
+	sort.Strings(report.Uploaded)
+	sort.Strings(report.Unchanged)
+	sort.Strings(report.Hidden)
+	sort.Slice(report.Failed, func(i, j int) bool {
+		return report.Failed[i].Path < report.Failed[j].Path
+	})
+
+	return report, ctx.Err()
+}
+
+// Returns the image files of `fsys` named after their path and content. Files that are not jpeg, png or gif images
+// of at most MAX_IMAGE_SIZE are reported as failures
+func hashImageFiles(fsys fs.FS, prefix string, report *ImageSyncReport) ([]imageFile, error) {
+	var files []imageFile
+	err := fs.WalkDir(fsys, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if filePath != "." && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		hash, err := hashImageFile(fsys, filePath)
+		if err != nil {
+			report.Failed = append(report.Failed, ImageSyncFailure{Path: filePath, Err: err})
+			return nil
+		}
+
+		ext := path.Ext(filePath)
+		name := fmt.Sprintf("%s%s.%s%s", prefix, strings.TrimSuffix(filePath, ext), hash, ext)
+		files = append(files, imageFile{path: filePath, name: name})
+		return nil
+	})
+
+	return files, err
+}
+
+// Returns the truncated SHA-256 of the content of a jpeg, png or gif image of at most MAX_IMAGE_SIZE
+func hashImageFile(fsys fs.FS, filePath string) (string, error) {
+	file, err := fsys.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	if contentType := http.DetectContentType(head[:n]); imageFormats[contentType] == "" {
+		return "", fmt.Errorf("%w, detected %s", ErrUnsupportedImageFormat, contentType)
+	}
+
+	hash := sha256.New()
+	hash.Write(head[:n])
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
+	if int64(n)+size > MAX_IMAGE_SIZE {
+		return "", fmt.Errorf("%w: %d bytes exceed %d", common.ErrFileTooLarge, int64(n)+size, MAX_IMAGE_SIZE)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))[:imageHashLength], nil
+}
+
+// Returns the images named with `prefix` by name. A visible image is kept over hidden ones of the same name
+func listSyncedImages(ctx context.Context, api ImagesApi, prefix string) (map[string]models.Image, error) {
+	var filterString string
+	if prefix != "" {
+		var err error
+		if filterString, err = filter.Build(filter.StartsWith("name", prefix)); err != nil {
+			return nil, err
+		}
+	}
+
+	pageSize := 100
+	options := &GetImagesOptions{PageSize: &pageSize}
+	images := map[string]models.Image{}
+	for {
+		page, err := api.GetImages(ctx, filterString, options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, image := range page.Data {
+			if !strings.HasPrefix(image.Attributes.Name, prefix) {
+				continue
+			}
+			if known, ok := images[image.Attributes.Name]; ok && !known.Attributes.Hidden {
+				continue
+			}
+			images[image.Attributes.Name] = image
+		}
+
+		if page.Links.Next == nil {
+			return images, nil
+		}
+		options.PageCursor = page.Links.Next
+	}
+}
+
+func uploadImageFile(ctx context.Context, api ImagesApi, fsys fs.FS, file imageFile) (*models.Image, error) {
+	content, err := fsys.Open(file.path)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	res, err := api.UploadImageFromFile(ctx, content, UploadImageFromFilePayload{Name: &file.name})
+	if err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+func hiddenImagePayload(imageId string, hidden bool) UpdateImagePayload {
+	return UpdateImagePayload{
+		Type:       "image",
+		ID:         imageId,
+		Attributes: UpdateImageAttributes{Hidden: &hidden},
+	}
+}
+
+// Calls `fn` with 0 to `count`-1, with at most `concurrency` calls in progress. Stops starting calls once `ctx` is done
+func inFlight(ctx context.Context, concurrency int, count int, fn func(i int)) {
+	var (
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, concurrency)
+	)
+
+	for i := 0; i < count; i++ {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case slots <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,136 @@
+package images_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	images "github.com/developertom01/klaviyo-go/api/imagesApi"
+	"github.com/developertom01/klaviyo-go/klaviyotest"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// Signature of a png file
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+type ImageSyncTestSuite struct {
+	suite.Suite
+	server *klaviyotest.Server
+	folder fstest.MapFS
+}
+
+func (suit *ImageSyncTestSuite) SetupTest() {
+	suit.server = klaviyotest.NewServer()
+	suit.folder = fstest.MapFS{
+		"logo.png":            {Data: []byte(pngHeader + "logo")},
+		"banners/summer.png":  {Data: []byte(pngHeader + "summer")},
+		"banners/.DS_Store":   {Data: []byte("finder")},
+		".drafts/winter.png":  {Data: []byte(pngHeader + "winter")},
+		"banners/notes.txt":   {Data: []byte("notes")},
+		"banners/spring.jpeg": {Data: []byte("\xff\xd8\xff" + "spring")},
+	}
+}
+
+func (suit *ImageSyncTestSuite) TearDownTest() {
+	suit.server.Close()
+}
+
+func (suit *ImageSyncTestSuite) sync(opt *images.ImageSyncOptions) *images.ImageSyncReport {
+	report, err := images.SyncImages(context.Background(), suit.server.Client().Images, suit.folder, opt)
+	suit.Require().NoError(err)
+
+	return report
+}
+
+func (suit *ImageSyncTestSuite) TestSyncImages() {
+	report := suit.sync(nil)
+
+	suit.Equal([]string{"banners/spring.jpeg", "banners/summer.png", "logo.png"}, report.Uploaded)
+	suit.Require().Len(report.Failed, 1)
+	suit.Equal("banners/notes.txt", report.Failed[0].Path)
+	suit.ErrorIs(report.Failed[0], images.ErrUnsupportedImageFormat)
+	suit.Len(report.Manifest, 3)
+
+	var urls []string
+	for _, image := range suit.server.Images().List() {
+		suit.Regexp(regexp.MustCompile(`^(logo|banners/summer|banners/spring)\.[0-9a-f]{12}\.(png|jpeg)$`), image.Attributes.Name)
+		urls = append(urls, image.Attributes.ImageUrl)
+	}
+	suit.ElementsMatch(urls, []string{report.Manifest["logo.png"], report.Manifest["banners/summer.png"], report.Manifest["banners/spring.jpeg"]})
+
+	// A second sync matches every image
+	report = suit.sync(nil)
+	suit.Empty(report.Uploaded)
+	suit.Equal([]string{"banners/spring.jpeg", "banners/summer.png", "logo.png"}, report.Unchanged)
+	suit.Equal(3, suit.server.Images().Len())
+}
+
+func (suit *ImageSyncTestSuite) TestSyncImagesHidesStaleImages() {
+	opt := images.NewImageSyncOptionsWithDefaultValues()
+	opt.Prefix = "assets/"
+	first := suit.sync(opt)
+	unrelated := suit.server.Images().Put(models.Image{Type: "image", Attributes: models.ImageAttributes{Name: "hand-made.png"}})
+	// Named like a synced image, but outside the prefix
+	otherSync := suit.server.Images().Put(models.Image{Type: "image", Attributes: models.ImageAttributes{Name: "campaigns/hero.0123456789ab.png"}})
+
+	suit.folder["logo.png"] = &fstest.MapFile{Data: []byte(pngHeader + "new logo")}
+	delete(suit.folder, "banners/spring.jpeg")
+
+	opt.HideStale = true
+	report := suit.sync(opt)
+
+	suit.Equal([]string{"logo.png"}, report.Uploaded)
+	suit.Equal([]string{"banners/summer.png"}, report.Unchanged)
+	suit.Len(report.Hidden, 2)
+	suit.NotEqual(first.Manifest["logo.png"], report.Manifest["logo.png"])
+	suit.Equal(first.Manifest["banners/summer.png"], report.Manifest["banners/summer.png"])
+
+	for _, image := range suit.server.Images().List() {
+		current := image.Attributes.ImageUrl == report.Manifest["logo.png"] || image.Attributes.ImageUrl == report.Manifest["banners/summer.png"]
+		suit.Equal(!current && image.ID != unrelated.ID && image.ID != otherSync.ID, image.Attributes.Hidden, image.Attributes.Name)
+	}
+
+	// Restoring the file shows its hidden image again
+	suit.folder["banners/spring.jpeg"] = &fstest.MapFile{Data: []byte("\xff\xd8\xff" + "spring")}
+	report = suit.sync(opt)
+	suit.Empty(report.Uploaded)
+	suit.Equal(first.Manifest["banners/spring.jpeg"], report.Manifest["banners/spring.jpeg"])
+	for _, image := range suit.server.Images().List() {
+		if image.Attributes.ImageUrl == report.Manifest["banners/spring.jpeg"] {
+			suit.False(image.Attributes.Hidden)
+		}
+	}
+}
+
+func (suit *ImageSyncTestSuite) TestSyncImagesHideStaleRequiresPrefix() {
+	opt := images.NewImageSyncOptionsWithDefaultValues()
+	opt.HideStale = true
+
+	_, err := images.SyncImages(context.Background(), suit.server.Client().Images, suit.folder, opt)
+	suit.ErrorIs(err, images.ErrImageSyncPrefixRequired)
+	suit.Empty(suit.server.Requests())
+}
+
+func (suit *ImageSyncTestSuite) TestSyncImagesDryRun() {
+	opt := images.NewImageSyncOptionsWithDefaultValues()
+	opt.DryRun = true
+	opt.Prefix = "assets/"
+	report := suit.sync(opt)
+
+	suit.True(report.DryRun)
+	suit.Equal([]string{"banners/spring.jpeg", "banners/summer.png", "logo.png"}, report.Uploaded)
+	suit.Empty(report.Manifest)
+	suit.Equal(0, suit.server.Images().Len())
+
+	requests := suit.server.Requests()
+	suit.Require().Len(requests, 1)
+	suit.Equal(http.MethodGet, requests[0].Method)
+	suit.Equal(`starts-with(name,"assets/")`, requests[0].Query.Get("filter"))
+}
+
+func TestImageSyncTestSuite(t *testing.T) {
+	suite.Run(t, new(ImageSyncTestSuite))
+}
@@ -1,23 +1,39 @@
 package common
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/developertom01/klaviyo-go/exceptions"
+	"github.com/developertom01/klaviyo-go/instrumentation"
 )
 
 type (
 	MultipartOptions struct {
-		File          io.Reader
+		File          io.Reader //Sent from its current offset. A failed upload is resent only when it is an io.Seeker, eg. *os.File
 		FileFieldName string
 		FileName      string
+		ContentType   string //Content type of the file part, application/octet-stream when empty
+		MaxSize       int64  //Maximum size of the file in bytes, unbounded when zero
 
 		Meta map[string]string
+
+		OnProgress func(progress UploadProgress) //Called as the file is sent, from the goroutine sending it
+	}
+
+	// Progress of a file upload
+	UploadProgress struct {
+		Sent  int64 //Bytes of the file sent, restarting from zero when the upload is resent
+		Total int64 //Size of the file, -1 when unknown
 	}
 
 	MultipartRequestOption struct {
@@ -29,15 +45,77 @@ type (
 )
 
 func executeRequest(httpClient HTTPClient, req *http.Request, session Session, revision string) (*http.Response, error) {
-	req.Header.Add("revision", revision)
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
-	session.ApplyToRequest(session.GetOptions(), req)
 
+	return doWithRetry(httpClient, req, session, revision)
+}
+
+// Authorizes and sends `req` using the session retry policy, logging and instrumenting every attempt.
+// Requests rejected with 401 are sent once more when the session can refresh its credentials.
+func doWithRetry(httpClient HTTPClient, req *http.Request, session Session, revision string) (*http.Response, error) {
+	httpClient, req, cancel, err := applyRequestOptions(httpClient, req, session, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := session.GetOptions().Logger()
+	instr := session.GetOptions().Instrumentation()
+
+	operation := instrumentation.OperationFromContext(req.Context())
+	ctx, endOperation := instr.StartOperation(req.Context(), operation, req)
+	req = req.WithContext(ctx)
+
+	var attempts int
 	execFn := func() (*http.Response, error) {
-		return httpClient.Do(req)
+		if attempts > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+		attempts++
+
+		attemptCtx, endAttempt := instr.StartAttempt(ctx, req, attempts)
+		attemptReq := req.WithContext(attemptCtx)
+
+		start := time.Now()
+		res, err := httpClient.Do(attemptReq)
+		logAttempt(logger, attemptReq, res, err, attempts, time.Since(start))
+		endAttempt(res, err)
+
+		return res, err
+	}
+
+	res, err := authorizeAndRetry(execFn, req, session)
+	if err == nil && res.StatusCode == http.StatusUnauthorized {
+		if refreshable, ok := session.(RefreshableSession); ok {
+			res.Body.Close()
+
+			if err = refreshable.RefreshAfterUnauthorized(req); err == nil {
+				res, err = authorizeAndRetry(execFn, req, session)
+			}
+		}
+	}
+
+	endOperation(res, err)
+
+	if err != nil || res.Body == nil {
+		cancel()
+		return res, err
 	}
-	return Retry(execFn, session.GetRetryOptions())
+	res.Body = cancelOnCloseBody{ReadCloser: res.Body, cancel: cancel}
+
+	return res, nil
+}
+
+func authorizeAndRetry(execFn RetryableFunc, req *http.Request, session Session) (*http.Response, error) {
+	if err := session.ApplyToRequest(session.GetOptions(), req); err != nil {
+		return nil, err
+	}
+
+	return RetryWithLogger(execFn, session.GetRetryOptions(), session.GetOptions().Logger())
 }
 
 func RetrieveData(httpClient HTTPClient, req *http.Request, session Session, revision string) ([]byte, error) {
@@ -48,67 +126,216 @@ func RetrieveData(httpClient HTTPClient, req *http.Request, session Session, rev
 	defer res.Body.Close()
 
 	if !exceptions.IsHttpCodeOk(res.StatusCode) {
-		var errorRes exceptions.ApiErrorResponse
-		err := json.NewDecoder(res.Body).Decode(&errorRes)
-		if err != nil {
-			return nil, err
-		}
-		return nil, exceptions.NewResponseError(errorRes)
+		return nil, exceptions.DecodeHttpError(req, res)
 	}
 
 	return io.ReadAll(res.Body)
 }
 
+// Uploads a file in a multipart form. The form is streamed through a pipe, so the file is never held in memory.
+// Returns ErrFileTooLarge before sending anything when the file is known to exceed MaxSize, or once it does while being sent
 func MakeMultipartRequest(ctx context.Context, requestOptions MultipartRequestOption, multipartOptions MultipartOptions) ([]byte, error) {
-	var requestWriter bytes.Buffer
-
-	//Create multipart writer
-	writer := multipart.NewWriter(&requestWriter)
-	defer writer.Close()
+	upload, err := newMultipartUpload(multipartOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer upload.close()
 
-	//Create file field
-	multipartFileField, err := writer.CreateFormFile(multipartOptions.FileFieldName, multipartOptions.FileName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestOptions.Url, upload.open())
 	if err != nil {
 		return nil, err
 	}
+	req.GetBody = upload.rewind
+	req.Header.Set("Content-Type", upload.contentType)
 
-	_, err = io.Copy(multipartFileField, multipartOptions.File)
+	res, err := doWithRetry(requestOptions.HttpClient, req, requestOptions.Session, requestOptions.Revision)
+	if uploadErr := upload.err(); uploadErr != nil {
+		if err == nil {
+			res.Body.Close()
+		}
+		return nil, uploadErr
+	}
 	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
+
+	if !exceptions.IsHttpCodeOk(res.StatusCode) {
+		return nil, exceptions.DecodeHttpError(req, res)
+	}
+
+	return io.ReadAll(res.Body)
+}
+
+// Escapes quotes of a Content-Disposition parameter, as mime/multipart does
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// Streams the multipart form of a file, once per attempt of the request
+type multipartUpload struct {
+	opt         MultipartOptions
+	boundary    string //Shared by every attempt, as it is part of the request content type
+	contentType string
+	start       int64 //Offset the file is sent from, -1 when it can not be rewound
+	total       int64 //Size of the file, -1 when unknown
+
+	mu      sync.Mutex
+	body    *io.PipeReader
+	done    chan struct{} //Closed once the current body is written
+	failure error         //Error reading the file or exceeding its maximum size
+}
+
+func newMultipartUpload(opt MultipartOptions) (*multipartUpload, error) {
+	if opt.File == nil {
+		return nil, errors.New("klaviyo: multipart file is required")
+	}
 
-	for key, value := range multipartOptions.Meta {
-		err := writer.WriteField(key, value)
-		if err != nil {
-			return nil, err
+	form := multipart.NewWriter(io.Discard)
+	upload := &multipartUpload{
+		opt:         opt,
+		boundary:    form.Boundary(),
+		contentType: form.FormDataContentType(),
+		start:       -1,
+		total:       -1,
+	}
+
+	switch file := opt.File.(type) {
+	case io.Seeker:
+		// Seeking fails for files that are not seekable, eg. a pipe. Those are sent once
+		if start, err := file.Seek(0, io.SeekCurrent); err == nil {
+			end, err := file.Seek(0, io.SeekEnd)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := file.Seek(start, io.SeekStart); err != nil {
+				return nil, err
+			}
+			upload.start, upload.total = start, end-start
 		}
+	case interface{ Len() int }:
+		upload.total = int64(file.Len())
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestOptions.Url, &requestWriter)
-	req.Header.Add("revision", requestOptions.Revision)
-	req.Header.Add("Content-Type", writer.FormDataContentType())
-	req.Header.Add("User-Agent", "Klaviyo-go-sdk-v0.0.0")
+	if opt.MaxSize > 0 && upload.total > opt.MaxSize {
+		return nil, fmt.Errorf("%w: %d bytes exceed %d", ErrFileTooLarge, upload.total, opt.MaxSize)
+	}
 
-	requestOptions.Session.ApplyToRequest(requestOptions.Session.GetOptions(), req)
+	return upload, nil
+}
 
-	execFn := func() (*http.Response, error) {
-		return requestOptions.HttpClient.Do(req)
+// Returns a body streaming the form, the file from its current offset
+func (u *multipartUpload) open() io.ReadCloser {
+	reader, writer := io.Pipe()
+	done := make(chan struct{})
+
+	u.mu.Lock()
+	u.body, u.done = reader, done
+	u.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		writer.CloseWithError(u.write(writer))
+	}()
+
+	return reader
+}
+
+// Returns a body streaming the form again, once the previous one is no longer read. Used as http.Request.GetBody
+func (u *multipartUpload) rewind() (io.ReadCloser, error) {
+	seeker, ok := u.opt.File.(io.Seeker)
+	if !ok || u.start < 0 {
+		return nil, fmt.Errorf("klaviyo: upload can not be resent, %T is not seekable", u.opt.File)
 	}
 
-	res, err := Retry(execFn, requestOptions.Session.GetRetryOptions())
-	if err != nil {
+	u.mu.Lock()
+	body, done := u.body, u.done
+	u.mu.Unlock()
+	body.Close()
+	<-done
+
+	if _, err := seeker.Seek(u.start, io.SeekStart); err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
+	return u.open(), nil
+}
 
-	if !exceptions.IsHttpCodeOk(res.StatusCode) {
-		var errorRes exceptions.ApiErrorResponse
-		err := json.NewDecoder(res.Body).Decode(&errorRes)
-		if err != nil {
-			return nil, err
+// Stops writing the current body
+func (u *multipartUpload) close() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.body != nil {
+		u.body.Close()
+	}
+}
+
+func (u *multipartUpload) err() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.failure
+}
+
+func (u *multipartUpload) fail(err error) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.failure = err
+	return err
+}
+
+// Writes the meta fields, then the file, then the closing boundary
+func (u *multipartUpload) write(w io.Writer) error {
+	form := multipart.NewWriter(w)
+	if err := form.SetBoundary(u.boundary); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(u.opt.Meta))
+	for key := range u.opt.Meta {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := form.WriteField(key, u.opt.Meta[key]); err != nil {
+			return err
 		}
-		return nil, exceptions.NewResponseError(errorRes)
 	}
 
-	return io.ReadAll(res.Body)
+	contentType := u.opt.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(u.opt.FileFieldName), quoteEscaper.Replace(u.opt.FileName)))
+	header.Set("Content-Type", contentType)
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	progress := UploadProgress{Total: u.total}
+	buf := make([]byte, 32<<10)
+	for {
+		n, readErr := u.opt.File.Read(buf)
+		if n > 0 {
+			progress.Sent += int64(n)
+			if u.opt.MaxSize > 0 && progress.Sent > u.opt.MaxSize {
+				return u.fail(fmt.Errorf("%w: more than %d bytes", ErrFileTooLarge, u.opt.MaxSize))
+			}
+			if _, err := part.Write(buf[:n]); err != nil {
+				return err
+			}
+			if u.opt.OnProgress != nil {
+				u.opt.OnProgress(progress)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return u.fail(readErr)
+		}
+	}
+
+	return form.Close()
 }
@@ -0,0 +1,112 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/developertom01/klaviyo-go/exceptions"
+	"github.com/developertom01/klaviyo-go/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrieveDataNonJsonErrorBody(t *testing.T) {
+	session := NewApiKeySession(options.NewOptions().WithApiKey(testLoggerApiKey), &RetryOptions{MaxRetries: 1})
+
+	client := NewMockHTTPClient()
+	client.On("Do", mock.Anything).Return(&http.Response{
+		StatusCode: http.StatusBadGateway,
+		Header:     http.Header{"Content-Type": []string{"text/html"}},
+		Body:       io.NopCloser(strings.NewReader("<html>Bad gateway</html>")),
+	}, nil)
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, BASE_URL+"/api/accounts/", nil)
+	_, err := RetrieveData(client, req, session, API_REVISION)
+
+	var rawErr exceptions.RawHttpError
+	assert.ErrorAs(t, err, &rawErr)
+	assert.Equal(t, http.StatusBadGateway, rawErr.StatusCode)
+	assert.Equal(t, "/api/accounts/", rawErr.Endpoint)
+	assert.ErrorIs(t, err, exceptions.ErrServerError)
+}
+
+func TestMakeMultipartRequest(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		reader, err := r.MultipartReader()
+		require.NoError(t, err)
+
+		parts := map[string]string{}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err, "the form is finalized with a closing boundary")
+			value, _ := io.ReadAll(part)
+			parts[part.FormName()] = string(value)
+			if part.FileName() != "" {
+				assert.Equal(t, "logo.png", part.FileName())
+				assert.Equal(t, "image/png", part.Header.Get("Content-Type"))
+			}
+		}
+		assert.Equal(t, map[string]string{"name": "logo", "file": "content"}, parts)
+
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	session := NewApiKeySession(options.NewOptions().WithApiKey(testLoggerApiKey), &RetryOptions{MaxRetries: 2})
+	var progress []UploadProgress
+	data, err := MakeMultipartRequest(context.Background(), MultipartRequestOption{
+		HttpClient: server.Client(),
+		Session:    session,
+		Url:        server.URL,
+		Revision:   API_REVISION,
+	}, MultipartOptions{
+		File:          strings.NewReader("content"),
+		FileFieldName: "file",
+		FileName:      "logo.png",
+		ContentType:   "image/png",
+		Meta:          map[string]string{"name": "logo"},
+		OnProgress: func(p UploadProgress) {
+			progress = append(progress, p)
+		},
+	})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data": {}}`, string(data))
+	assert.Equal(t, 2, attempts, "the seekable file is resent")
+	assert.Equal(t, []UploadProgress{{Sent: 7, Total: 7}, {Sent: 7, Total: 7}}, progress)
+}
+
+func TestMakeMultipartRequestMaxSize(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		io.Copy(io.Discard, r.Body)
+	}))
+	defer server.Close()
+
+	session := NewApiKeySession(options.NewOptions().WithApiKey(testLoggerApiKey), &RetryOptions{MaxRetries: 1})
+	requestOptions := MultipartRequestOption{HttpClient: server.Client(), Session: session, Url: server.URL, Revision: API_REVISION}
+
+	_, err := MakeMultipartRequest(context.Background(), requestOptions, MultipartOptions{File: strings.NewReader("content"), FileFieldName: "file", MaxSize: 4})
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+	assert.Equal(t, 0, requests, "a file of known size is rejected before sending")
+
+	// The size of a reader that is neither seekable nor sized is only known once it is sent
+	file := io.MultiReader(strings.NewReader("content"))
+	_, err = MakeMultipartRequest(context.Background(), requestOptions, MultipartOptions{File: file, FileFieldName: "file", MaxSize: 4})
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+}
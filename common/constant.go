@@ -1,4 +1,10 @@
 package common
 
+import "github.com/developertom01/klaviyo-go/exceptions"
+
 const API_REVISION = "2024-02-15"
 const BASE_URL = "https://a.klaviyo.com"
+const USER_AGENT = "Klaviyo-go-sdk-v0.0.0"
+
+// Response header carrying the ID Klaviyo assigned to the request
+const REQUEST_ID_HEADER = exceptions.REQUEST_ID_HEADER
@@ -0,0 +1,9 @@
+package common
+
+import "errors"
+
+var serializationError = errors.New("Serializing data failed")
+var tokenRefreshError = errors.New("Refreshing OAuth token failed")
+
+// Returned when an uploaded file exceeds MultipartOptions.MaxSize
+var ErrFileTooLarge = errors.New("File exceeds maximum size")
@@ -0,0 +1,8 @@
+package filter
+
+import (
+	"errors"
+)
+
+// Wrapped by every error returned for an invalid expression
+var ErrInvalidFilter = errors.New("Invalid filter")
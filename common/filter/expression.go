@@ -0,0 +1,250 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Operator string
+
+const (
+	OperatorEquals         Operator = "equals"
+	OperatorLessThan       Operator = "less-than"
+	OperatorLessOrEqual    Operator = "less-or-equal"
+	OperatorGreaterThan    Operator = "greater-than"
+	OperatorGreaterOrEqual Operator = "greater-or-equal"
+	OperatorContains       Operator = "contains"
+	OperatorContainsAny    Operator = "contains-any"
+	OperatorContainsAll    Operator = "contains-all"
+	OperatorStartsWith     Operator = "starts-with"
+	OperatorEndsWith       Operator = "ends-with"
+	OperatorAny            Operator = "any"
+	OperatorHas            Operator = "has"
+
+	//Boolean operators
+	OperatorAnd Operator = "and"
+	OperatorOr  Operator = "or"
+	OperatorNot Operator = "not"
+)
+
+type (
+	// Filter expression: a Comparison, Logical or Negation
+	Expression interface {
+		String() string  //Expression as sent in the `filter` query parameter. Not URL-encoded
+		Validate() error //Returns error wrapping ErrInvalidFilter when expression can not be sent
+		Accept(visitor Visitor) error
+	}
+
+	// Comparison of a field, eg. equals(status,"Draft"). Value is nil for has
+	Comparison struct {
+		Operator Operator
+		Field    string
+		Value    Value
+	}
+
+	// and or or of operands
+	Logical struct {
+		Operator Operator
+		Operands []Expression
+	}
+
+	// not of an operand
+	Negation struct {
+		Operand Expression
+	}
+)
+
+func Equals(field string, value Value) Comparison {
+	return Comparison{Operator: OperatorEquals, Field: field, Value: value}
+}
+
+func LessThan(field string, value Value) Comparison {
+	return Comparison{Operator: OperatorLessThan, Field: field, Value: value}
+}
+
+func LessOrEqual(field string, value Value) Comparison {
+	return Comparison{Operator: OperatorLessOrEqual, Field: field, Value: value}
+}
+
+func GreaterThan(field string, value Value) Comparison {
+	return Comparison{Operator: OperatorGreaterThan, Field: field, Value: value}
+}
+
+func GreaterOrEqual(field string, value Value) Comparison {
+	return Comparison{Operator: OperatorGreaterOrEqual, Field: field, Value: value}
+}
+
+func Contains(field string, value Value) Comparison {
+	return Comparison{Operator: OperatorContains, Field: field, Value: value}
+}
+
+func ContainsAny(field string, values ListValue) Comparison {
+	return Comparison{Operator: OperatorContainsAny, Field: field, Value: values}
+}
+
+func ContainsAll(field string, values ListValue) Comparison {
+	return Comparison{Operator: OperatorContainsAll, Field: field, Value: values}
+}
+
+func StartsWith(field string, value string) Comparison {
+	return Comparison{Operator: OperatorStartsWith, Field: field, Value: StringValue(value)}
+}
+
+func EndsWith(field string, value string) Comparison {
+	return Comparison{Operator: OperatorEndsWith, Field: field, Value: StringValue(value)}
+}
+
+func Any(field string, values ListValue) Comparison {
+	return Comparison{Operator: OperatorAny, Field: field, Value: values}
+}
+
+// Matches resources having `field`, eg. has(tags)
+func Has(field string) Comparison {
+	return Comparison{Operator: OperatorHas, Field: field}
+}
+
+func And(operands ...Expression) Logical {
+	return Logical{Operator: OperatorAnd, Operands: operands}
+}
+
+func Or(operands ...Expression) Logical {
+	return Logical{Operator: OperatorOr, Operands: operands}
+}
+
+func Not(operand Expression) Negation {
+	return Negation{Operand: operand}
+}
+
+// Validates and joins `expressions` with commas, which the API combines with and.
+// The result is not URL-encoded, pass it as filter of list methods or to common.Query.Filter
+func Build(expressions ...Expression) (string, error) {
+	parts := make([]string, 0, len(expressions))
+	for i, expression := range expressions {
+		if expression == nil {
+			return "", fmt.Errorf("%w: expression %d is missing", ErrInvalidFilter, i)
+		}
+		if err := expression.Validate(); err != nil {
+			return "", err
+		}
+		parts = append(parts, expression.String())
+	}
+
+	return strings.Join(parts, ","), nil
+}
+
+func (c Comparison) String() string {
+	if c.Value == nil {
+		return fmt.Sprintf("%s(%s)", c.Operator, c.Field)
+	}
+	return fmt.Sprintf("%s(%s,%s)", c.Operator, c.Field, c.Value.String())
+}
+
+func (c Comparison) Validate() error {
+	if err := c.check(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	return nil
+}
+
+func (c Comparison) check() error {
+	if err := validateField(c.Field); err != nil {
+		return fmt.Errorf("%s: %v", c.Operator, err)
+	}
+
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%s(%s): %s", c.Operator, c.Field, fmt.Sprintf(format, args...))
+	}
+
+	value := unwrapLiteral(c.Value)
+	_, isList := value.(ListValue)
+	switch c.Operator {
+	case OperatorHas:
+		if c.Value != nil {
+			return invalid("takes no value")
+		}
+		return nil
+	case OperatorAny, OperatorContainsAny, OperatorContainsAll:
+		if !isList {
+			return invalid("value must be a list")
+		}
+	case OperatorStartsWith, OperatorEndsWith:
+		if _, ok := value.(StringValue); !ok {
+			return invalid("value must be a string")
+		}
+	case OperatorEquals, OperatorContains:
+		if value == nil || isList {
+			return invalid("value must not be a list")
+		}
+	case OperatorLessThan, OperatorLessOrEqual, OperatorGreaterThan, OperatorGreaterOrEqual:
+		switch value.(type) {
+		case IntValue, FloatValue, DateTimeValue, StringValue:
+		default:
+			return invalid("value must be a number, date time or string")
+		}
+	default:
+		return invalid("unknown comparison operator")
+	}
+
+	if err := c.Value.validate(); err != nil {
+		return invalid("%v", err)
+	}
+
+	return nil
+}
+
+func (l Logical) String() string {
+	operands := make([]string, 0, len(l.Operands))
+	for _, operand := range l.Operands {
+		if operand == nil {
+			continue
+		}
+		operands = append(operands, operand.String())
+	}
+
+	return fmt.Sprintf("%s(%s)", l.Operator, strings.Join(operands, ","))
+}
+
+func (l Logical) Validate() error {
+	if l.Operator != OperatorAnd && l.Operator != OperatorOr {
+		return fmt.Errorf("%w: %s: unknown boolean operator", ErrInvalidFilter, l.Operator)
+	}
+	if len(l.Operands) == 0 {
+		return fmt.Errorf("%w: %s: requires at least one operand", ErrInvalidFilter, l.Operator)
+	}
+
+	for i, operand := range l.Operands {
+		if operand == nil {
+			return fmt.Errorf("%w: %s: operand %d is missing", ErrInvalidFilter, l.Operator, i)
+		}
+		if err := operand.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n Negation) String() string {
+	if n.Operand == nil {
+		return fmt.Sprintf("%s()", OperatorNot)
+	}
+	return fmt.Sprintf("%s(%s)", OperatorNot, n.Operand.String())
+}
+
+func (n Negation) Validate() error {
+	if n.Operand == nil {
+		return fmt.Errorf("%w: %s: operand is missing", ErrInvalidFilter, OperatorNot)
+	}
+	return n.Operand.Validate()
+}
+
+// Field names are dotted paths such as messages.channel
+func validateField(field string) error {
+	if field == "" {
+		return fmt.Errorf("field is empty")
+	}
+	if i := strings.IndexAny(field, "(),\"[] \t\r\n"); i >= 0 {
+		return fmt.Errorf("field %q contains %q", field, field[i])
+	}
+	return nil
+}
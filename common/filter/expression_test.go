@@ -0,0 +1,96 @@
+package filter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTypedValues(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		expression Expression
+		expected   string
+	}{
+		{"string", Equals("messages.channel", String("email")), `equals(messages.channel,"email")`},
+		{"escaped string", Equals("name", String(`say "hi" \o/`)), `equals(name,"say \"hi\" \\o/")`},
+		{"bool", Equals("archived", Bool(true)), `equals(archived,true)`},
+		{"int", GreaterThan("price", Int(100)), `greater-than(price,100)`},
+		{"float", LessThan("price", Float(9.5)), `less-than(price,9.5)`},
+		{"null", Equals("scheduled_at", Null()), `equals(scheduled_at,null)`},
+		{"date time", LessOrEqual("updated_at", DateTime(updatedAt)), `less-or-equal(updated_at,2024-01-01T00:00:00Z)`},
+		{"list", Any("status", Strings("Draft", "Sent")), `any(status,["Draft","Sent"])`},
+		{"starts with", StartsWith("name", "Spring"), `starts-with(name,"Spring")`},
+		{"ends with", EndsWith("name", "Sale"), `ends-with(name,"Sale")`},
+		{"has", Has("tags"), `has(tags)`},
+		{"not", Not(Equals("archived", Bool(true))), `not(equals(archived,true))`},
+		{
+			"n-ary and",
+			And(Equals("a", Int(1)), Equals("b", Int(2)), Or(Equals("c", Int(3)), Has("d"))),
+			`and(equals(a,1),equals(b,2),or(equals(c,3),has(d)))`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := Build(test.expression)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, filter)
+		})
+	}
+}
+
+func TestBuildJoinsExpressions(t *testing.T) {
+	filter, err := Build(Equals("messages.channel", String("email")), Has("tags"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, `equals(messages.channel,"email"),has(tags)`, filter)
+}
+
+func TestBuildReturnsErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression Expression
+	}{
+		{"empty field", Equals("", String("x"))},
+		{"invalid field", Equals("a,b", String("x"))},
+		{"missing value", Equals("name", nil)},
+		{"list for equals", Equals("name", Strings("a"))},
+		{"scalar for any", Comparison{Operator: OperatorAny, Field: "status", Value: String("Draft")}},
+		{"empty list", ContainsAny("tags", List())},
+		{"nested list", ContainsAny("tags", List(Strings("a")))},
+		{"bool for less-than", LessThan("archived", Bool(true))},
+		{"NaN", GreaterThan("price", Float(math.NaN()))},
+		{"zero date time", GreaterThan("updated_at", DateTime(time.Time{}))},
+		{"value for has", Comparison{Operator: OperatorHas, Field: "tags", Value: Bool(true)}},
+		{"empty and", And()},
+		{"missing operand", Or(Has("a"), nil)},
+		{"invalid nested operand", And(Has("a"), Equals("", Int(1)))},
+		{"missing negated operand", Not(nil)},
+		{"unknown operator", Comparison{Operator: OperatorAnd, Field: "a", Value: Int(1)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := Build(test.expression)
+
+			assert.ErrorIs(t, err, ErrInvalidFilter)
+			assert.Equal(t, "", filter)
+		})
+	}
+}
+
+func TestBuildWithoutExpressions(t *testing.T) {
+	filter, err := Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", filter)
+
+	_, err = Build(nil)
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+}
@@ -0,0 +1,335 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Error of Parse, positioned at the offending character
+type SyntaxError struct {
+	Message string
+	Offset  int //Byte offset in the input
+	Line    int //1-based
+	Column  int //1-based, in characters
+	input   string
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+// Parses a filter string, eg. and(equals(messages.channel,"email"),greater-than(updated_at,2024-01-01T00:00:00Z)),
+// into its comma separated expressions. Build turns them back into the same string.
+// Blank input has no expressions. Errors are *SyntaxError wrapping ErrInvalidFilter
+func Parse(input string) ([]Expression, error) {
+	p := &parser{input: input}
+	if p.skipSpace(); p.done() {
+		return nil, nil
+	}
+
+	var expressions []Expression
+	for {
+		expression, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, expression)
+
+		p.skipSpace()
+		if p.done() {
+			return expressions, nil
+		}
+		if err := p.expect(','); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Parses a filter string holding exactly one expression
+func ParseExpression(input string) (Expression, error) {
+	p := &parser{input: input}
+
+	expression, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.done() {
+		return nil, p.errorf(p.pos, "unexpected %s after expression", p.describe())
+	}
+
+	return expression, nil
+}
+
+func (p *parser) parseExpression() (Expression, error) {
+	p.skipSpace()
+	start := p.pos
+
+	name := p.word()
+	if name == "" {
+		return nil, p.errorf(p.pos, "expected operator, found %s", p.describe())
+	}
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	operator := Operator(name)
+	switch operator {
+	case OperatorAnd, OperatorOr:
+		var operands []Expression
+		for {
+			operand, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, operand)
+
+			p.skipSpace()
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+		if err := p.expect(')'); err != nil {
+			return nil, err
+		}
+		return Logical{Operator: operator, Operands: operands}, nil
+
+	case OperatorNot:
+		operand, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(')'); err != nil {
+			return nil, err
+		}
+		return Negation{Operand: operand}, nil
+
+	case OperatorEquals, OperatorLessThan, OperatorLessOrEqual, OperatorGreaterThan, OperatorGreaterOrEqual,
+		OperatorContains, OperatorContainsAny, OperatorContainsAll, OperatorStartsWith, OperatorEndsWith, OperatorAny, OperatorHas:
+		comparison, err := p.parseComparison(operator)
+		if err != nil {
+			return nil, err
+		}
+		if err := comparison.check(); err != nil {
+			return nil, p.errorf(start, "%v", err)
+		}
+		return comparison, nil
+
+	default:
+		return nil, p.errorf(start, "unknown operator %q", name)
+	}
+}
+
+func (p *parser) parseComparison(operator Operator) (Comparison, error) {
+	p.skipSpace()
+	field := p.word()
+	if field == "" {
+		return Comparison{}, p.errorf(p.pos, "expected field, found %s", p.describe())
+	}
+
+	comparison := Comparison{Operator: operator, Field: field}
+	if operator != OperatorHas {
+		if err := p.expect(','); err != nil {
+			return Comparison{}, err
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return Comparison{}, err
+		}
+		comparison.Value = value
+	}
+
+	if err := p.expect(')'); err != nil {
+		return Comparison{}, err
+	}
+
+	return comparison, nil
+}
+
+func (p *parser) parseValue() (Value, error) {
+	p.skipSpace()
+
+	switch p.peek() {
+	case '"':
+		return p.parseString()
+	case '[':
+		return p.parseList()
+	}
+
+	start := p.pos
+	literal := p.word()
+	switch literal {
+	case "":
+		return nil, p.errorf(p.pos, "expected value, found %s", p.describe())
+	case "true", "false":
+		return BoolValue(literal == "true"), nil
+	case "null":
+		return NullValue{}, nil
+	}
+
+	var value Value
+	if number, err := strconv.ParseInt(literal, 10, 64); err == nil {
+		value = IntValue(number)
+	} else if number, err := strconv.ParseFloat(literal, 64); err == nil {
+		value = FloatValue(number)
+	} else if moment, err := time.Parse(time.RFC3339Nano, literal); err == nil {
+		value = DateTimeValue{Time: moment}
+	} else {
+		return nil, p.errorf(start, "invalid value %q, strings must be quoted", literal)
+	}
+
+	// Keeps literals such as 1.50 or 10:30:00.500Z, which String would shorten
+	if value.String() != literal {
+		return LiteralValue{Value: value, Literal: literal}, nil
+	}
+	return value, nil
+}
+
+func (p *parser) parseString() (Value, error) {
+	start := p.pos
+	p.pos++
+
+	var sb strings.Builder
+	for !p.done() {
+		c := p.input[p.pos]
+		switch c {
+		case '"':
+			p.pos++
+			return StringValue(sb.String()), nil
+		case '\\':
+			if p.pos+1 >= len(p.input) {
+				return nil, p.errorf(p.pos, "unterminated escape")
+			}
+			sb.WriteByte(p.input[p.pos+1])
+			p.pos += 2
+		default:
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+
+	return nil, p.errorf(start, "unterminated string")
+}
+
+func (p *parser) parseList() (Value, error) {
+	p.pos++
+
+	list := ListValue{}
+	p.skipSpace()
+	if p.peek() == ']' {
+		p.pos++
+		return list, nil
+	}
+
+	for {
+		p.skipSpace()
+		if p.peek() == '[' {
+			return nil, p.errorf(p.pos, "lists can not be nested")
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+
+		p.skipSpace()
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+
+	if err := p.expect(']'); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Reads operator, field or unquoted value
+func (p *parser) word() string {
+	start := p.pos
+	for !p.done() && !strings.ContainsRune("(),[]\" \t\r\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) expect(c byte) error {
+	p.skipSpace()
+	if p.peek() != c {
+		return p.errorf(p.pos, "expected %q, found %s", c, p.describe())
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) skipSpace() {
+	for !p.done() && strings.IndexByte(" \t\r\n", p.input[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) describe() string {
+	if p.done() {
+		return "end of filter"
+	}
+	r, _ := utf8.DecodeRuneInString(p.input[p.pos:])
+	return strconv.QuoteRune(r)
+}
+
+func (p *parser) errorf(offset int, format string, args ...any) *SyntaxError {
+	line := 1 + strings.Count(p.input[:offset], "\n")
+	lineStart := strings.LastIndexByte(p.input[:offset], '\n') + 1
+
+	return &SyntaxError{
+		Message: fmt.Sprintf(format, args...),
+		Offset:  offset,
+		Line:    line,
+		Column:  utf8.RuneCountInString(p.input[lineStart:offset]) + 1,
+		input:   p.input,
+	}
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s: %s at line %d, column %d", ErrInvalidFilter, e.Message, e.Line, e.Column)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return ErrInvalidFilter
+}
+
+// Returns the error followed by the offending line and a caret under the offending character
+//
+//	Invalid filter: expected ')', found end of filter at line 1, column 20
+//	equals(name,"Sale"
+//	                  ^
+func (e *SyntaxError) Pretty() string {
+	lineStart := strings.LastIndexByte(e.input[:e.Offset], '\n') + 1
+	lineEnd := strings.IndexByte(e.input[e.Offset:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(e.input)
+	} else {
+		lineEnd += e.Offset
+	}
+
+	return fmt.Sprintf("%s\n%s\n%s^", e.Error(), e.input[lineStart:lineEnd], strings.Repeat(" ", e.Column-1))
+}
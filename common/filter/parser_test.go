@@ -0,0 +1,168 @@
+package filter
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	expressions, err := Parse(`and(equals(messages.channel,"email"),greater-than(updated_at,2024-01-01T00:00:00Z))`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Expression{
+		And(
+			Equals("messages.channel", String("email")),
+			GreaterThan("updated_at", DateTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))),
+		),
+	}, expressions)
+}
+
+func TestParseValues(t *testing.T) {
+	expressions, err := Parse(` equals(archived, false), less-than(price,9.5), greater-or-equal(count, -3),
+		equals(scheduled_at,null), any(status,["Draft", "Sent"]), has(tags), not(ends-with(name,"\"quoted\""))`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Expression{
+		Equals("archived", Bool(false)),
+		LessThan("price", Float(9.5)),
+		GreaterOrEqual("count", Int(-3)),
+		Equals("scheduled_at", Null()),
+		Any("status", Strings("Draft", "Sent")),
+		Has("tags"),
+		Not(EndsWith("name", `"quoted"`)),
+	}, expressions)
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	inputs := []string{
+		`equals(messages.channel,"email")`,
+		`and(equals(a,1),or(contains-any(b,["x","y"]),not(has(c))),less-or-equal(d,2024-05-01T10:30:00.5+02:00))`,
+		`equals(name,"back\\slash \"quote\""),equals(e,true),greater-than(f,1.25)`,
+		`less-than(price,1.50),greater-or-equal(count,+007),any(updated,[2024-05-01T10:30:00.500Z,2024-05-01T10:30:00+00:00])`,
+	}
+
+	for _, input := range inputs {
+		expressions, err := Parse(input)
+		assert.NoError(t, err)
+
+		built, err := Build(expressions...)
+		assert.NoError(t, err)
+		assert.Equal(t, input, built)
+	}
+}
+
+func TestParseKeepsLiterals(t *testing.T) {
+	expression, err := ParseExpression(`any(price,[1.50,2.5])`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Any("price", List(LiteralValue{Value: Float(1.5), Literal: "1.50"}, Float(2.5))), expression)
+	assert.NoError(t, expression.Validate())
+}
+
+func TestParseBlank(t *testing.T) {
+	expressions, err := Parse("  ")
+
+	assert.NoError(t, err)
+	assert.Nil(t, expressions)
+}
+
+func TestParseErrorPositions(t *testing.T) {
+	tests := []struct {
+		input   string
+		message string
+		line    int
+		column  int
+	}{
+		{`equals(name,"Sale"`, `expected ')', found end of filter`, 1, 19},
+		{`equal(name,"Sale")`, `unknown operator "equal"`, 1, 1},
+		{`and(has(a),equals(b,Sale))`, `invalid value "Sale", strings must be quoted`, 1, 21},
+		{`equals(name,"Sale`, `unterminated string`, 1, 13},
+		{"and(\n  has(a),\n  any(b,\"x\"))", `any(b): value must be a list`, 3, 3},
+		{`has(a) has(b)`, `expected ',', found 'h'`, 1, 8},
+		{`contains-any(tags,[["a"]])`, `lists can not be nested`, 1, 20},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(test.input)
+
+		var syntaxErr *SyntaxError
+		if assert.True(t, errors.As(err, &syntaxErr), test.input) {
+			assert.ErrorIs(t, err, ErrInvalidFilter)
+			assert.Equal(t, test.message, syntaxErr.Message, test.input)
+			assert.Equal(t, test.line, syntaxErr.Line, test.input)
+			assert.Equal(t, test.column, syntaxErr.Column, test.input)
+		}
+	}
+}
+
+func TestSyntaxErrorPretty(t *testing.T) {
+	_, err := Parse(`equals(name,"Sale"`)
+
+	var syntaxErr *SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+	assert.Equal(t, "Invalid filter: expected ')', found end of filter at line 1, column 19\nequals(name,\"Sale\"\n                  ^", syntaxErr.Pretty())
+}
+
+func TestParseExpression(t *testing.T) {
+	expression, err := ParseExpression(`has(tags)`)
+	assert.NoError(t, err)
+	assert.Equal(t, Has("tags"), expression)
+
+	_, err = ParseExpression(`has(tags),has(flows)`)
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+}
+
+func TestWalk(t *testing.T) {
+	expression := And(Equals("a", Int(1)), Not(Has("b")), Or(Has("c"), Has("d")))
+
+	var fields []string
+	err := Walk(expression, VisitorFuncs{
+		Comparison: func(comparison Comparison) error {
+			fields = append(fields, comparison.Field)
+			return nil
+		},
+		Logical: func(logical Logical) error {
+			if logical.Operator == OperatorOr {
+				return SkipOperands
+			}
+			return nil
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, fields)
+}
+
+func TestWalkStopsOnError(t *testing.T) {
+	stop := errors.New("stop")
+	visited := 0
+
+	err := Walk(And(Has("a"), Has("b")), VisitorFuncs{
+		Comparison: func(comparison Comparison) error {
+			visited++
+			return stop
+		},
+	})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, visited)
+}
+
+func TestTransform(t *testing.T) {
+	expression, err := ParseExpression(`and(equals(channel,"email"),not(has(channel)))`)
+	assert.NoError(t, err)
+
+	renamed, err := Transform(expression, func(node Expression) (Expression, error) {
+		if comparison, ok := node.(Comparison); ok && comparison.Field == "channel" {
+			comparison.Field = "messages.channel"
+			return comparison, nil
+		}
+		return node, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, `and(equals(messages.channel,"email"),not(has(messages.channel)))`, renamed.String())
+}
@@ -0,0 +1,114 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type (
+	// Fields and operators a list endpoint accepts in its filter
+	Schema struct {
+		Endpoint string                //Endpoint named in errors, eg. GET /api/campaigns/
+		Fields   map[string][]Operator //Allowed operators of every filterable field
+		Required []string              //Fields every filter must compare, eg. messages.channel of campaigns
+	}
+
+	// Filter rejected by a Schema
+	SchemaError struct {
+		Endpoint string
+		Field    string
+		Operator Operator //Empty when field is not filterable or missing
+		Message  string
+	}
+)
+
+// Common operators of date time fields
+var DateTimeOperators = []Operator{OperatorGreaterOrEqual, OperatorGreaterThan, OperatorLessOrEqual, OperatorLessThan}
+
+// Validates every comparison of `expressions` against the schema. Errors are *SchemaError wrapping ErrInvalidFilter
+func (s Schema) Validate(expressions ...Expression) error {
+	compared := make(map[string]bool)
+
+	for _, expression := range expressions {
+		err := Walk(expression, VisitorFuncs{
+			Comparison: func(comparison Comparison) error {
+				operators, ok := s.Fields[comparison.Field]
+				if !ok {
+					return &SchemaError{
+						Endpoint: s.Endpoint,
+						Field:    comparison.Field,
+						Message:  fmt.Sprintf("field %q is not filterable, allowed fields: %s", comparison.Field, strings.Join(s.fieldNames(), ", ")),
+					}
+				}
+				if !slices.Contains(operators, comparison.Operator) {
+					return &SchemaError{
+						Endpoint: s.Endpoint,
+						Field:    comparison.Field,
+						Operator: comparison.Operator,
+						Message:  fmt.Sprintf("operator %s is not allowed on %q, allowed operators: %s", comparison.Operator, comparison.Field, joinOperators(operators)),
+					}
+				}
+
+				compared[comparison.Field] = true
+				return nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, field := range s.Required {
+		if !compared[field] {
+			return &SchemaError{
+				Endpoint: s.Endpoint,
+				Field:    field,
+				Message:  fmt.Sprintf("a filter on %q is required", field),
+			}
+		}
+	}
+
+	return nil
+}
+
+// Parses and validates a filter string. Blank filters are only valid without Required fields
+func (s Schema) ValidateString(input string) error {
+	expressions, err := Parse(input)
+	if err != nil {
+		return err
+	}
+
+	return s.Validate(expressions...)
+}
+
+func (s Schema) fieldNames() []string {
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func joinOperators(operators []Operator) string {
+	names := make([]string, 0, len(operators))
+	for _, operator := range operators {
+		names = append(names, string(operator))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func (e *SchemaError) Error() string {
+	if e.Endpoint == "" {
+		return fmt.Sprintf("%s: %s", ErrInvalidFilter, e.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", ErrInvalidFilter, e.Endpoint, e.Message)
+}
+
+func (e *SchemaError) Unwrap() error {
+	return ErrInvalidFilter
+}
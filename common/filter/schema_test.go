@@ -0,0 +1,63 @@
+package filter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSchema = Schema{
+	Endpoint: "GET /api/campaigns/",
+	Fields: map[string][]Operator{
+		"messages.channel": {OperatorEquals},
+		"name":             {OperatorContains},
+		"updated_at":       DateTimeOperators,
+	},
+	Required: []string{"messages.channel"},
+}
+
+func TestSchemaValidate(t *testing.T) {
+	err := testSchema.ValidateString(`and(equals(messages.channel,"email"),contains(name,"Sale"),greater-than(updated_at,2024-01-01T00:00:00Z))`)
+
+	assert.NoError(t, err)
+}
+
+func TestSchemaRejectsField(t *testing.T) {
+	err := testSchema.Validate(Equals("messages.channel", String("email")), Equals("status", String("Draft")))
+
+	var schemaErr *SchemaError
+	assert.True(t, errors.As(err, &schemaErr))
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+	assert.Equal(t, "status", schemaErr.Field)
+	assert.Equal(t, Operator(""), schemaErr.Operator)
+	assert.Equal(t, `Invalid filter: GET /api/campaigns/: field "status" is not filterable, allowed fields: messages.channel, name, updated_at`, err.Error())
+}
+
+func TestSchemaRejectsOperator(t *testing.T) {
+	err := testSchema.Validate(And(Equals("messages.channel", String("email")), Not(Equals("name", String("Sale")))))
+
+	var schemaErr *SchemaError
+	assert.True(t, errors.As(err, &schemaErr))
+	assert.Equal(t, "name", schemaErr.Field)
+	assert.Equal(t, OperatorEquals, schemaErr.Operator)
+	assert.Equal(t, `Invalid filter: GET /api/campaigns/: operator equals is not allowed on "name", allowed operators: contains`, err.Error())
+}
+
+func TestSchemaRequiresField(t *testing.T) {
+	for _, input := range []string{"", `contains(name,"Sale")`} {
+		err := testSchema.ValidateString(input)
+
+		var schemaErr *SchemaError
+		assert.True(t, errors.As(err, &schemaErr), input)
+		assert.Equal(t, "messages.channel", schemaErr.Field)
+		assert.Equal(t, `Invalid filter: GET /api/campaigns/: a filter on "messages.channel" is required`, err.Error())
+	}
+}
+
+func TestSchemaReportsSyntaxErrors(t *testing.T) {
+	err := testSchema.ValidateString(`equals(messages.channel,email)`)
+
+	var syntaxErr *SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+}
@@ -0,0 +1,195 @@
+package filter
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// Operand of a comparison, eg. String("email") or DateTime(t)
+	Value interface {
+		String() string //Value as written in a filter expression
+		validate() error
+	}
+
+	StringValue string
+
+	BoolValue bool
+
+	IntValue int64
+
+	FloatValue float64
+
+	// Date time, written unquoted in ISO 8601 format, eg. 2024-01-01T00:00:00Z
+	DateTimeValue struct {
+		Time time.Time
+	}
+
+	NullValue struct{}
+
+	ListValue []Value
+
+	// Number or date time written as `Literal`, eg. 1.50 for Float(1.5). Parse keeps the literals of values whose String
+	// differs, so Build returns the parsed filter unchanged
+	LiteralValue struct {
+		Value   Value
+		Literal string
+	}
+)
+
+func String(value string) StringValue {
+	return StringValue(value)
+}
+
+func Bool(value bool) BoolValue {
+	return BoolValue(value)
+}
+
+func Int(value int64) IntValue {
+	return IntValue(value)
+}
+
+func Float(value float64) FloatValue {
+	return FloatValue(value)
+}
+
+func DateTime(value time.Time) DateTimeValue {
+	return DateTimeValue{Time: value}
+}
+
+func Null() NullValue {
+	return NullValue{}
+}
+
+func List(values ...Value) ListValue {
+	return ListValue(values)
+}
+
+// List of strings, eg. Strings("email", "sms")
+func Strings(values ...string) ListValue {
+	list := make(ListValue, 0, len(values))
+	for _, value := range values {
+		list = append(list, StringValue(value))
+	}
+	return list
+}
+
+// Quoted string. Backslashes and double quotes are escaped with a backslash
+func (v StringValue) String() string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range string(v) {
+		if r == '"' || r == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+func (v StringValue) validate() error {
+	return nil
+}
+
+func (v BoolValue) String() string {
+	return strconv.FormatBool(bool(v))
+}
+
+func (v BoolValue) validate() error {
+	return nil
+}
+
+func (v IntValue) String() string {
+	return strconv.FormatInt(int64(v), 10)
+}
+
+func (v IntValue) validate() error {
+	return nil
+}
+
+func (v FloatValue) String() string {
+	return strconv.FormatFloat(float64(v), 'f', -1, 64)
+}
+
+func (v FloatValue) validate() error {
+	if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+		return fmt.Errorf("%s is not a finite number", v.String())
+	}
+	return nil
+}
+
+func (v DateTimeValue) String() string {
+	return v.Time.Format(time.RFC3339Nano)
+}
+
+func (v DateTimeValue) validate() error {
+	if v.Time.IsZero() {
+		return fmt.Errorf("date time is zero")
+	}
+	return nil
+}
+
+func (v NullValue) String() string {
+	return "null"
+}
+
+func (v NullValue) validate() error {
+	return nil
+}
+
+func (v LiteralValue) String() string {
+	return v.Literal
+}
+
+func (v LiteralValue) validate() error {
+	if v.Value == nil {
+		return fmt.Errorf("literal %q has no value", v.Literal)
+	}
+	return v.Value.validate()
+}
+
+// Returns the value of a LiteralValue, other values as they are
+func unwrapLiteral(value Value) Value {
+	if literal, ok := value.(LiteralValue); ok {
+		return literal.Value
+	}
+	return value
+}
+
+func (v ListValue) String() string {
+	items := make([]string, 0, len(v))
+	for _, item := range v {
+		if item == nil {
+			items = append(items, "")
+			continue
+		}
+		items = append(items, item.String())
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(items, ","))
+}
+
+func (v ListValue) validate() error {
+	if len(v) == 0 {
+		return fmt.Errorf("list is empty")
+	}
+
+	for i, item := range v {
+		switch item.(type) {
+		case nil:
+			return fmt.Errorf("list item %d is missing", i)
+		case ListValue:
+			return fmt.Errorf("list item %d is a list", i)
+		}
+		if err := item.validate(); err != nil {
+			return fmt.Errorf("list item %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
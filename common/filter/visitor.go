@@ -0,0 +1,118 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Visits the nodes of an expression, see Walk
+type Visitor interface {
+	VisitComparison(comparison Comparison) error
+	VisitLogical(logical Logical) error
+	VisitNegation(negation Negation) error
+}
+
+// Returned by a Visitor to skip the operands of the visited Logical or Negation
+var SkipOperands = errors.New("Skip operands")
+
+func (c Comparison) Accept(visitor Visitor) error {
+	return visitor.VisitComparison(c)
+}
+
+func (l Logical) Accept(visitor Visitor) error {
+	return visitor.VisitLogical(l)
+}
+
+func (n Negation) Accept(visitor Visitor) error {
+	return visitor.VisitNegation(n)
+}
+
+// Visits `expression` and its operands depth-first, parents before operands. Stops at the first error
+//
+//	fields := map[string]bool{}
+//	filter.Walk(expression, filter.VisitorFuncs{
+//		Comparison: func(c filter.Comparison) error { fields[c.Field] = true; return nil },
+//	})
+func Walk(expression Expression, visitor Visitor) error {
+	if expression == nil {
+		return nil
+	}
+
+	err := expression.Accept(visitor)
+	if errors.Is(err, SkipOperands) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch node := expression.(type) {
+	case Logical:
+		for _, operand := range node.Operands {
+			if err := Walk(operand, visitor); err != nil {
+				return err
+			}
+		}
+	case Negation:
+		return Walk(node.Operand, visitor)
+	}
+
+	return nil
+}
+
+// Visitor made of functions. Nil functions visit nothing
+type VisitorFuncs struct {
+	Comparison func(comparison Comparison) error
+	Logical    func(logical Logical) error
+	Negation   func(negation Negation) error
+}
+
+func (v VisitorFuncs) VisitComparison(comparison Comparison) error {
+	if v.Comparison == nil {
+		return nil
+	}
+	return v.Comparison(comparison)
+}
+
+func (v VisitorFuncs) VisitLogical(logical Logical) error {
+	if v.Logical == nil {
+		return nil
+	}
+	return v.Logical(logical)
+}
+
+func (v VisitorFuncs) VisitNegation(negation Negation) error {
+	if v.Negation == nil {
+		return nil
+	}
+	return v.Negation(negation)
+}
+
+// Rebuilds `expression` bottom-up, replacing every node by the result of `fn`, eg. to rename fields.
+// Operands are transformed before the Logical or Negation holding them
+func Transform(expression Expression, fn func(Expression) (Expression, error)) (Expression, error) {
+	switch node := expression.(type) {
+	case nil:
+		return nil, fmt.Errorf("%w: expression is missing", ErrInvalidFilter)
+	case Logical:
+		operands := make([]Expression, 0, len(node.Operands))
+		for _, operand := range node.Operands {
+			transformed, err := Transform(operand, fn)
+			if err != nil {
+				return nil, err
+			}
+			operands = append(operands, transformed)
+		}
+		node.Operands = operands
+		return fn(node)
+	case Negation:
+		operand, err := Transform(node.Operand, fn)
+		if err != nil {
+			return nil, err
+		}
+		node.Operand = operand
+		return fn(node)
+	default:
+		return fn(node)
+	}
+}
@@ -1,9 +1,10 @@
 package common
 
 import (
-	"fmt"
 	"net/url"
 	"strings"
+
+	"github.com/developertom01/klaviyo-go/common/filter"
 )
 
 type FilterOperator string
@@ -27,8 +28,9 @@ const (
 	FilterOperatorNOT FilterOperator = "not"
 )
 
+// Deprecated: quotes every value as a string and panics on misuse. Use the typed expressions of the filter package
 type FilterBuilder struct {
-	filters []string
+	filters []filter.Expression
 }
 
 func NewFilterBuilder() *FilterBuilder {
@@ -36,93 +38,47 @@ func NewFilterBuilder() *FilterBuilder {
 }
 
 func (builder *FilterBuilder) Equal(field string, value string) *FilterBuilder {
-	operation := url.QueryEscape(fmt.Sprintf("%s,%q", field, value))
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorEquals, operation))
-	return builder
+	return builder.add(filter.Equals(field, filter.String(value)))
 }
 
 func (builder *FilterBuilder) LessThan(field string, value string) *FilterBuilder {
-	operation := url.QueryEscape(fmt.Sprintf("%s,%q", field, value))
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorLessThan, operation))
-
-	return builder
+	return builder.add(filter.LessThan(field, filter.String(value)))
 }
 
 func (builder *FilterBuilder) LessOrEqual(field string, value string) *FilterBuilder {
-	operation := url.QueryEscape(fmt.Sprintf("%s,%q", field, value))
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorLessOrEqual, operation))
-
-	return builder
+	return builder.add(filter.LessOrEqual(field, filter.String(value)))
 }
 
 func (builder *FilterBuilder) GreaterThan(field string, value string) *FilterBuilder {
-	operation := url.QueryEscape(fmt.Sprintf("%s,%q", field, value))
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorGreaterThan, operation))
-
-	return builder
+	return builder.add(filter.GreaterThan(field, filter.String(value)))
 }
 
 func (builder *FilterBuilder) GreaterOrEqual(field string, value string) *FilterBuilder {
-	operation := url.QueryEscape(fmt.Sprintf("%s,%q", field, value))
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorGreaterOrEqual, operation))
-
-	return builder
+	return builder.add(filter.GreaterOrEqual(field, filter.String(value)))
 }
 
 func (builder *FilterBuilder) Contains(field string, value string) *FilterBuilder {
-	operation := url.QueryEscape(fmt.Sprintf("%s,%q", field, value))
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorContains, operation))
-
-	return builder
+	return builder.add(filter.Contains(field, filter.String(value)))
 }
 
 func (builder *FilterBuilder) ContainsAny(field string, values []string) *FilterBuilder {
-	var valuesStr = make([]string, 0)
-	for _, value := range values {
-		valuesStr = append(valuesStr, fmt.Sprintf("%q", value))
-	}
-	operation := url.QueryEscape(fmt.Sprintf("%s,%v", field, valuesStr))
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorContainsAny, operation))
-
-	return builder
+	return builder.add(filter.ContainsAny(field, filter.Strings(values...)))
 }
 
 func (builder *FilterBuilder) ContainsAll(field string, values []string) *FilterBuilder {
-	var valuesStr = make([]string, 0)
-	for _, value := range values {
-		valuesStr = append(valuesStr, fmt.Sprintf("%q", value))
-	}
-
-	operation := url.QueryEscape(fmt.Sprintf("%s,%v", field, valuesStr))
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorContainsAll, operation))
-
-	return builder
+	return builder.add(filter.ContainsAll(field, filter.Strings(values...)))
 }
 
 func (builder *FilterBuilder) EndsWith(field string, value string) *FilterBuilder {
-	operation := url.QueryEscape(fmt.Sprintf("%s,%s", field, value))
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorEndsWith, operation))
-
-	return builder
+	return builder.add(filter.EndsWith(field, value))
 }
 
 func (builder *FilterBuilder) StartsWith(field string, value string) *FilterBuilder {
-	operation := url.QueryEscape(fmt.Sprintf("%s,%s", field, value))
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorStartsWith, operation))
-
-	return builder
+	return builder.add(filter.StartsWith(field, value))
 }
 
 func (builder *FilterBuilder) Any(field string, values []string) *FilterBuilder {
-	var valuesStr = make([]string, 0)
-	for _, value := range values {
-		valuesStr = append(valuesStr, fmt.Sprintf("%q", value))
-	}
-
-	operation := url.QueryEscape(fmt.Sprintf("%s,%v", field, valuesStr))
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorAny, operation))
-
-	return builder
+	return builder.add(filter.Any(field, filter.Strings(values...)))
 }
 
 func (builder *FilterBuilder) And(fb1 FilterBuilder, fb2 FilterBuilder) *FilterBuilder {
@@ -130,10 +86,7 @@ func (builder *FilterBuilder) And(fb1 FilterBuilder, fb2 FilterBuilder) *FilterB
 		panic("And operator must contain two operands")
 	}
 
-	operation := url.QueryEscape(fmt.Sprintf("%s,%s", fb1.build(), fb2.build()))
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorAND, operation))
-
-	return builder
+	return builder.add(filter.And(fb1.expression(), fb2.expression()))
 }
 
 func (builder *FilterBuilder) Or(fb1 FilterBuilder, fb2 FilterBuilder) *FilterBuilder {
@@ -141,10 +94,7 @@ func (builder *FilterBuilder) Or(fb1 FilterBuilder, fb2 FilterBuilder) *FilterBu
 		panic("Or operator must contain two operands")
 	}
 
-	operation := url.QueryEscape(fmt.Sprintf("%s,%s", fb1.build(), fb2.build()))
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorOR, operation))
-
-	return builder
+	return builder.add(filter.Or(fb1.expression(), fb2.expression()))
 }
 
 func (builder *FilterBuilder) Not(fb FilterBuilder) *FilterBuilder {
@@ -152,16 +102,33 @@ func (builder *FilterBuilder) Not(fb FilterBuilder) *FilterBuilder {
 		panic("Not operator must contain one operands")
 	}
 
-	filter := fb.build()
-	builder.filters = append(builder.filters, fmt.Sprintf("%s(%s)", FilterOperatorNOT, filter))
+	return builder.add(filter.Not(fb.expression()))
+}
+
+// Returns `filter=` followed by the expressions as filter.Build joins them, URL-encoded besides the parentheses
+func (fb *FilterBuilder) Build() string {
+	return "filter=" + filterParenthesesReplacer.Replace(url.QueryEscape(fb.build()))
+}
 
+var filterParenthesesReplacer = strings.NewReplacer("%28", "(", "%29", ")")
+
+func (builder *FilterBuilder) add(expression filter.Expression) *FilterBuilder {
+	builder.filters = append(builder.filters, expression)
 	return builder
 }
 
-func (fb *FilterBuilder) Build() string {
-	return fmt.Sprintf("filter=%v", fb.build())
+// Returns the only expression of the builder, nil when it has none
+func (fb *FilterBuilder) expression() filter.Expression {
+	if len(fb.filters) == 0 {
+		return nil
+	}
+	return fb.filters[0]
 }
 
 func (fb *FilterBuilder) build() string {
-	return strings.Join(fb.filters, ",")
+	parts := make([]string, 0, len(fb.filters))
+	for _, expression := range fb.filters {
+		parts = append(parts, expression.String())
+	}
+	return strings.Join(parts, ",")
 }
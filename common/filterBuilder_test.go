@@ -3,6 +3,7 @@ package common
 import (
 	"testing"
 
+	"github.com/developertom01/klaviyo-go/common/filter"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -55,7 +56,7 @@ func TestContains(t *testing.T) {
 }
 
 func TestContainsAny(t *testing.T) {
-	var expectedStr = "filter=contains-any(field1%2C%5B%22value1%22+%22value2%22%5D)"
+	var expectedStr = "filter=contains-any(field1%2C%5B%22value1%22%2C%22value2%22%5D)"
 	fb := NewFilterBuilder()
 	fb.ContainsAny("field1", []string{"value1", "value2"})
 
@@ -63,7 +64,7 @@ func TestContainsAny(t *testing.T) {
 }
 
 func TestContainsAll(t *testing.T) {
-	var expectedStr = "filter=contains-all(field1%2C%5B%22value1%22+%22value2%22%5D)"
+	var expectedStr = "filter=contains-all(field1%2C%5B%22value1%22%2C%22value2%22%5D)"
 	fb := NewFilterBuilder()
 	fb.ContainsAll("field1", []string{"value1", "value2"})
 
@@ -71,7 +72,7 @@ func TestContainsAll(t *testing.T) {
 }
 
 func TestEndsWith(t *testing.T) {
-	var expectedStr = "filter=ends-with(field1%2Cvalue)"
+	var expectedStr = "filter=ends-with(field1%2C%22value%22)"
 	fb := NewFilterBuilder()
 	fb.EndsWith("field1", "value")
 
@@ -79,7 +80,7 @@ func TestEndsWith(t *testing.T) {
 }
 
 func TestStartsWith(t *testing.T) {
-	var expectedStr = "filter=starts-with(field1%2Cvalue)"
+	var expectedStr = "filter=starts-with(field1%2C%22value%22)"
 	fb := NewFilterBuilder()
 	fb.StartsWith("field1", "value")
 
@@ -87,7 +88,7 @@ func TestStartsWith(t *testing.T) {
 }
 
 func TestAny(t *testing.T) {
-	var expectedStr = "filter=any(field1%2C%5B%22value1%22+%22value2%22%5D)"
+	var expectedStr = "filter=any(field1%2C%5B%22value1%22%2C%22value2%22%5D)"
 	fb := NewFilterBuilder()
 	fb.Any("field1", []string{"value1", "value2"})
 
@@ -95,7 +96,7 @@ func TestAny(t *testing.T) {
 }
 
 func TestAnd(t *testing.T) {
-	var expectedStr = "filter=and(equals%28field1%252C%2522value1%2522%29%2Cequals%28field2%252C%2522value2%2522%29)"
+	var expectedStr = "filter=and(equals(field1%2C%22value1%22)%2Cequals(field2%2C%22value2%22))"
 
 	fb1 := NewFilterBuilder()
 	fb1.Equal("field1", "value1")
@@ -109,7 +110,7 @@ func TestAnd(t *testing.T) {
 }
 
 func TestOr(t *testing.T) {
-	var expectedStr = "filter=or(equals%28field1%252C%2522value1%2522%29%2Cequals%28field2%252C%2522value2%2522%29)"
+	var expectedStr = "filter=or(equals(field1%2C%22value1%22)%2Cequals(field2%2C%22value2%22))"
 
 	fb1 := NewFilterBuilder()
 	fb1.Equal("field1", "value1")
@@ -132,3 +133,28 @@ func TestNot(t *testing.T) {
 	fb.Not(*fb1)
 	assert.Equal(t, expectedStr, fb.Build())
 }
+
+func TestFilterBuilderMatchesFilterBuild(t *testing.T) {
+	equal := NewFilterBuilder().Equal("name", `say "hi"`)
+	prefix := NewFilterBuilder().StartsWith("name", "Spring")
+
+	fb := NewFilterBuilder().
+		Or(*equal, *prefix).
+		ContainsAny("tags", []string{"sale", "new"}).
+		EndsWith("email", "@example.com")
+
+	expected, err := filter.Build(
+		filter.Or(filter.Equals("name", filter.String(`say "hi"`)), filter.StartsWith("name", "Spring")),
+		filter.ContainsAny("tags", filter.Strings("sale", "new")),
+		filter.EndsWith("email", "@example.com"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, normalizeFilter(fb.Build()))
+
+	schema := filter.Schema{Fields: map[string][]filter.Operator{
+		"name":  {filter.OperatorEquals, filter.OperatorStartsWith},
+		"tags":  {filter.OperatorContainsAny},
+		"email": {filter.OperatorEndsWith},
+	}}
+	assert.NoError(t, schema.ValidateString(normalizeFilter(fb.Build())))
+}
@@ -6,13 +6,12 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/developertom01/klaviyo-go/options"
 	"github.com/stretchr/testify/mock"
 )
 
 // HTTPClient interface for making HTTP requests
-type HTTPClient interface {
-	Do(req *http.Request) (*http.Response, error)
-}
+type HTTPClient = options.HTTPClient
 
 // MockHTTPClient is a mock implementation of HTTPClient for testing
 type MockHTTPClient struct {
@@ -44,7 +43,5 @@ func BuildURLWithQueryParams(baseUrl string, queryParams map[string][]string) (s
 	if err != nil {
 		return "", err
 	}
-	fmt.Println(apiUrl)
-
 	return apiUrl.String(), nil
 }
@@ -0,0 +1,113 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/developertom01/klaviyo-go/exceptions"
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Options of WaitForJob
+type WaitOptions struct {
+	Interval    time.Duration                     //Delay between the first two polls. Defaults to 1 second when zero
+	MaxInterval time.Duration                     //Maximum delay between polls, unbounded when zero
+	Multiplier  float64                           //Factor the delay grows by after each poll. Defaults to 2 when zero
+	OnProgress  func(progress models.JobProgress) //Called after each poll
+}
+
+func NewWaitOptionsWithDefaultValues() *WaitOptions {
+	return &WaitOptions{
+		Interval:    time.Second,
+		MaxInterval: time.Second * 30,
+		Multiplier:  2,
+	}
+}
+
+// Returned by WaitForJob when the fetcher returns neither a job nor an error
+var ErrJobNotFetched = errors.New("Fetching job returned no document")
+
+// Fetches the current state of a job, eg.
+//
+//	func(ctx context.Context) (*models.CatalogItemBulkJobResource, error) {
+//		return api.Catalog.GetCreateItemsJob(ctx, jobId, nil)
+//	}
+type JobFetcher[J models.Job] func(ctx context.Context) (*models.Document[J], error)
+
+// Polls a job with backoff until it is complete or cancelled, and returns its last state.
+// Returns exceptions.JobError when the job was cancelled or had failed operations, and the context error when it is done first
+func WaitForJob[J models.Job](ctx context.Context, fetch JobFetcher[J], opt *WaitOptions) (J, error) {
+	opt = withWaitDefaults(opt)
+
+	var job J
+	interval := opt.Interval
+	for {
+		document, err := fetch(ctx)
+		if err != nil {
+			return job, err
+		}
+		if document == nil {
+			return job, ErrJobNotFetched
+		}
+		job = document.Data
+
+		progress := job.Progress()
+		if opt.OnProgress != nil {
+			opt.OnProgress(progress)
+		}
+		if progress.Done {
+			return job, jobError(progress)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return job, fmt.Errorf("klaviyo: waiting for %s %s: %w", progress.Type, progress.ID, ctx.Err())
+		case <-timer.C:
+		}
+
+		if opt.Multiplier > 1 {
+			interval = time.Duration(float64(interval) * opt.Multiplier)
+		}
+		if opt.MaxInterval > 0 {
+			interval = min(interval, opt.MaxInterval)
+		}
+	}
+}
+
+// Returns a copy of `opt` with the zero interval and multiplier set to their defaults, so the job is not polled in a tight loop
+func withWaitDefaults(opt *WaitOptions) *WaitOptions {
+	defaults := NewWaitOptionsWithDefaultValues()
+	if opt == nil {
+		return defaults
+	}
+
+	withDefaults := *opt
+	if withDefaults.Interval <= 0 {
+		withDefaults.Interval = defaults.Interval
+	}
+	if withDefaults.Multiplier == 0 {
+		withDefaults.Multiplier = defaults.Multiplier
+	}
+	return &withDefaults
+}
+
+// Returns the error of a done job, nil when it completed without failures
+func jobError(progress models.JobProgress) error {
+	if !progress.Cancelled && progress.Failed == 0 && len(progress.Errors) == 0 {
+		return nil
+	}
+
+	return exceptions.JobError{
+		JobType:   progress.Type,
+		JobId:     progress.ID,
+		Status:    progress.Status,
+		Total:     progress.Total,
+		Failed:    progress.Failed,
+		Cancelled: progress.Cancelled,
+		Err:       exceptions.ApiErrorResponse{Errors: progress.Errors},
+	}
+}
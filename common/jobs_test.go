@@ -0,0 +1,127 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/developertom01/klaviyo-go/exceptions"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns fetcher of `jobs` in order, repeating the last one
+func jobSequence(jobs ...models.CatalogItemBulkJob) JobFetcher[models.CatalogItemBulkJob] {
+	polls := 0
+	return func(ctx context.Context) (*models.CatalogItemBulkJobResource, error) {
+		job := jobs[min(polls, len(jobs)-1)]
+		polls++
+		return &models.CatalogItemBulkJobResource{Data: job}, nil
+	}
+}
+
+func bulkJob(status models.CatalogItemBulkJobStatus, completed int64, failed int64, errs ...exceptions.ApiError) models.CatalogItemBulkJob {
+	return models.CatalogItemBulkJob{
+		Type: "catalog-item-bulk-create-job",
+		ID:   "job-id",
+		Attributes: models.CatalogItemBulkJobAttributes{
+			Status:         status,
+			TotalCount:     10,
+			CompletedCount: &completed,
+			FailedCount:    &failed,
+			Errors:         errs,
+		},
+	}
+}
+
+func fastWaitOptions() *WaitOptions {
+	return &WaitOptions{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond, Multiplier: 2}
+}
+
+func TestWaitForJobReportsProgress(t *testing.T) {
+	opt := fastWaitOptions()
+	var progress []models.JobProgress
+	opt.OnProgress = func(p models.JobProgress) { progress = append(progress, p) }
+
+	job, err := WaitForJob(context.Background(), jobSequence(
+		bulkJob(models.CatalogItemBulkJobStatusQueued, 0, 0),
+		bulkJob(models.CatalogItemBulkJobStatusProcessing, 4, 0),
+		bulkJob(models.CatalogItemBulkJobStatusComplete, 10, 0),
+	), opt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.CatalogItemBulkJobStatusComplete, job.Attributes.Status)
+	assert.Len(t, progress, 3)
+	assert.Equal(t, int64(4), progress[1].Completed)
+	assert.Equal(t, int64(10), progress[1].Total)
+	assert.True(t, progress[2].Done)
+}
+
+func TestWaitForJobReturnsFailedOperations(t *testing.T) {
+	pointer := "/data/attributes/items/data/3"
+	_, err := WaitForJob(context.Background(), jobSequence(
+		bulkJob(models.CatalogItemBulkJobStatusComplete, 9, 1, exceptions.ApiError{Code: "invalid", Title: "Invalid input.", Detail: "price must be positive", Source: &exceptions.ApiErrorSource{Pointer: &pointer}}),
+	), fastWaitOptions())
+
+	var jobErr exceptions.JobError
+	assert.ErrorAs(t, err, &jobErr)
+	assert.ErrorIs(t, err, exceptions.ErrJobFailed)
+	assert.NotErrorIs(t, err, exceptions.ErrJobCancelled)
+	assert.Equal(t, "data.attributes.items.data[3]", jobErr.FieldErrors()[0].Path)
+	assert.Contains(t, err.Error(), "1 of 10 operations failed")
+}
+
+func TestWaitForJobReturnsCancelledSendJob(t *testing.T) {
+	fetch := func(ctx context.Context) (*models.CampaignSendJobResponse, error) {
+		return &models.CampaignSendJobResponse{Data: models.CampaignSendJob{
+			Type:       "campaign-send-job",
+			ID:         "campaign-id",
+			Attributes: models.CampaignSendJobAttributes{Status: models.CampaignSendJobStatusCancelled},
+		}}, nil
+	}
+
+	_, err := WaitForJob(context.Background(), fetch, fastWaitOptions())
+	assert.ErrorIs(t, err, exceptions.ErrJobCancelled)
+}
+
+func TestWaitForJobHonoursContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	job, err := WaitForJob(ctx, jobSequence(bulkJob(models.CatalogItemBulkJobStatusProcessing, 1, 0)), fastWaitOptions())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "job-id", job.ID)
+
+	fetchErr := errors.New("unavailable")
+	_, err = WaitForJob(context.Background(), func(ctx context.Context) (*models.CatalogItemBulkJobResource, error) {
+		return nil, fetchErr
+	}, nil)
+	assert.ErrorIs(t, err, fetchErr)
+}
+
+func TestWaitForJobDefaultsZeroInterval(t *testing.T) {
+	polls := 0
+	opt := &WaitOptions{OnProgress: func(models.JobProgress) { polls++ }}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := WaitForJob(ctx, jobSequence(bulkJob(models.CatalogItemBulkJobStatusProcessing, 1, 0)), opt)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, polls)
+	assert.Zero(t, opt.Interval)
+}
+
+func TestWaitForJobRejectsMissingDocument(t *testing.T) {
+	polls := 0
+	job, err := WaitForJob(context.Background(), func(ctx context.Context) (*models.CatalogItemBulkJobResource, error) {
+		if polls++; polls == 1 {
+			return &models.CatalogItemBulkJobResource{Data: bulkJob(models.CatalogItemBulkJobStatusProcessing, 4, 0)}, nil
+		}
+		return nil, nil
+	}, fastWaitOptions())
+
+	assert.ErrorIs(t, err, ErrJobNotFetched)
+	assert.Equal(t, models.CatalogItemBulkJobStatusProcessing, job.Attributes.Status)
+}
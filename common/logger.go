@@ -0,0 +1,71 @@
+package common
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/developertom01/klaviyo-go/exceptions"
+	"github.com/developertom01/klaviyo-go/options"
+)
+
+// Headers whose values are never written to logs
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Returns a copy of `header` with credentials replaced by options.RedactedValue
+func RedactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for key := range redacted {
+		for _, sensitive := range sensitiveHeaders {
+			if strings.EqualFold(key, sensitive) {
+				redacted[key] = []string{options.RedactedValue}
+			}
+		}
+	}
+
+	return redacted
+}
+
+func headersLogValue(header http.Header) slog.Value {
+	redacted := RedactHeaders(header)
+
+	attrs := make([]slog.Attr, 0, len(redacted))
+	for key, values := range redacted {
+		attrs = append(attrs, slog.String(key, strings.Join(values, ",")))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// Logs the outcome of a single HTTP attempt
+func logAttempt(logger *slog.Logger, req *http.Request, res *http.Response, err error, attempt int, latency time.Duration) {
+	ctx := req.Context()
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Duration("latency", latency),
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+		logger.LogAttrs(ctx, slog.LevelError, "klaviyo: request failed", attrs...)
+		return
+	}
+
+	attrs = append(attrs, slog.Int("status", res.StatusCode))
+	if requestId := res.Header.Get(REQUEST_ID_HEADER); requestId != "" {
+		attrs = append(attrs, slog.String("request_id", requestId))
+	}
+
+	level := slog.LevelDebug
+	if !exceptions.IsHttpCodeOk(res.StatusCode) {
+		level = slog.LevelWarn
+	}
+	if logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("request_headers", headersLogValue(req.Header)))
+	}
+
+	logger.LogAttrs(ctx, level, "klaviyo: request completed", attrs...)
+}
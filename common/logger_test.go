@@ -0,0 +1,67 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/developertom01/klaviyo-go/options"
+	"github.com/stretchr/testify/assert"
+)
+
+const testLoggerApiKey = "pk_secret_test_key"
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Klaviyo-API-Key "+testLoggerApiKey)
+	header.Set("Revision", API_REVISION)
+
+	redacted := RedactHeaders(header)
+
+	assert.Equal(t, options.RedactedValue, redacted.Get("Authorization"))
+	assert.Equal(t, API_REVISION, redacted.Get("Revision"))
+	assert.Contains(t, header.Get("Authorization"), testLoggerApiKey)
+}
+
+func TestRetrieveDataLogsAttemptWithoutSecrets(t *testing.T) {
+	var logOutput bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logOutput, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	opt := options.NewOptionsWithDefaultValues().WithApiKey(testLoggerApiKey).WithLogger(logger)
+	session := NewApiKeySession(opt, NewRetryOptionsWithDefaultValues())
+
+	mockedClient := NewMockHTTPClient()
+	err := PrepareMockResponse(http.StatusOK, map[string]any{"data": []any{}}, mockedClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, BASE_URL+"/api/accounts/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = RetrieveData(mockedClient, req, session, API_REVISION)
+	assert.Nil(t, err)
+
+	output := logOutput.String()
+	assert.Contains(t, output, `"method":"GET"`)
+	assert.Contains(t, output, `"path":"/api/accounts/"`)
+	assert.Contains(t, output, `"status":200`)
+	assert.Contains(t, output, `"attempt":1`)
+	assert.Contains(t, output, `"latency"`)
+	assert.NotContains(t, output, testLoggerApiKey)
+
+	logOutput.Reset()
+	logger.Info("session", "session", session)
+	assert.NotContains(t, logOutput.String(), testLoggerApiKey)
+}
+
+func TestSessionLogsNothingByDefault(t *testing.T) {
+	opt := options.NewOptionsWithDefaultValues().WithApiKey(testLoggerApiKey)
+	session := NewApiKeySession(opt, nil)
+
+	assert.False(t, session.GetOptions().Logger().Enabled(context.Background(), slog.LevelError))
+}
@@ -0,0 +1,317 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/developertom01/klaviyo-go/options"
+)
+
+// Klaviyo OAuth token endpoint, relative to the base URL
+const OAUTH_TOKEN_PATH = "/oauth/token"
+
+// Klaviyo OAuth token endpoint of BASE_URL
+const OAUTH_TOKEN_URL = BASE_URL + OAUTH_TOKEN_PATH
+
+const bearerAuthorizationPrefix = "Bearer"
+
+// Tokens are refreshed when they expire within this window unless OAuthSessionOptions.RefreshWindow is set
+const defaultTokenRefreshWindow = time.Minute
+
+var ErrOAuthTokenRequired = errors.New("OAuth access token or token source required")
+
+// Returned with the error of OAuthSessionOptions.OnTokenRefresh. The refreshed token is still used by the session,
+// but the refresh token it replaced may no longer be valid, so the new one must be persisted before the process exits
+var ErrOAuthTokenNotPersisted = errors.New("Persisting refreshed OAuth token failed")
+
+type (
+	// Implemented by sessions whose credentials can be renewed after Klaviyo rejects them with 401
+	RefreshableSession interface {
+		Session
+
+		//Renews the credentials used by `rejected`. Requests are sent again once this returns nil
+		RefreshAfterUnauthorized(rejected *http.Request) error
+	}
+
+	OAuthToken struct {
+		AccessToken  string    `json:"access_token"`
+		RefreshToken string    `json:"refresh_token"`
+		Expiry       time.Time `json:"expiry"` //Zero when the token does not expire
+	}
+
+	// Issues new tokens. `current` is the token being replaced and may be expired
+	TokenSource interface {
+		Token(ctx context.Context, current OAuthToken) (*OAuthToken, error)
+	}
+
+	// Adapts a function to TokenSource
+	TokenSourceFunc func(ctx context.Context, current OAuthToken) (*OAuthToken, error)
+
+	// Called with every refreshed token, eg. to persist rotated refresh tokens. Its error is returned
+	// with ErrOAuthTokenNotPersisted by the request that refreshed the token
+	OAuthTokenRefreshCallback func(ctx context.Context, token OAuthToken) error
+
+	OAuthSessionOptions struct {
+		Token          OAuthToken                //Initial token. May be empty when TokenSource can issue one
+		TokenSource    TokenSource               //Source used to refresh tokens
+		OnTokenRefresh OAuthTokenRefreshCallback //Optional
+		RefreshWindow  time.Duration             //Refresh tokens expiring within this window. Default: 1 minute
+		RetryOptions   *RetryOptions
+	}
+
+	// Session authorizing requests with OAuth bearer tokens. Safe for concurrent use.
+	// Tokens are refreshed by one request at a time while the others needing a new token wait for it
+	OAuthSession struct {
+		opt           options.Options
+		retryOpt      RetryOptions
+		source        TokenSource
+		onRefresh     OAuthTokenRefreshCallback
+		refreshWindow time.Duration
+
+		mu         sync.Mutex
+		token      OAuthToken
+		refreshing *tokenRefresh //Refresh in flight, nil when there is none
+	}
+
+	// Refresh shared by the requests waiting on it
+	tokenRefresh struct {
+		done chan struct{} //Closed once the refresh is over
+		err  error         //Error of the token source, set before done is closed
+	}
+
+	sessionOptionsKey struct{}
+)
+
+func (fn TokenSourceFunc) Token(ctx context.Context, current OAuthToken) (*OAuthToken, error) {
+	return fn(ctx, current)
+}
+
+func NewOAuthSession(opt options.Options, oauthOpt OAuthSessionOptions) *OAuthSession {
+	retryOptions := oauthOpt.RetryOptions
+	if retryOptions == nil {
+		retryOptions = NewRetryOptionsWithDefaultValues()
+	}
+
+	refreshWindow := oauthOpt.RefreshWindow
+	if refreshWindow == 0 {
+		refreshWindow = defaultTokenRefreshWindow
+	}
+
+	return &OAuthSession{
+		opt:           opt.Clone(),
+		retryOpt:      *retryOptions,
+		source:        oauthOpt.TokenSource,
+		onRefresh:     oauthOpt.OnTokenRefresh,
+		refreshWindow: refreshWindow,
+		token:         oauthOpt.Token,
+	}
+}
+
+func (s *OAuthSession) ApplyToRequest(option options.Options, req *http.Request) error {
+	token, err := s.validToken(req.Context())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("%s %s", bearerAuthorizationPrefix, token.AccessToken))
+
+	return nil
+}
+
+func (s *OAuthSession) RefreshAfterUnauthorized(rejected *http.Request) error {
+	accessToken := strings.TrimPrefix(rejected.Header.Get("Authorization"), bearerAuthorizationPrefix+" ")
+
+	return s.refresh(rejected.Context(), accessToken)
+}
+
+func (s *OAuthSession) GetRetryOptions() RetryOptions {
+	return s.retryOpt
+}
+
+func (s *OAuthSession) GetOptions() options.Options {
+	return s.opt
+}
+
+// Returns the current token
+func (s *OAuthSession) Token() OAuthToken {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.token
+}
+
+// Logs options without tokens
+func (s *OAuthSession) LogValue() slog.Value {
+	return slog.AnyValue(s.opt)
+}
+
+func (s *OAuthSession) validToken(ctx context.Context) (OAuthToken, error) {
+	token := s.Token()
+	if token.AccessToken != "" && !s.expiresSoon(token) {
+		return token, nil
+	}
+
+	if err := s.refresh(ctx, token.AccessToken); err != nil {
+		return OAuthToken{}, err
+	}
+
+	return s.Token(), nil
+}
+
+func (s *OAuthSession) expiresSoon(token OAuthToken) bool {
+	return !token.Expiry.IsZero() && time.Until(token.Expiry) < s.refreshWindow
+}
+
+// Replaces the token with access token `stale`, unless another request already did.
+// The token source is called without holding s.mu, so requests with a valid token are not blocked by it
+func (s *OAuthSession) refresh(ctx context.Context, stale string) error {
+	s.mu.Lock()
+	if s.token.AccessToken != stale && s.token.AccessToken != "" {
+		s.mu.Unlock()
+		return nil
+	}
+
+	if s.source == nil {
+		defer s.mu.Unlock()
+		if s.token.AccessToken == "" {
+			return ErrOAuthTokenRequired
+		}
+		return nil
+	}
+
+	if inFlight := s.refreshing; inFlight != nil {
+		s.mu.Unlock()
+		select {
+		case <-inFlight.done:
+			return inFlight.err
+		case <-ctx.Done():
+			return errors.Join(tokenRefreshError, ctx.Err())
+		}
+	}
+
+	inFlight := &tokenRefresh{done: make(chan struct{})}
+	s.refreshing = inFlight
+	current := s.token
+	s.mu.Unlock()
+
+	token, err := s.source.Token(context.WithValue(ctx, sessionOptionsKey{}, s.opt), current)
+	if err != nil {
+		inFlight.err = errors.Join(tokenRefreshError, err)
+		s.finishRefresh(inFlight)
+		return inFlight.err
+	}
+
+	if token.RefreshToken == "" {
+		token.RefreshToken = current.RefreshToken
+	}
+	s.mu.Lock()
+	s.token = *token
+	s.mu.Unlock()
+
+	// The refresh stays in flight until the token is persisted, so rotated tokens are persisted in order
+	defer s.finishRefresh(inFlight)
+	if s.onRefresh != nil {
+		if err := s.onRefresh(ctx, *token); err != nil {
+			s.opt.Logger().ErrorContext(ctx, "klaviyo: persisting refreshed OAuth token failed", slog.Any("error", err))
+			return errors.Join(ErrOAuthTokenNotPersisted, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *OAuthSession) finishRefresh(inFlight *tokenRefresh) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.refreshing = nil
+	close(inFlight.done)
+}
+
+type (
+	refreshTokenSource struct {
+		clientId     string
+		clientSecret string
+		httpClient   HTTPClient
+	}
+
+	oauthTokenResponse struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+)
+
+// Returns the OAuth token endpoint of the base URL the token is refreshed for, ie. the base URL of the call overrides
+// set with WithRequestOptions, else of the OAuthSession calling the TokenSource, else BASE_URL
+func OAuthTokenUrl(ctx context.Context) string {
+	if callOpt := requestOptionsFromContext(ctx); callOpt != nil && callOpt.BaseUrl() != "" {
+		return ResolveBaseUrl(callOpt) + OAUTH_TOKEN_PATH
+	}
+
+	sessionOpt, _ := ctx.Value(sessionOptionsKey{}).(options.Options)
+	return ResolveBaseUrl(sessionOpt) + OAUTH_TOKEN_PATH
+}
+
+// TokenSource exchanging refresh tokens at Klaviyo's OAuth token endpoint, see OAuthTokenUrl.
+// http.DefaultClient is used when `httpClient` is nil
+func NewRefreshTokenSource(clientId string, clientSecret string, httpClient HTTPClient) TokenSource {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &refreshTokenSource{
+		clientId:     clientId,
+		clientSecret: clientSecret,
+		httpClient:   httpClient,
+	}
+}
+
+func (source *refreshTokenSource) Token(ctx context.Context, current OAuthToken) (*OAuthToken, error) {
+	if current.RefreshToken == "" {
+		return nil, errors.New("OAuth refresh token required")
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", current.RefreshToken)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, OAuthTokenUrl(ctx), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(source.clientId, source.clientSecret)
+
+	res, err := source.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OAuth token endpoint returned %s", res.Status)
+	}
+
+	var tokenRes oauthTokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&tokenRes); err != nil {
+		return nil, errors.Join(serializationError, err)
+	}
+
+	token := OAuthToken{
+		AccessToken:  tokenRes.AccessToken,
+		RefreshToken: tokenRes.RefreshToken,
+	}
+	if tokenRes.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenRes.ExpiresIn) * time.Second)
+	}
+
+	return &token, nil
+}
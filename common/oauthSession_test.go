@@ -0,0 +1,225 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/developertom01/klaviyo-go/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OAuthSessionTestSuite struct {
+	suite.Suite
+	mockedClient *MockHTTPClient
+	refreshes    atomic.Int32
+	persisted    []OAuthToken
+	persistMu    sync.Mutex
+}
+
+func (suit *OAuthSessionTestSuite) SetupTest() {
+	suit.mockedClient = NewMockHTTPClient()
+	suit.refreshes.Store(0)
+	suit.persisted = nil
+}
+
+func (suit *OAuthSessionTestSuite) newSession(token OAuthToken) *OAuthSession {
+	source := TokenSourceFunc(func(ctx context.Context, current OAuthToken) (*OAuthToken, error) {
+		n := suit.refreshes.Add(1)
+		return &OAuthToken{
+			AccessToken: "access-" + string(rune('0'+n)),
+			Expiry:      time.Now().Add(time.Hour),
+		}, nil
+	})
+
+	return NewOAuthSession(options.NewOptionsWithDefaultValues(), OAuthSessionOptions{
+		Token:       token,
+		TokenSource: source,
+		OnTokenRefresh: func(ctx context.Context, token OAuthToken) error {
+			suit.persistMu.Lock()
+			defer suit.persistMu.Unlock()
+			suit.persisted = append(suit.persisted, token)
+			return nil
+		},
+		RetryOptions: &RetryOptions{MaxRetries: 1, Interval: time.Millisecond},
+	})
+}
+
+func newRequest() *http.Request {
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, BASE_URL+"/api/accounts/", nil)
+	return req
+}
+
+func responseWithStatus(statusCode int) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewBufferString(`{"errors":[]}`)),
+	}
+}
+
+func (suit *OAuthSessionTestSuite) TestAppliesBearerToken() {
+	session := suit.newSession(OAuthToken{AccessToken: "valid", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour)})
+	req := newRequest()
+
+	suit.Nil(session.ApplyToRequest(session.GetOptions(), req))
+	suit.Equal("Bearer valid", req.Header.Get("Authorization"))
+	suit.Equal(int32(0), suit.refreshes.Load())
+}
+
+func (suit *OAuthSessionTestSuite) TestRefreshesBeforeExpiry() {
+	session := suit.newSession(OAuthToken{AccessToken: "expiring", RefreshToken: "refresh", Expiry: time.Now().Add(10 * time.Second)})
+	req := newRequest()
+
+	suit.Nil(session.ApplyToRequest(session.GetOptions(), req))
+	suit.Equal("Bearer access-1", req.Header.Get("Authorization"))
+	suit.Equal("refresh", session.Token().RefreshToken)
+	suit.Len(suit.persisted, 1)
+}
+
+func (suit *OAuthSessionTestSuite) TestRefreshesOnUnauthorized() {
+	session := suit.newSession(OAuthToken{AccessToken: "revoked", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour)})
+
+	suit.mockedClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.Header.Get("Authorization") == "Bearer revoked"
+	})).Return(responseWithStatus(http.StatusUnauthorized), nil).Once()
+	suit.mockedClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.Header.Get("Authorization") == "Bearer access-1"
+	})).Return(responseWithStatus(http.StatusOK), nil).Once()
+
+	_, err := RetrieveData(suit.mockedClient, newRequest(), session, API_REVISION)
+
+	suit.Nil(err)
+	suit.Equal(int32(1), suit.refreshes.Load())
+	suit.mockedClient.AssertExpectations(suit.T())
+}
+
+func (suit *OAuthSessionTestSuite) TestConcurrentRequestsRefreshOnce() {
+	session := suit.newSession(OAuthToken{AccessToken: "expired", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Minute)})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := newRequest()
+			assert.Nil(suit.T(), session.ApplyToRequest(session.GetOptions(), req))
+			assert.Equal(suit.T(), "Bearer access-1", req.Header.Get("Authorization"))
+		}()
+	}
+	wg.Wait()
+
+	suit.Equal(int32(1), suit.refreshes.Load())
+}
+
+func (suit *OAuthSessionTestSuite) TestRefreshFailure() {
+	sourceErr := errors.New("invalid_grant")
+	session := NewOAuthSession(options.NewOptionsWithDefaultValues(), OAuthSessionOptions{
+		TokenSource: TokenSourceFunc(func(ctx context.Context, current OAuthToken) (*OAuthToken, error) {
+			return nil, sourceErr
+		}),
+	})
+
+	err := session.ApplyToRequest(session.GetOptions(), newRequest())
+	suit.ErrorIs(err, sourceErr)
+}
+
+func (suit *OAuthSessionTestSuite) TestRefreshReturnsPersistFailure() {
+	persistErr := errors.New("database unavailable")
+	session := NewOAuthSession(options.NewOptionsWithDefaultValues(), OAuthSessionOptions{
+		Token: OAuthToken{AccessToken: "expired", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Minute)},
+		TokenSource: TokenSourceFunc(func(ctx context.Context, current OAuthToken) (*OAuthToken, error) {
+			return &OAuthToken{AccessToken: "rotated", RefreshToken: "rotated-refresh"}, nil
+		}),
+		OnTokenRefresh: func(ctx context.Context, token OAuthToken) error {
+			return persistErr
+		},
+	})
+
+	err := session.ApplyToRequest(session.GetOptions(), newRequest())
+	suit.ErrorIs(err, ErrOAuthTokenNotPersisted)
+	suit.ErrorIs(err, persistErr)
+
+	req := newRequest()
+	suit.Nil(session.ApplyToRequest(session.GetOptions(), req))
+	suit.Equal("Bearer rotated", req.Header.Get("Authorization"))
+}
+
+func (suit *OAuthSessionTestSuite) TestSlowRefreshDoesNotBlockValidTokens() {
+	release := make(chan struct{})
+	session := NewOAuthSession(options.NewOptionsWithDefaultValues(), OAuthSessionOptions{
+		Token: OAuthToken{AccessToken: "valid", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour)},
+		TokenSource: TokenSourceFunc(func(ctx context.Context, current OAuthToken) (*OAuthToken, error) {
+			<-release
+			return &OAuthToken{AccessToken: "refreshed"}, nil
+		}),
+	})
+
+	rejected := newRequest()
+	suit.Nil(session.ApplyToRequest(session.GetOptions(), rejected))
+
+	refreshed := make(chan error)
+	go func() { refreshed <- session.RefreshAfterUnauthorized(rejected) }()
+
+	req := newRequest()
+	suit.Nil(session.ApplyToRequest(session.GetOptions(), req))
+	suit.Equal("Bearer valid", req.Header.Get("Authorization"))
+
+	close(release)
+	suit.Nil(<-refreshed)
+	suit.Equal("refreshed", session.Token().AccessToken)
+	suit.Equal("refresh", session.Token().RefreshToken)
+}
+
+func (suit *OAuthSessionTestSuite) TestRefreshTokenSource() {
+	suit.mockedClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		clientId, clientSecret, ok := req.BasicAuth()
+		return ok && clientId == "client" && clientSecret == "secret" && req.URL.String() == OAUTH_TOKEN_URL
+	})).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"access_token":"new-access","refresh_token":"new-refresh","expires_in":3600}`)),
+	}, nil)
+
+	source := NewRefreshTokenSource("client", "secret", suit.mockedClient)
+	token, err := source.Token(context.Background(), OAuthToken{RefreshToken: "old-refresh"})
+
+	suit.Nil(err)
+	suit.Equal("new-access", token.AccessToken)
+	suit.Equal("new-refresh", token.RefreshToken)
+	suit.WithinDuration(time.Now().Add(time.Hour), token.Expiry, time.Minute)
+}
+
+func (suit *OAuthSessionTestSuite) TestRefreshTokenSourceUsesBaseUrl() {
+	suit.mockedClient.On("Do", mock.Anything).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(`{"access_token":"new-access","refresh_token":"new-refresh"}`)),
+	}, nil)
+
+	session := NewOAuthSession(options.NewOptionsWithDefaultValues().WithBaseUrl("http://localhost:8080/"), OAuthSessionOptions{
+		Token:       OAuthToken{RefreshToken: "old-refresh"},
+		TokenSource: NewRefreshTokenSource("client", "secret", suit.mockedClient),
+	})
+
+	suit.Nil(session.ApplyToRequest(session.GetOptions(), newRequest()))
+	suit.Equal("http://localhost:8080/oauth/token", suit.mockedClient.Calls[0].Arguments.Get(0).(*http.Request).URL.String())
+	suit.Equal("new-access", session.Token().AccessToken)
+}
+
+func TestOAuthTokenUrl(t *testing.T) {
+	assert.Equal(t, OAUTH_TOKEN_URL, OAuthTokenUrl(context.Background()))
+
+	ctx := WithRequestOptions(context.Background(), options.NewOptions().WithBaseUrl("http://call.example.com"))
+	assert.Equal(t, "http://call.example.com/oauth/token", OAuthTokenUrl(ctx))
+}
+
+func TestOAuthSessionTestSuite(t *testing.T) {
+	suite.Run(t, new(OAuthSessionTestSuite))
+}
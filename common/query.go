@@ -0,0 +1,140 @@
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/developertom01/klaviyo-go/common/filter"
+)
+
+// Builds query string of API requests. Values are URL-encoded once and empty values are skipped
+type Query struct {
+	values url.Values
+}
+
+func NewQuery() *Query {
+	return &Query{values: url.Values{}}
+}
+
+// Sets filter. Accepts a filter expression, eg. equals(name,"sam"), or the output of FilterBuilder.Build
+func (q *Query) Filter(value string) *Query {
+	return q.Set("filter", normalizeFilter(value))
+}
+
+// Validates `value`, a filter expression or the output of FilterBuilder.Build, against the fields and operators
+// `schema` allows. Skipped when disabled with options.WithFilterValidation
+func ValidateFilter(session Session, schema filter.Schema, value string) error {
+	if session != nil && session.GetOptions() != nil && !session.GetOptions().FilterValidation() {
+		return nil
+	}
+
+	return schema.ValidateString(normalizeFilter(value))
+}
+
+// Strips `filter=` prefix and URL-encoding added by FilterBuilder.Build
+func normalizeFilter(value string) string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "filter=") {
+		return value
+	}
+
+	value = strings.TrimPrefix(value, "filter=")
+	if unescaped, err := url.QueryUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
+
+// Sets sparse fieldset of `resourceType`, eg. fields[campaign]=name,status
+func (q *Query) Fields(resourceType string, fields ...string) *Query {
+	return q.Set(fmt.Sprintf("fields[%s]", resourceType), strings.Join(fields, ","))
+}
+
+// Sets additional fields of `resourceType`, eg. additional-fields[profile]=predictive_analytics
+func (q *Query) AdditionalFields(resourceType string, fields ...string) *Query {
+	return q.Set(fmt.Sprintf("additional-fields[%s]", resourceType), strings.Join(fields, ","))
+}
+
+// Query parameter such as models.Fields or models.Sort
+type QueryParam interface {
+	QueryParam() (key string, value string)
+}
+
+// Sets every param, eg. query.Apply(options.CampaignFields, options.Sort). Params with empty values are skipped
+func (q *Query) Apply(params ...QueryParam) *Query {
+	for _, param := range params {
+		if param == nil {
+			continue
+		}
+		key, value := param.QueryParam()
+		q.Set(key, value)
+	}
+	return q
+}
+
+// Sets related resources to include
+func (q *Query) Include(relationships ...string) *Query {
+	return q.Set("include", strings.Join(relationships, ","))
+}
+
+// Sets sort field. Prefix field with `-` to sort in descending order
+func (q *Query) Sort(field string) *Query {
+	return q.Set("sort", field)
+}
+
+// Sets page[size]. Ignored when not positive
+func (q *Query) PageSize(size int) *Query {
+	if size <= 0 {
+		return q
+	}
+	return q.Set("page[size]", strconv.Itoa(size))
+}
+
+// Sets page[cursor]. Accepts a cursor or a pagination link, eg. Links.Next, holding one
+func (q *Query) PageCursor(cursor string) *Query {
+	if link, err := url.Parse(cursor); err == nil && link.Query().Has("page[cursor]") {
+		cursor = link.Query().Get("page[cursor]")
+	}
+	return q.Set("page[cursor]", cursor)
+}
+
+// Sets `key`. Empty values remove the key
+func (q *Query) Set(key string, value string) *Query {
+	if value == "" {
+		q.values.Del(key)
+		return q
+	}
+
+	q.values.Set(key, value)
+	return q
+}
+
+// Returns value of `key`
+func (q *Query) Get(key string) string {
+	return q.values.Get(key)
+}
+
+// Returns encoded query string sorted by key, without leading `?`
+func (q *Query) Encode() string {
+	return q.values.Encode()
+}
+
+// Appends encoded query string to `baseUrl`
+func (q *Query) URL(baseUrl string) string {
+	encoded := q.Encode()
+	if encoded == "" {
+		return baseUrl
+	}
+	return baseUrl + "?" + encoded
+}
+
+// Converts typed values such as []models.CampaignsField to strings
+func StringsOf[S ~string](values []S) []string {
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		strs = append(strs, string(value))
+	}
+	return strs
+}
@@ -0,0 +1,93 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/developertom01/klaviyo-go/common/filter"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/developertom01/klaviyo-go/options"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryEncode(t *testing.T) {
+	query := NewQuery().
+		Fields("campaign", "name", "status").
+		Include("tags", "campaign-messages").
+		Sort("-created_at").
+		PageSize(10).
+		PageCursor("abc")
+
+	assert.Equal(t, "fields%5Bcampaign%5D=name%2Cstatus&include=tags%2Ccampaign-messages&page%5Bcursor%5D=abc&page%5Bsize%5D=10&sort=-created_at", query.Encode())
+}
+
+func TestQuerySkipsEmptyValues(t *testing.T) {
+	query := NewQuery().
+		Fields("campaign").
+		Include().
+		Sort("").
+		PageSize(0).
+		Filter("")
+
+	assert.Equal(t, "", query.Encode())
+	assert.Equal(t, "https://a.klaviyo.com/api/campaigns/", query.URL("https://a.klaviyo.com/api/campaigns/"))
+}
+
+func TestQueryFilterFromFilterBuilder(t *testing.T) {
+	filter := NewFilterBuilder().Equal("messages.channel", "email").Build()
+	query := NewQuery().Filter(filter)
+
+	assert.Equal(t, `equals(messages.channel,"email")`, query.Get("filter"))
+	assert.Equal(t, "filter=equals%28messages.channel%2C%22email%22%29", query.Encode())
+}
+
+func TestQueryPageCursorEscaping(t *testing.T) {
+	query := NewQuery().PageCursor("a+b/c=")
+
+	assert.Equal(t, "a+b/c=", query.Get("page[cursor]"))
+	assert.Equal(t, "https://a.klaviyo.com/api/images/?page%5Bcursor%5D=a%2Bb%2Fc%3D", query.URL("https://a.klaviyo.com/api/images/"))
+}
+
+func TestQueryPageCursorFromLink(t *testing.T) {
+	query := NewQuery().PageCursor("https://a.klaviyo.com/api/images/?page%5Bcursor%5D=bmV4dA%3D%3D")
+
+	assert.Equal(t, "bmV4dA==", query.Get("page[cursor]"))
+}
+
+func TestQueryFilterEncodesExpressionOnce(t *testing.T) {
+	expression, err := filter.Build(filter.And(
+		filter.Equals("messages.channel", filter.String("email")),
+		filter.Contains("name", filter.String("50% off")),
+	))
+	assert.NoError(t, err)
+
+	query := NewQuery().Filter(expression)
+
+	assert.Equal(t, `and(equals(messages.channel,"email"),contains(name,"50% off"))`, query.Get("filter"))
+	assert.Equal(t, "filter=and%28equals%28messages.channel%2C%22email%22%29%2Ccontains%28name%2C%2250%25+off%22%29%29", query.Encode())
+}
+
+func TestValidateFilter(t *testing.T) {
+	schema := filter.Schema{
+		Fields:   map[string][]filter.Operator{"messages.channel": {filter.OperatorEquals}},
+		Required: []string{"messages.channel"},
+	}
+	session := NewApiKeySession(options.NewOptionsWithDefaultValues().WithApiKey("key"), NewRetryOptionsWithDefaultValues())
+
+	assert.NoError(t, ValidateFilter(session, schema, NewFilterBuilder().Equal("messages.channel", "email").Build()))
+	assert.ErrorIs(t, ValidateFilter(session, schema, `equals(name,"sam")`), filter.ErrInvalidFilter)
+
+	disabled := NewApiKeySession(options.NewOptionsWithDefaultValues().WithApiKey("key").WithFilterValidation(false), NewRetryOptionsWithDefaultValues())
+	assert.NoError(t, ValidateFilter(disabled, schema, `equals(name,"sam")`))
+}
+
+func TestQueryApply(t *testing.T) {
+	var noTags models.Fields[models.TagField]
+
+	query := NewQuery().Apply(
+		models.FieldsOf(models.CampaignsFieldName, models.CampaignsFieldStatus),
+		noTags,
+		models.SortBy(models.CampaignSortFieldCreatedAtDesc).Asc(models.CampaignSortFieldNameAsc),
+	)
+
+	assert.Equal(t, "fields%5Bcampaign%5D=name%2Cstatus&sort=-created_at%2Cname", query.Encode())
+}
@@ -0,0 +1,103 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	// Token bucket limiting the rate of requests. Safe for concurrent use
+	RateLimiter struct {
+		mu       sync.Mutex
+		rate     float64 //Tokens added per second
+		burst    float64
+		tokens   float64
+		lastFill time.Time
+	}
+
+	// HTTPClient waiting on a RateLimiter before every request
+	rateLimitedClient struct {
+		client  HTTPClient
+		limiter *RateLimiter
+	}
+)
+
+// Allows `ratePerSecond` requests per second on average with bursts of up to `burst` requests.
+// A rate of 0 or less does not limit requests
+func NewRateLimiter(ratePerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:     ratePerSecond,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		lastFill: time.Now(),
+	}
+}
+
+// Blocks until a request is allowed or `ctx` is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Takes a token and returns 0, or returns how long to wait for the next token
+func (l *RateLimiter) reserve() time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.lastFill).Seconds()*l.rate)
+	l.lastFill = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// Wraps `client` so every request waits on `limiter` first
+func NewRateLimitedClient(client HTTPClient, limiter *RateLimiter) HTTPClient {
+	return &rateLimitedClient{
+		client:  client,
+		limiter: limiter,
+	}
+}
+
+func (c *rateLimitedClient) Do(req *http.Request) (*http.Response, error) {
+	if err := c.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return c.client.Do(req)
+}
+
+// Returns `override` waiting on the RateLimiter of `client`, when it has one, so clients set per call do not bypass it
+func withRateLimiterOf(client HTTPClient, override HTTPClient) HTTPClient {
+	if limited, ok := client.(*rateLimitedClient); ok {
+		return NewRateLimitedClient(override, limited.limiter)
+	}
+	return override
+}
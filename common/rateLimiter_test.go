@@ -0,0 +1,67 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRateLimiterAllowsBurst(t *testing.T) {
+	limiter := NewRateLimiter(1, 3)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, limiter.Wait(context.Background()))
+	}
+
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestRateLimiterWaitsForTokens(t *testing.T) {
+	limiter := NewRateLimiter(50, 1)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, limiter.Wait(context.Background()))
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+}
+
+func TestRateLimiterWithoutRate(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		limiter := NewRateLimiter(rate, 1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		for i := 0; i < 10; i++ {
+			assert.Nil(t, limiter.Wait(ctx))
+		}
+		cancel()
+	}
+}
+
+func TestRateLimiterHonoursContext(t *testing.T) {
+	limiter := NewRateLimiter(0.001, 1)
+	assert.Nil(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRateLimitedClient(t *testing.T) {
+	mockedClient := NewMockHTTPClient()
+	mockedClient.On("Do", mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil)
+
+	client := NewRateLimitedClient(mockedClient, NewRateLimiter(1, 1))
+	req, _ := http.NewRequest(http.MethodGet, BASE_URL, nil)
+
+	res, err := client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
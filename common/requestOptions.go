@@ -0,0 +1,128 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/developertom01/klaviyo-go/options"
+)
+
+type requestOptionsKey struct{}
+
+// Overrides revision, base URL, user agent, timeout and HTTP client of calls made with `ctx`.
+// Values not set on `opt` fall back to the session options. An overriding HTTP client keeps the rate limit of the client it replaces
+func WithRequestOptions(ctx context.Context, opt options.Options) context.Context {
+	return context.WithValue(ctx, requestOptionsKey{}, opt)
+}
+
+func requestOptionsFromContext(ctx context.Context) options.Options {
+	opt, _ := ctx.Value(requestOptionsKey{}).(options.Options)
+	return opt
+}
+
+// Returns configured base URL or BASE_URL
+func ResolveBaseUrl(opt options.Options) string {
+	if opt == nil || opt.BaseUrl() == "" {
+		return BASE_URL
+	}
+	return strings.TrimSuffix(opt.BaseUrl(), "/")
+}
+
+// Returns configured revision or API_REVISION
+func ResolveRevision(opt options.Options) string {
+	if opt == nil || opt.Revision() == "" {
+		return API_REVISION
+	}
+	return opt.Revision()
+}
+
+// Returns `httpClient`, the client configured on `opt` or http.DefaultClient, whichever is set first
+func ResolveHTTPClient(opt options.Options, httpClient HTTPClient) HTTPClient {
+	if httpClient != nil {
+		return httpClient
+	}
+	if opt != nil && opt.HTTPClient() != nil {
+		return opt.HTTPClient()
+	}
+	return http.DefaultClient
+}
+
+func resolveUserAgent(opt options.Options) string {
+	if opt == nil || opt.UserAgent() == "" {
+		return USER_AGENT
+	}
+	return opt.UserAgent()
+}
+
+// Applies per call overrides found in the request context.
+// Returned cancel function releases the call timeout and must be called once the response body is consumed
+func applyRequestOptions(httpClient HTTPClient, req *http.Request, session Session, revision string) (HTTPClient, *http.Request, context.CancelFunc, error) {
+	sessionOpt := session.GetOptions()
+	callOpt := requestOptionsFromContext(req.Context())
+
+	userAgent := resolveUserAgent(sessionOpt)
+	timeout := sessionOpt.Timeout()
+	cancel := context.CancelFunc(func() {})
+
+	if callOpt != nil {
+		if callOpt.Revision() != "" {
+			revision = callOpt.Revision()
+		}
+		if callOpt.UserAgent() != "" {
+			userAgent = callOpt.UserAgent()
+		}
+		if callOpt.Timeout() > 0 {
+			timeout = callOpt.Timeout()
+		}
+		if callOpt.HTTPClient() != nil {
+			httpClient = withRateLimiterOf(httpClient, callOpt.HTTPClient())
+		}
+		if callOpt.BaseUrl() != "" {
+			if err := rewriteBaseUrl(req, ResolveBaseUrl(sessionOpt), ResolveBaseUrl(callOpt)); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+	}
+
+	req.Header.Set("revision", revision)
+	req.Header.Set("User-Agent", userAgent)
+
+	if timeout > 0 {
+		var ctx context.Context
+		ctx, cancel = context.WithTimeout(req.Context(), timeout)
+		req = req.WithContext(ctx)
+	}
+
+	return httpClient, req, cancel, nil
+}
+
+// Points `req` built against `from` at `to`
+func rewriteBaseUrl(req *http.Request, from string, to string) error {
+	rawUrl := req.URL.String()
+	if !strings.HasPrefix(rawUrl, from) {
+		return nil
+	}
+
+	rewritten, err := url.Parse(to + strings.TrimPrefix(rawUrl, from))
+	if err != nil {
+		return err
+	}
+	req.URL = rewritten
+	req.Host = rewritten.Host
+
+	return nil
+}
+
+// Releases call timeout when response body is closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,110 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/developertom01/klaviyo-go/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRequestOptionsMockClient(t *testing.T) *MockHTTPClient {
+	client := NewMockHTTPClient()
+	if err := PrepareMockResponse(http.StatusOK, map[string]any{"data": []any{}}, client); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func sentRequest(client *MockHTTPClient) *http.Request {
+	return client.Calls[0].Arguments.Get(0).(*http.Request)
+}
+
+func TestNewApiKeySessionRetainsOptions(t *testing.T) {
+	opt := options.NewOptions().
+		WithApiKey(testLoggerApiKey).
+		WithRevision("2024-07-15").
+		WithBaseUrl("http://localhost:8080/").
+		WithUserAgent("my-app/1.0").
+		WithTimeout(time.Second)
+
+	session := NewApiKeySession(opt, nil)
+
+	assert.Equal(t, "2024-07-15", session.GetOptions().Revision())
+	assert.Equal(t, "http://localhost:8080", ResolveBaseUrl(session.GetOptions()))
+	assert.Equal(t, "my-app/1.0", session.GetOptions().UserAgent())
+	assert.Equal(t, time.Second, session.GetOptions().Timeout())
+}
+
+func TestNewApiKeySessionSnapshotsOptions(t *testing.T) {
+	opt := options.NewOptionsWithDefaultValues().WithApiKey(testLoggerApiKey)
+	session := NewApiKeySession(opt, nil)
+
+	opt.WithApiKey("other-key").WithRevision("2024-07-15")
+
+	assert.Equal(t, testLoggerApiKey, *session.GetOptions().ApiKey())
+	assert.Equal(t, options.DEFAULT_REVISION, session.GetOptions().Revision())
+}
+
+func TestResolveDefaults(t *testing.T) {
+	opt := options.NewOptions()
+
+	assert.Equal(t, BASE_URL, ResolveBaseUrl(opt))
+	assert.Equal(t, API_REVISION, ResolveRevision(opt))
+	assert.Equal(t, http.DefaultClient, ResolveHTTPClient(opt, nil))
+
+	client := NewMockHTTPClient()
+	assert.Equal(t, client, ResolveHTTPClient(opt.WithHTTPClient(client), nil))
+}
+
+func TestRetrieveDataUsesSessionUserAgent(t *testing.T) {
+	session := NewApiKeySession(options.NewOptions().WithApiKey(testLoggerApiKey).WithUserAgent("my-app/1.0"), nil)
+	client := newRequestOptionsMockClient(t)
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, BASE_URL+"/api/accounts/", nil)
+	_, err := RetrieveData(client, req, session, API_REVISION)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "my-app/1.0", sentRequest(client).Header.Get("User-Agent"))
+	assert.Equal(t, API_REVISION, sentRequest(client).Header.Get("revision"))
+}
+
+func TestRetrieveDataAppliesRequestOptions(t *testing.T) {
+	session := NewApiKeySession(options.NewOptions().WithApiKey(testLoggerApiKey), nil)
+	sessionClient := NewMockHTTPClient()
+	callClient := newRequestOptionsMockClient(t)
+
+	ctx := WithRequestOptions(context.Background(), options.NewOptions().
+		WithRevision("2024-07-15.pre").
+		WithBaseUrl("http://localhost:8080").
+		WithUserAgent("trial").
+		WithHTTPClient(callClient))
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, BASE_URL+"/api/accounts/?fields[account]=test_account", nil)
+	_, err := RetrieveData(sessionClient, req, session, API_REVISION)
+
+	assert.Nil(t, err)
+	sessionClient.AssertNotCalled(t, "Do", mock.Anything)
+
+	sent := sentRequest(callClient)
+	assert.Equal(t, "http://localhost:8080/api/accounts/?fields[account]=test_account", sent.URL.String())
+	assert.Equal(t, "localhost:8080", sent.Host)
+	assert.Equal(t, "2024-07-15.pre", sent.Header.Get("revision"))
+	assert.Equal(t, "trial", sent.Header.Get("User-Agent"))
+}
+
+func TestRetrieveDataAppliesTimeout(t *testing.T) {
+	session := NewApiKeySession(options.NewOptions().WithApiKey(testLoggerApiKey).WithTimeout(time.Minute), nil)
+	client := newRequestOptionsMockClient(t)
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, BASE_URL+"/api/accounts/", nil)
+	_, err := RetrieveData(client, req, session, API_REVISION)
+
+	assert.Nil(t, err)
+	deadline, ok := sentRequest(client).Context().Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+}
@@ -1,10 +1,12 @@
 package common
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 	"slices"
 	"time"
+
+	"github.com/developertom01/klaviyo-go/options"
 )
 
 type RetryOptions struct {
@@ -21,7 +23,17 @@ func NewRetryOptionsWithDefaultValues() *RetryOptions {
 
 type RetryableFunc func() (*http.Response, error)
 
+// Calls `fn` until it returns a non retryable status or `opt.MaxRetries` attempts were made. Retries are not logged,
+// see RetryWithLogger
 func Retry(fn RetryableFunc, opt RetryOptions) (*http.Response, error) {
+	return RetryWithLogger(fn, opt, nil)
+}
+
+// Retry logging retries to `logger`, nothing is logged when it is nil
+func RetryWithLogger(fn RetryableFunc, opt RetryOptions, logger *slog.Logger) (*http.Response, error) {
+	if logger == nil {
+		logger = options.NewOptions().Logger()
+	}
 	var retryStatusCode = []int{http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
 
 	var resp *http.Response
@@ -43,12 +55,14 @@ func Retry(fn RetryableFunc, opt RetryOptions) (*http.Response, error) {
 			break
 		}
 
-		// Log the error
-		log.Printf("Error making API call: %v\n", err)
-
 		// If not the last retry, wait before retrying
 		if retries < opt.MaxRetries-1 {
-			log.Printf("Retrying in %v seconds...\n", opt.Interval.Seconds())
+			logger.Warn("klaviyo: retrying request",
+				slog.Int("attempt", retries+1),
+				slog.Int("status", resp.StatusCode),
+				slog.Duration("delay", opt.Interval),
+			)
+			resp.Body.Close()
 			time.Sleep(opt.Interval)
 		}
 	}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns a RetryableFunc answering 503 until attempt `succeedAt`, counting attempts in `attempts`
+func unavailableUntil(succeedAt int, attempts *int) RetryableFunc {
+	return func() (*http.Response, error) {
+		*attempts++
+		status := http.StatusServiceUnavailable
+		if *attempts == succeedAt {
+			status = http.StatusOK
+		}
+		return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewBufferString("{}"))}, nil
+	}
+}
+
+func TestRetry(t *testing.T) {
+	attempts := 0
+	res, err := Retry(unavailableUntil(2, &attempts), RetryOptions{MaxRetries: 3, Interval: time.Millisecond})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetryWithLogger(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	attempts := 0
+	res, err := RetryWithLogger(unavailableUntil(3, &attempts), RetryOptions{MaxRetries: 3, Interval: time.Millisecond}, logger)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 2, bytes.Count(logs.Bytes(), []byte("klaviyo: retrying request")))
+	assert.Contains(t, logs.String(), "attempt=2 status=503")
+}
+
+func TestRetryWithoutLogger(t *testing.T) {
+	attempts := 0
+	res, err := RetryWithLogger(unavailableUntil(2, &attempts), RetryOptions{MaxRetries: 3, Interval: time.Millisecond}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 2, attempts)
+}
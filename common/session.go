@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 
 	"github.com/developertom01/klaviyo-go/exceptions"
@@ -22,9 +23,8 @@ type ApiKeySession struct {
 	retryOpt RetryOptions
 }
 
+// Session authorizing requests with the API key of `opt`. Later changes to `opt` do not affect it
 func NewApiKeySession(opt options.Options, rOpt *RetryOptions) Session {
-	options := options.NewOptionsWithDefaultValues().WithApiKey(*opt.ApiKey())
-
 	var retryOptions *RetryOptions
 
 	if rOpt == nil {
@@ -34,7 +34,7 @@ func NewApiKeySession(opt options.Options, rOpt *RetryOptions) Session {
 	}
 
 	return &ApiKeySession{
-		opt:      options,
+		opt:      opt.Clone(),
 		retryOpt: *retryOptions,
 	}
 }
@@ -43,7 +43,7 @@ func (s ApiKeySession) ApplyToRequest(option options.Options, req *http.Request)
 	if s.opt.ApiKey() == nil {
 		return exceptions.NewApiKeyRequiredError("API key not set")
 	}
-	req.Header.Add("Authorization", fmt.Sprintf("%s %s", authorizationPrefix, *s.opt.ApiKey()))
+	req.Header.Set("Authorization", fmt.Sprintf("%s %s", authorizationPrefix, *s.opt.ApiKey()))
 
 	return nil
 }
@@ -55,3 +55,8 @@ func (s ApiKeySession) GetRetryOptions() RetryOptions {
 func (s ApiKeySession) GetOptions() options.Options {
 	return s.opt
 }
+
+// Logs options with the API key redacted
+func (s ApiKeySession) LogValue() slog.Value {
+	return slog.AnyValue(s.opt)
+}
@@ -1,16 +1,290 @@
 package exceptions
 
-// Error when status code returns 5xx or 4xx
-type ErrorResponse struct {
-	Err ApiErrorResponse
-}
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
 
-func (ErrorResponse) Error() string {
-	return "Api call error"
-}
+// Response header carrying the ID Klaviyo assigned to the request
+const REQUEST_ID_HEADER = "X-Request-Id"
+
+// Maximum number of bytes of an error response body kept in memory
+const MAX_ERROR_BODY_SIZE = 64 << 10
+
+// Maximum length of the body snippet included in RawHttpError messages
+const errorBodySnippetSize = 256
+
+// Sentinels matched by errors.Is against errors returned by API calls
+var (
+	ErrNotFound     = errors.New("Resource not found")
+	ErrUnauthorized = errors.New("Unauthorized")
+	ErrForbidden    = errors.New("Forbidden")
+	ErrValidation   = errors.New("Invalid request")
+	ErrRateLimited  = errors.New("Rate limited")
+	ErrConflict     = errors.New("Conflict")
+	ErrServerError  = errors.New("Server error")
+)
+
+type (
+	// Error when status code returns 5xx or 4xx
+	ErrorResponse struct {
+		Err        ApiErrorResponse
+		StatusCode int    //HTTP status code
+		RequestId  string //ID Klaviyo assigned to the request, empty when not sent
+		Method     string //HTTP method of the request
+		Endpoint   string //Path of the request, eg. /api/campaigns/
+		Body       []byte //Raw response body, capped at MAX_ERROR_BODY_SIZE
+	}
+
+	// Error response whose body is not a JSON:API error document, eg. an HTML page of a proxy.
+	// Unwraps to the typed error of its status code, eg. ServerError
+	RawHttpError struct {
+		ErrorResponse
+		ContentType string
+		typed       error
+	}
+
+	// 404
+	NotFoundError struct{ ErrorResponse }
+
+	// 401
+	UnauthorizedError struct{ ErrorResponse }
+
+	// 403
+	ForbiddenError struct{ ErrorResponse }
+
+	// 400 and 422
+	ValidationError struct{ ErrorResponse }
+
+	// 409
+	ConflictError struct{ ErrorResponse }
+
+	// 5xx
+	ServerError struct{ ErrorResponse }
+
+	// 429
+	RateLimitedError struct {
+		ErrorResponse
+		RetryAfter time.Duration //Delay requested by the Retry-After header, zero when not sent
+	}
+)
 
 func NewResponseError(err ApiErrorResponse) ErrorResponse {
 	return ErrorResponse{
 		Err: err,
 	}
 }
+
+// Creates the typed error matching the status code of `res`.
+// Errors of status codes without a dedicated type are returned as ErrorResponse
+func NewHttpError(req *http.Request, res *http.Response, body ApiErrorResponse) error {
+	return typedError(newErrorResponse(req, res, body, nil), res)
+}
+
+// Reads the body of an unsuccessful response, at most MAX_ERROR_BODY_SIZE bytes, and creates the matching error.
+// Bodies that are not JSON:API error documents are returned as RawHttpError
+func DecodeHttpError(req *http.Request, res *http.Response) error {
+	var raw []byte
+	if res.Body != nil {
+		var err error
+		raw, err = io.ReadAll(io.LimitReader(res.Body, MAX_ERROR_BODY_SIZE))
+		if err != nil {
+			return errors.Join(typedError(newErrorResponse(req, res, ApiErrorResponse{}, raw), res), err)
+		}
+	}
+
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return typedError(newErrorResponse(req, res, ApiErrorResponse{}, raw), res)
+	}
+
+	var body ApiErrorResponse
+	if err := json.Unmarshal(raw, &body); err != nil || len(body.Errors) == 0 {
+		errRes := newErrorResponse(req, res, ApiErrorResponse{}, raw)
+		return RawHttpError{
+			ErrorResponse: errRes,
+			ContentType:   res.Header.Get("Content-Type"),
+			typed:         typedError(errRes, res),
+		}
+	}
+
+	return typedError(newErrorResponse(req, res, body, raw), res)
+}
+
+func newErrorResponse(req *http.Request, res *http.Response, body ApiErrorResponse, raw []byte) ErrorResponse {
+	errRes := ErrorResponse{
+		Err:        body,
+		StatusCode: res.StatusCode,
+		RequestId:  res.Header.Get(REQUEST_ID_HEADER),
+		Body:       raw,
+	}
+	if req != nil {
+		errRes.Method = req.Method
+		errRes.Endpoint = req.URL.Path
+	}
+
+	return errRes
+}
+
+func typedError(errRes ErrorResponse, res *http.Response) error {
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return NotFoundError{errRes}
+	case res.StatusCode == http.StatusUnauthorized:
+		return UnauthorizedError{errRes}
+	case res.StatusCode == http.StatusForbidden:
+		return ForbiddenError{errRes}
+	case res.StatusCode == http.StatusBadRequest, res.StatusCode == http.StatusUnprocessableEntity:
+		return ValidationError{errRes}
+	case res.StatusCode == http.StatusConflict:
+		return ConflictError{errRes}
+	case res.StatusCode == http.StatusTooManyRequests:
+		return RateLimitedError{ErrorResponse: errRes, RetryAfter: parseRetryAfter(res.Header.Get("Retry-After"))}
+	case IsHttpCodeServerError(res.StatusCode):
+		return ServerError{errRes}
+	}
+
+	return errRes
+}
+
+func (e ErrorResponse) Error() string {
+	var message strings.Builder
+
+	if e.StatusCode == 0 {
+		message.WriteString("Api call error")
+	} else {
+		message.WriteString("klaviyo: ")
+		if e.Endpoint != "" {
+			fmt.Fprintf(&message, "%s %s: ", e.Method, e.Endpoint)
+		}
+		fmt.Fprintf(&message, "%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+
+	if details := e.details(); details != "" {
+		message.WriteString(": ")
+		message.WriteString(details)
+	}
+	if e.RequestId != "" {
+		fmt.Fprintf(&message, " (request id %s)", e.RequestId)
+	}
+
+	return message.String()
+}
+
+func (e ErrorResponse) details() string {
+	details := make([]string, 0, len(e.Err.Errors))
+	for _, apiErr := range e.Err.Errors {
+		switch {
+		case apiErr.Detail == "" || apiErr.Detail == apiErr.Title:
+			details = append(details, apiErr.Title)
+		case apiErr.Title == "":
+			details = append(details, apiErr.Detail)
+		default:
+			details = append(details, apiErr.Title+": "+apiErr.Detail)
+		}
+	}
+
+	return strings.Join(details, "; ")
+}
+
+// Reports whether `target` is the sentinel of the status code
+func (e ErrorResponse) Is(target error) bool {
+	sentinel := sentinelOf(e.StatusCode)
+	return sentinel != nil && sentinel == target
+}
+
+// Errors returned by Klaviyo
+func (e ErrorResponse) ApiErrors() []ApiError {
+	return e.Err.Errors
+}
+
+// Errors pointing at a field of the request payload
+func (e ErrorResponse) FieldErrors() []FieldError {
+	var fieldErrors []FieldError
+	for _, apiErr := range e.Err.Errors {
+		if apiErr.Source == nil || apiErr.Source.Pointer == nil {
+			continue
+		}
+		fieldErrors = append(fieldErrors, FieldError{
+			Path:    apiErr.Source.Path(),
+			Pointer: *apiErr.Source.Pointer,
+			Code:    apiErr.Code,
+			Detail:  apiErr.Detail,
+		})
+	}
+
+	return fieldErrors
+}
+
+func sentinelOf(statusCode int) error {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case statusCode == http.StatusForbidden:
+		return ErrForbidden
+	case statusCode == http.StatusBadRequest, statusCode == http.StatusUnprocessableEntity:
+		return ErrValidation
+	case statusCode == http.StatusConflict:
+		return ErrConflict
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case IsHttpCodeServerError(statusCode):
+		return ErrServerError
+	}
+
+	return nil
+}
+
+func (e NotFoundError) Unwrap() error     { return e.ErrorResponse }
+func (e UnauthorizedError) Unwrap() error { return e.ErrorResponse }
+func (e ForbiddenError) Unwrap() error    { return e.ErrorResponse }
+func (e ValidationError) Unwrap() error   { return e.ErrorResponse }
+func (e ConflictError) Unwrap() error     { return e.ErrorResponse }
+func (e ServerError) Unwrap() error       { return e.ErrorResponse }
+func (e RateLimitedError) Unwrap() error  { return e.ErrorResponse }
+
+func (e RawHttpError) Unwrap() error {
+	if e.typed != nil {
+		return e.typed
+	}
+	return e.ErrorResponse
+}
+
+func (e RawHttpError) Error() string {
+	snippet := strings.Join(strings.Fields(string(e.Body)), " ")
+	if len(snippet) > errorBodySnippetSize {
+		snippet = snippet[:errorBodySnippetSize] + "..."
+	}
+
+	return fmt.Sprintf("%s: unexpected response body: %q", e.ErrorResponse.Error(), snippet)
+}
+
+func (e RateLimitedError) Error() string {
+	if e.RetryAfter <= 0 {
+		return e.ErrorResponse.Error()
+	}
+	return fmt.Sprintf("%s, retry after %s", e.ErrorResponse.Error(), e.RetryAfter)
+}
+
+// Parses Retry-After given in seconds or as HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return 0
+}
@@ -0,0 +1,154 @@
+package exceptions
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHttpError(statusCode int, header http.Header, apiErrors ...ApiError) error {
+	req, _ := http.NewRequest(http.MethodGet, "https://a.klaviyo.com/api/campaigns/123/?include=tags", nil)
+	res := &http.Response{StatusCode: statusCode, Header: header}
+
+	return NewHttpError(req, res, ApiErrorResponse{Errors: apiErrors})
+}
+
+func TestNewHttpErrorTypes(t *testing.T) {
+	cases := []struct {
+		statusCode int
+		sentinel   error
+		target     any
+	}{
+		{http.StatusNotFound, ErrNotFound, &NotFoundError{}},
+		{http.StatusUnauthorized, ErrUnauthorized, &UnauthorizedError{}},
+		{http.StatusForbidden, ErrForbidden, &ForbiddenError{}},
+		{http.StatusBadRequest, ErrValidation, &ValidationError{}},
+		{http.StatusUnprocessableEntity, ErrValidation, &ValidationError{}},
+		{http.StatusConflict, ErrConflict, &ConflictError{}},
+		{http.StatusTooManyRequests, ErrRateLimited, &RateLimitedError{}},
+		{http.StatusBadGateway, ErrServerError, &ServerError{}},
+	}
+
+	for _, c := range cases {
+		err := newTestHttpError(c.statusCode, nil)
+
+		assert.ErrorIs(t, err, c.sentinel, c.statusCode)
+		assert.ErrorAs(t, err, c.target, c.statusCode)
+		assert.ErrorAs(t, err, &ErrorResponse{}, c.statusCode)
+	}
+
+	assert.NotErrorIs(t, newTestHttpError(http.StatusNotFound, nil), ErrServerError)
+}
+
+func TestErrorResponseFields(t *testing.T) {
+	header := http.Header{}
+	header.Set(REQUEST_ID_HEADER, "req-1")
+
+	err := newTestHttpError(http.StatusNotFound, header, ApiError{Code: "not_found", Title: "Not found", Detail: "Campaign 123 does not exist"})
+
+	var notFound NotFoundError
+	assert.True(t, errors.As(err, &notFound))
+	assert.Equal(t, http.StatusNotFound, notFound.StatusCode)
+	assert.Equal(t, "req-1", notFound.RequestId)
+	assert.Equal(t, http.MethodGet, notFound.Method)
+	assert.Equal(t, "/api/campaigns/123/", notFound.Endpoint)
+	assert.Equal(t, "not_found", notFound.ApiErrors()[0].Code)
+	assert.Equal(t, "klaviyo: GET /api/campaigns/123/: 404 Not Found: Not found: Campaign 123 does not exist (request id req-1)", err.Error())
+}
+
+func TestRateLimitedRetryAfter(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "30")
+
+	err := newTestHttpError(http.StatusTooManyRequests, header, ApiError{Title: "Throttled", Detail: "Throttled"})
+
+	var rateLimited RateLimitedError
+	assert.True(t, errors.As(err, &rateLimited))
+	assert.Equal(t, 30*time.Second, rateLimited.RetryAfter)
+	assert.Equal(t, "klaviyo: GET /api/campaigns/123/: 429 Too Many Requests: Throttled, retry after 30s", err.Error())
+}
+
+func TestErrorResponseWithoutStatus(t *testing.T) {
+	err := NewResponseError(ApiErrorResponse{Errors: []ApiError{{Title: "Invalid input"}}})
+
+	assert.Equal(t, "Api call error: Invalid input", err.Error())
+	assert.NotErrorIs(t, err, ErrValidation)
+}
+
+func decodeTestHttpError(statusCode int, contentType string, body string) error {
+	req, _ := http.NewRequest(http.MethodPost, "https://a.klaviyo.com/api/campaigns/", nil)
+	res := &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	return DecodeHttpError(req, res)
+}
+
+func TestDecodeHttpErrorHtmlBody(t *testing.T) {
+	err := decodeTestHttpError(http.StatusBadGateway, "text/html", "<html>\n  <body>Bad gateway</body>\n</html>")
+
+	var rawErr RawHttpError
+	assert.True(t, errors.As(err, &rawErr))
+	assert.Equal(t, http.StatusBadGateway, rawErr.StatusCode)
+	assert.Equal(t, "text/html", rawErr.ContentType)
+	assert.ErrorIs(t, err, ErrServerError)
+	assert.ErrorAs(t, err, &ServerError{})
+	assert.ErrorAs(t, err, &ErrorResponse{})
+	assert.Equal(t, `klaviyo: POST /api/campaigns/: 502 Bad Gateway: unexpected response body: "<html> <body>Bad gateway</body> </html>"`, err.Error())
+}
+
+func TestDecodeHttpErrorCapsBody(t *testing.T) {
+	err := decodeTestHttpError(http.StatusServiceUnavailable, "text/plain", strings.Repeat("x", MAX_ERROR_BODY_SIZE*2))
+
+	var rawErr RawHttpError
+	assert.True(t, errors.As(err, &rawErr))
+	assert.Len(t, rawErr.Body, MAX_ERROR_BODY_SIZE)
+	assert.Less(t, len(err.Error()), errorBodySnippetSize*2)
+}
+
+func TestDecodeHttpErrorRawBodyTypes(t *testing.T) {
+	notFound := decodeTestHttpError(http.StatusNotFound, "text/html", "<html>Not found</html>")
+	assert.ErrorAs(t, notFound, &NotFoundError{})
+	assert.ErrorIs(t, notFound, ErrNotFound)
+
+	var rateLimited RateLimitedError
+	if assert.ErrorAs(t, decodeTestHttpError(http.StatusTooManyRequests, "text/plain", "slow down"), &rateLimited) {
+		assert.Equal(t, http.StatusTooManyRequests, rateLimited.StatusCode)
+	}
+}
+
+func TestDecodeHttpErrorEmptyBody(t *testing.T) {
+	err := decodeTestHttpError(http.StatusNotFound, "", "")
+
+	assert.ErrorAs(t, err, &NotFoundError{})
+	assert.Equal(t, "klaviyo: POST /api/campaigns/: 404 Not Found", err.Error())
+}
+
+func TestDecodeHttpErrorFieldErrors(t *testing.T) {
+	body := `{"errors":[{"id":"1","code":"invalid","title":"Invalid input.","detail":"Audience is required","source":{"pointer":"/data/attributes/audiences/included/0"}}]}`
+
+	err := decodeTestHttpError(http.StatusBadRequest, "application/vnd.api+json", body)
+
+	var validationErr ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []FieldError{{
+		Path:    "data.attributes.audiences.included[0]",
+		Pointer: "/data/attributes/audiences/included/0",
+		Code:    "invalid",
+		Detail:  "Audience is required",
+	}}, validationErr.FieldErrors())
+	assert.Equal(t, body, string(validationErr.Body))
+}
+
+func TestPointerToPath(t *testing.T) {
+	assert.Equal(t, "", PointerToPath("/"))
+	assert.Equal(t, "data.attributes.name", PointerToPath("/data/attributes/name"))
+	assert.Equal(t, "data[0].attributes.a/b", PointerToPath("/data/0/attributes/a~1b"))
+}
@@ -0,0 +1,63 @@
+package exceptions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinels matched by errors.Is against errors of asynchronous jobs
+var (
+	ErrJobFailed    = errors.New("Job failed")
+	ErrJobCancelled = errors.New("Job cancelled")
+)
+
+// Error of an asynchronous job that was cancelled or had failed operations
+type JobError struct {
+	JobType   string //eg. catalog-item-bulk-create-job
+	JobId     string
+	Status    string
+	Total     int64 //Operations processed by the job
+	Failed    int64 //Operations that failed
+	Cancelled bool
+	Err       ApiErrorResponse //Errors of the failed operations
+}
+
+func (e JobError) Error() string {
+	var message strings.Builder
+	fmt.Fprintf(&message, "klaviyo: %s %s: ", e.JobType, e.JobId)
+
+	if e.Cancelled {
+		message.WriteString("job was cancelled")
+	} else {
+		fmt.Fprintf(&message, "%d of %d operations failed", e.Failed, e.Total)
+	}
+
+	if details := (ErrorResponse{Err: e.Err}).details(); details != "" {
+		message.WriteString(": ")
+		message.WriteString(details)
+	}
+
+	return message.String()
+}
+
+// Reports whether `target` is ErrJobCancelled for a cancelled job, or ErrJobFailed for a job with failed operations
+func (e JobError) Is(target error) bool {
+	switch target {
+	case ErrJobCancelled:
+		return e.Cancelled
+	case ErrJobFailed:
+		return e.Failed > 0 || len(e.Err.Errors) > 0
+	}
+	return false
+}
+
+// Errors of the failed operations
+func (e JobError) ApiErrors() []ApiError {
+	return e.Err.Errors
+}
+
+// Errors of the failed operations pointing at a field of the job payload
+func (e JobError) FieldErrors() []FieldError {
+	return ErrorResponse{Err: e.Err}.FieldErrors()
+}
@@ -1,8 +1,14 @@
 package exceptions
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 type (
 	ApiErrorSource struct {
-		Pointer   *string `json:"pinter,omitempty"`
+		Pointer   *string `json:"pointer,omitempty"`
 		Parameter *string `json:"parameter,omitempty"`
 	}
 
@@ -19,6 +25,45 @@ type (
 	}
 )
 
+// Field reported by a validation error
+type FieldError struct {
+	Path    string //Payload path of the field, eg. data.attributes.audiences.included[0]
+	Pointer string //JSON pointer reported by Klaviyo
+	Code    string
+	Detail  string
+}
+
+// Converts JSON pointer of the source to a payload path, eg. /data/attributes/tags/0 to data.attributes.tags[0]
+func (s ApiErrorSource) Path() string {
+	if s.Pointer == nil {
+		return ""
+	}
+	return PointerToPath(*s.Pointer)
+}
+
+// Converts RFC 6901 JSON pointer to a payload path, eg. /data/attributes/tags/0 to data.attributes.tags[0]
+func PointerToPath(pointer string) string {
+	if pointer == "" || pointer == "/" {
+		return ""
+	}
+
+	var path strings.Builder
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		if _, err := strconv.Atoi(token); err == nil && path.Len() > 0 {
+			fmt.Fprintf(&path, "[%s]", token)
+			continue
+		}
+		if path.Len() > 0 {
+			path.WriteByte('.')
+		}
+		path.WriteString(token)
+	}
+
+	return path.String()
+}
+
 // 2xx codes
 func IsHttpCodeOk(code int) bool {
 	return code >= 200 && code < 300
@@ -0,0 +1,200 @@
+package fixtures
+
+import (
+	"fmt"
+
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Builder of a models.Campaign and its messages and tags
+type CampaignBuilder struct {
+	generator *Generator
+	campaign  models.Campaign
+	channel   models.Channel
+	messages  []models.CampaignMessage
+	tags      []models.Tag
+}
+
+// Returns builder of a draft email campaign with the default generator
+func Campaign() *CampaignBuilder {
+	return generator().Campaign()
+}
+
+// Returns builder of a draft email campaign
+func (g *Generator) Campaign() *CampaignBuilder {
+	id := g.id()
+	created := g.now()
+
+	return &CampaignBuilder{
+		generator: g,
+		channel:   models.ChannelEmail,
+		campaign: models.Campaign{
+			Type: "campaign",
+			ID:   id,
+			Attributes: models.CampaignAttributes{
+				Name:         fmt.Sprintf("Campaign %s", g.words(2)),
+				Status:       "Draft",
+				Audiences:    models.Audiences{Included: []string{g.id()}},
+				SendOptions:  models.SendOptions{UseSmartSending: true},
+				SendStrategy: models.SendStrategy{Method: models.SendStrategyMethodImmediate},
+				CreatedAt:    created,
+				UpdatedAt:    created,
+			},
+			Links: models.DataLinks{Self: selfLink("campaign", id)},
+		},
+	}
+}
+
+func (b *CampaignBuilder) WithID(id string) *CampaignBuilder {
+	b.campaign.ID = id
+	b.campaign.Links.Self = selfLink("campaign", id)
+	return b
+}
+
+func (b *CampaignBuilder) WithName(name string) *CampaignBuilder {
+	b.campaign.Attributes.Name = name
+	return b
+}
+
+func (b *CampaignBuilder) WithStatus(status string) *CampaignBuilder {
+	b.campaign.Attributes.Status = status
+	return b
+}
+
+func (b *CampaignBuilder) WithArchived(archived bool) *CampaignBuilder {
+	b.campaign.Attributes.Archived = archived
+	return b
+}
+
+func (b *CampaignBuilder) WithAudiences(included []string, excluded []string) *CampaignBuilder {
+	b.campaign.Attributes.Audiences = models.Audiences{Included: included, Excluded: excluded}
+	return b
+}
+
+func (b *CampaignBuilder) WithSendStrategy(strategy models.SendStrategy) *CampaignBuilder {
+	b.campaign.Attributes.SendStrategy = strategy
+	return b
+}
+
+// Sets the channel of the messages added next, email by default
+func (b *CampaignBuilder) WithChannel(channel models.Channel) *CampaignBuilder {
+	b.channel = channel
+	return b
+}
+
+// Adds `n` messages on the campaign channel
+func (b *CampaignBuilder) WithMessages(n int) *CampaignBuilder {
+	for i := 0; i < n; i++ {
+		message := b.generator.CampaignMessage().WithChannel(b.channel).Build()
+		b.messages = append(b.messages, message)
+	}
+	return b
+}
+
+// Adds `n` tags
+func (b *CampaignBuilder) WithTags(n int) *CampaignBuilder {
+	for i := 0; i < n; i++ {
+		b.tags = append(b.tags, b.generator.Tag().Build())
+	}
+	return b
+}
+
+func (b *CampaignBuilder) Build() models.Campaign {
+	campaign := b.campaign
+	if len(b.messages) > 0 || len(b.tags) > 0 {
+		campaign.Relationships = &models.CampaignRelationship{
+			CampaignMessage: relationships(campaign.Type, campaign.ID, "campaign-messages", identifiers(b.messages)),
+			Tags:            relationships(campaign.Type, campaign.ID, "tags", identifiers(b.tags)),
+		}
+	}
+	return campaign
+}
+
+// Returns the messages of the campaign
+func (b *CampaignBuilder) Messages() []models.CampaignMessage {
+	return append([]models.CampaignMessage(nil), b.messages...)
+}
+
+// Returns the messages and tags of the campaign
+func (b *CampaignBuilder) Included() []any {
+	return append(anys(b.messages), anys(b.tags)...)
+}
+
+// Returns document of the campaign including its messages and tags
+func (b *CampaignBuilder) Document() models.CampaignResponse {
+	return Document(b.Build(), b.Included()...)
+}
+
+// Builder of a models.CampaignMessage
+type CampaignMessageBuilder struct {
+	generator *Generator
+	message   models.CampaignMessage
+}
+
+// Returns builder of an email message with the default generator
+func CampaignMessage() *CampaignMessageBuilder {
+	return generator().CampaignMessage()
+}
+
+// Returns builder of an email message
+func (g *Generator) CampaignMessage() *CampaignMessageBuilder {
+	id := g.id()
+	created := g.now()
+
+	b := &CampaignMessageBuilder{
+		generator: g,
+		message: models.CampaignMessage{
+			Type: "campaign-message",
+			ID:   id,
+			Attributes: models.CampaignMessageAttributes{
+				Label:     fmt.Sprintf("Message %s", g.words(2)),
+				SendTimes: []models.SendTime{},
+				CreatedAt: created,
+				UpdatedAt: created,
+			},
+			Links: models.DataLinks{Self: selfLink("campaign-message", id)},
+		},
+	}
+	return b.WithChannel(models.ChannelEmail)
+}
+
+func (b *CampaignMessageBuilder) WithID(id string) *CampaignMessageBuilder {
+	b.message.ID = id
+	b.message.Links.Self = selfLink("campaign-message", id)
+	return b
+}
+
+func (b *CampaignMessageBuilder) WithLabel(label string) *CampaignMessageBuilder {
+	b.message.Attributes.Label = label
+	return b
+}
+
+// Sets the channel and content of the message, email or sms
+func (b *CampaignMessageBuilder) WithChannel(channel models.Channel) *CampaignMessageBuilder {
+	b.message.Attributes.Channel = channel
+	b.message.Attributes.Content = b.generator.messageContent(channel)
+	return b
+}
+
+func (b *CampaignMessageBuilder) WithContent(content models.MessageContent) *CampaignMessageBuilder {
+	b.message.Attributes.Content = content
+	return b
+}
+
+func (b *CampaignMessageBuilder) Build() models.CampaignMessage {
+	return b.message
+}
+
+// Returns content of a message on `channel`
+func (g *Generator) messageContent(channel models.Channel) models.MessageContent {
+	if channel == models.ChannelSms {
+		return models.SMSContent{Body: ptr(g.words(8))}
+	}
+
+	return models.EmailContent{
+		Subject:     ptr(g.words(4)),
+		PreviewText: ptr(g.words(6)),
+		FromEmail:   ptr(g.email()),
+		FromLabel:   ptr(g.words(1)),
+	}
+}
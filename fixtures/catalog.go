@@ -0,0 +1,188 @@
+package fixtures
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Builder of a models.CatalogItem and its variants
+type CatalogItemBuilder struct {
+	generator *Generator
+	item      models.CatalogItem
+	variants  []models.CatalogVariant
+}
+
+// Returns builder of a published catalog item with the default generator
+func CatalogItem() *CatalogItemBuilder {
+	return generator().CatalogItem()
+}
+
+// Returns builder of a published catalog item
+func (g *Generator) CatalogItem() *CatalogItemBuilder {
+	externalId := fmt.Sprintf("SKU-%d", g.intBetween(10000, 99999))
+	title := fmt.Sprintf("Product %s", g.words(2))
+	created := g.now()
+
+	b := &CatalogItemBuilder{
+		generator: g,
+		item: models.CatalogItem{
+			Type: "catalog-item",
+			Attributes: models.CatalogItemAttributes{
+				Title:             &title,
+				Description:       ptr(g.words(12)),
+				Price:             ptr(int64(g.intBetween(100, 50000))),
+				Url:               ptr(fmt.Sprintf("https://shop.example.com/products/%s", strings.ToLower(externalId))),
+				ImageFullUrl:      ptr(fmt.Sprintf("https://shop.example.com/images/%s.png", strings.ToLower(externalId))),
+				ImageThumbnailUrl: ptr(fmt.Sprintf("https://shop.example.com/images/%s-thumbnail.png", strings.ToLower(externalId))),
+				Published:         ptr(true),
+				Created:           &created,
+				Updated:           &created,
+			},
+		},
+	}
+	return b.WithExternalID(externalId)
+}
+
+// Sets the external ID and the compound ID of the item
+func (b *CatalogItemBuilder) WithExternalID(externalId string) *CatalogItemBuilder {
+	b.item.ID = models.NewCatalogID(externalId).String()
+	b.item.Attributes.ExternalId = &externalId
+	b.item.Links.Self = selfLink("catalog-item", b.item.ID)
+	return b
+}
+
+func (b *CatalogItemBuilder) WithTitle(title string) *CatalogItemBuilder {
+	b.item.Attributes.Title = &title
+	return b
+}
+
+// Sets the price of the item in cents
+func (b *CatalogItemBuilder) WithPrice(price int64) *CatalogItemBuilder {
+	b.item.Attributes.Price = &price
+	return b
+}
+
+func (b *CatalogItemBuilder) WithPublished(published bool) *CatalogItemBuilder {
+	b.item.Attributes.Published = &published
+	return b
+}
+
+func (b *CatalogItemBuilder) WithCustomMetadata(metadata map[string]any) *CatalogItemBuilder {
+	b.item.Attributes.CustomMetadata = &metadata
+	return b
+}
+
+// Adds `n` variants priced like the item
+func (b *CatalogItemBuilder) WithVariants(n int) *CatalogItemBuilder {
+	externalId := *b.item.Attributes.ExternalId
+	for i := 0; i < n; i++ {
+		variant := b.generator.CatalogVariant().
+			WithExternalID(fmt.Sprintf("%s-%d", externalId, len(b.variants)+1)).
+			WithItem(b.item.ID)
+		if b.item.Attributes.Price != nil {
+			variant.WithPrice(*b.item.Attributes.Price)
+		}
+		b.variants = append(b.variants, variant.Build())
+	}
+	return b
+}
+
+func (b *CatalogItemBuilder) Build() models.CatalogItem {
+	item := b.item
+	if len(b.variants) > 0 {
+		item.Relationship = &models.CatalogItemRelationships{
+			Variant: relationship(item.Type, item.ID, "variants", identifiers(b.variants)),
+		}
+	}
+	return item
+}
+
+// Returns the variants of the item
+func (b *CatalogItemBuilder) Variants() []models.CatalogVariant {
+	return append([]models.CatalogVariant(nil), b.variants...)
+}
+
+// Returns document of the item including its variants
+func (b *CatalogItemBuilder) Document() models.CatalogItemResource {
+	return Document(b.Build(), anys(b.variants)...)
+}
+
+// Builder of a models.CatalogVariant
+type CatalogVariantBuilder struct {
+	generator *Generator
+	variant   models.CatalogVariant
+	itemId    string
+}
+
+// Returns builder of a published catalog variant in stock with the default generator
+func CatalogVariant() *CatalogVariantBuilder {
+	return generator().CatalogVariant()
+}
+
+// Returns builder of a published catalog variant in stock
+func (g *Generator) CatalogVariant() *CatalogVariantBuilder {
+	externalId := fmt.Sprintf("SKU-%d-V", g.intBetween(10000, 99999))
+	created := g.now()
+
+	b := &CatalogVariantBuilder{
+		generator: g,
+		variant: models.CatalogVariant{
+			Type: "catalog-variant",
+			Attributes: models.CatalogVariantAttributes{
+				Title:             ptr(fmt.Sprintf("Variant %s", g.words(2))),
+				Description:       ptr(g.words(12)),
+				InventoryPolicy:   ptr(int64(1)),
+				InventoryQuantity: ptr(int64(g.intBetween(1, 500))),
+				Price:             ptr(int64(g.intBetween(100, 50000))),
+				Url:               ptr(fmt.Sprintf("https://shop.example.com/products/%s", strings.ToLower(externalId))),
+				Published:         ptr(true),
+				Created:           &created,
+				Updated:           &created,
+			},
+		},
+	}
+	return b.WithExternalID(externalId)
+}
+
+// Sets the external ID, the SKU and the compound ID of the variant
+func (b *CatalogVariantBuilder) WithExternalID(externalId string) *CatalogVariantBuilder {
+	b.variant.ID = models.NewCatalogID(externalId).String()
+	b.variant.Attributes.ExternalId = &externalId
+	b.variant.Attributes.Sku = &externalId
+	b.variant.Links.Self = selfLink("catalog-variant", b.variant.ID)
+	return b
+}
+
+func (b *CatalogVariantBuilder) WithSku(sku string) *CatalogVariantBuilder {
+	b.variant.Attributes.Sku = &sku
+	return b
+}
+
+// Sets the price of the variant in cents
+func (b *CatalogVariantBuilder) WithPrice(price int64) *CatalogVariantBuilder {
+	b.variant.Attributes.Price = &price
+	return b
+}
+
+func (b *CatalogVariantBuilder) WithInventoryQuantity(quantity int64) *CatalogVariantBuilder {
+	b.variant.Attributes.InventoryQuantity = &quantity
+	return b
+}
+
+// Links the variant to catalog item `itemId`
+func (b *CatalogVariantBuilder) WithItem(itemId string) *CatalogVariantBuilder {
+	b.itemId = itemId
+	return b
+}
+
+func (b *CatalogVariantBuilder) Build() models.CatalogVariant {
+	variant := b.variant
+	if b.itemId != "" {
+		variant.Relationships = &models.CatalogVariantRelationships{
+			Item: relationship(variant.Type, variant.ID, "item", []models.RelationshipData{{Type: "catalog-item", ID: b.itemId}}),
+		}
+	}
+	return variant
+}
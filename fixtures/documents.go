@@ -0,0 +1,92 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Returns document of `data` including `included`, eg. Document(campaign, messages...).
+// Panics when an included value is not a resource
+func Document[T any](data T, included ...any) models.Document[T] {
+	return models.Document[T]{
+		Data:     data,
+		Links:    models.Links{Self: ptr(selfLinkOf(data))},
+		Included: mustInclude(included),
+	}
+}
+
+// Returns document of the `data` collection including `included`. Panics when an included value is not a resource
+func Collection[T any](data []T, included ...any) models.CollectionDocument[T] {
+	self := ""
+	if len(data) > 0 {
+		identifier := identifierOf(data[0])
+		self = fmt.Sprintf("%s/api/%ss/", BASE_URL, identifier.Type)
+	}
+
+	return models.CollectionDocument[T]{
+		Data:     data,
+		Links:    models.Links{Self: &self},
+		Included: mustInclude(included),
+	}
+}
+
+func mustInclude(resources []any) models.Included {
+	included, err := models.NewIncluded(resources...)
+	if err != nil {
+		panic(fmt.Sprintf("fixtures: %v", err))
+	}
+	return included
+}
+
+// Returns relationship of resource `resourceType` `id` named `name`, nil without linkage
+func relationships(resourceType string, id string, name string, linkage []models.RelationshipData) *models.Relationships {
+	if len(linkage) == 0 {
+		return nil
+	}
+
+	relationship := relationship(resourceType, id, name, linkage)
+	return &relationship
+}
+
+func relationship(resourceType string, id string, name string, linkage []models.RelationshipData) models.Relationships {
+	self := selfLink(resourceType, id)
+	return models.Relationships{
+		Data: linkage,
+		Links: &models.RelationshipLinks{
+			Self:    fmt.Sprintf("%srelationships/%s/", self, name),
+			Related: fmt.Sprintf("%s%s/", self, name),
+		},
+	}
+}
+
+// Returns type and ID of resources
+func identifiers[T any](resources []T) []models.RelationshipData {
+	var linkage []models.RelationshipData
+	for _, resource := range resources {
+		linkage = append(linkage, identifierOf(resource))
+	}
+	return linkage
+}
+
+func identifierOf(resource any) models.RelationshipData {
+	var identifier models.RelationshipData
+	if data, err := json.Marshal(resource); err == nil {
+		_ = json.Unmarshal(data, &identifier)
+	}
+	return identifier
+}
+
+func selfLinkOf(resource any) string {
+	identifier := identifierOf(resource)
+	return selfLink(identifier.Type, identifier.ID)
+}
+
+func anys[T any](resources []T) []any {
+	values := make([]any, 0, len(resources))
+	for _, resource := range resources {
+		values = append(values, resource)
+	}
+	return values
+}
@@ -0,0 +1,72 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type FixturesTestSuite struct {
+	suite.Suite
+}
+
+func (suite *FixturesTestSuite) TestDeterministicUnderSeed() {
+	first := New(42).Campaign().WithMessages(2).Document()
+	second := New(42).Campaign().WithMessages(2).Document()
+	other := New(7).Campaign().WithMessages(2).Document()
+
+	suite.Equal(first, second)
+	suite.NotEqual(first.Data.ID, other.Data.ID)
+
+	Seed(42)
+	suite.Equal(first.Data, Campaign().WithMessages(2).Build())
+}
+
+func (suite *FixturesTestSuite) TestCampaignDocumentRoundTrip() {
+	builder := New(1).Campaign().WithStatus("Sent").WithChannel("sms").WithMessages(2).WithTags(1)
+	document := builder.Document()
+
+	suite.Equal("Sent", document.Data.Attributes.Status)
+	suite.Len(document.Data.Relationships.CampaignMessage.Data, 2)
+
+	data, err := json.Marshal(document)
+	suite.Require().NoError(err)
+
+	var decoded models.CampaignResponse
+	suite.Require().NoError(json.Unmarshal(data, &decoded))
+
+	messages := models.RelatedOf[models.CampaignMessage](decoded.Included, decoded.Data, "campaign-messages")
+	suite.Require().Len(messages, 2)
+	suite.Equal(builder.Messages()[0].ID, messages[0].ID)
+	suite.Equal(models.ChannelSms, messages[0].Attributes.Channel)
+	suite.IsType(models.SMSContent{}, messages[0].Attributes.Content)
+	suite.Len(models.RelatedOf[models.Tag](decoded.Included, decoded.Data, "tags"), 1)
+}
+
+func (suite *FixturesTestSuite) TestFlowAndCatalogRelationships() {
+	g := New(1)
+
+	flow := g.Flow().WithStatus(models.FlowsStatusLive).WithActions(2)
+	actions := flow.Actions()
+	suite.Len(flow.Build().RelationShips.FlowAction.Data, 2)
+	suite.Equal(flow.Build().ID, actions[0].Relationships.Flow.Data[0].ID)
+	suite.Equal(2, flow.Document().Included.Len())
+
+	item := g.CatalogItem().WithExternalID("SHIRT").WithPrice(2500).WithVariants(2)
+	variants := item.Variants()
+	suite.Equal("$custom:::$default:::SHIRT", item.Build().ID)
+	suite.Equal("$custom:::$default:::SHIRT-1", variants[0].ID)
+	suite.Equal(int64(2500), *variants[1].Attributes.Price)
+	suite.Equal(item.Build().ID, variants[0].Relationships.Item.Data[0].ID)
+
+	collection := Collection([]models.Image{g.Image().WithFormat("gif").Build(), g.Image().WithHidden(true).Build()})
+	suite.Len(collection.Data, 2)
+	suite.Equal("gif", collection.Data[0].Attributes.Format)
+	suite.Equal("https://a.klaviyo.com/api/images/", *collection.Links.Self)
+}
+
+func TestFixturesTestSuite(t *testing.T) {
+	suite.Run(t, new(FixturesTestSuite))
+}
@@ -0,0 +1,279 @@
+package fixtures
+
+import (
+	"fmt"
+
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Builder of a models.Flow and its actions
+type FlowBuilder struct {
+	generator *Generator
+	flow      models.Flow
+	actions   []*FlowActionBuilder
+	tags      []models.Tag
+}
+
+// Returns builder of a draft flow with the default generator
+func Flow() *FlowBuilder {
+	return generator().Flow()
+}
+
+// Returns builder of a draft flow
+func (g *Generator) Flow() *FlowBuilder {
+	id := g.id()
+	created := g.now()
+
+	return &FlowBuilder{
+		generator: g,
+		flow: models.Flow{
+			Type: "flow",
+			ID:   id,
+			Attributes: models.FlowAttributes{
+				Name:        ptr(fmt.Sprintf("Flow %s", g.words(2))),
+				Status:      ptr(models.FlowsStatusDraft),
+				Archived:    ptr(false),
+				CreatedAt:   &created,
+				UpdatedAt:   &created,
+				TriggerType: ptr(models.FlowTriggerTypeMetric),
+			},
+			Links: models.DataLinks{Self: selfLink("flow", id)},
+		},
+	}
+}
+
+func (b *FlowBuilder) WithID(id string) *FlowBuilder {
+	b.flow.ID = id
+	b.flow.Links.Self = selfLink("flow", id)
+	return b
+}
+
+func (b *FlowBuilder) WithName(name string) *FlowBuilder {
+	b.flow.Attributes.Name = &name
+	return b
+}
+
+func (b *FlowBuilder) WithStatus(status models.FlowsStatus) *FlowBuilder {
+	b.flow.Attributes.Status = &status
+	return b
+}
+
+func (b *FlowBuilder) WithArchived(archived bool) *FlowBuilder {
+	b.flow.Attributes.Archived = &archived
+	return b
+}
+
+func (b *FlowBuilder) WithTriggerType(triggerType models.FlowTriggerType) *FlowBuilder {
+	b.flow.Attributes.TriggerType = &triggerType
+	return b
+}
+
+// Adds `n` actions sending one message each
+func (b *FlowBuilder) WithActions(n int) *FlowBuilder {
+	for i := 0; i < n; i++ {
+		b.actions = append(b.actions, b.generator.FlowAction().WithFlow(b.flow.ID).WithMessages(1))
+	}
+	return b
+}
+
+// Adds `n` tags
+func (b *FlowBuilder) WithTags(n int) *FlowBuilder {
+	for i := 0; i < n; i++ {
+		b.tags = append(b.tags, b.generator.Tag().Build())
+	}
+	return b
+}
+
+func (b *FlowBuilder) Build() models.Flow {
+	flow := b.flow
+	if len(b.actions) > 0 || len(b.tags) > 0 {
+		flow.RelationShips = &models.FlowRelationShips{
+			FlowAction: relationship(flow.Type, flow.ID, "flow-actions", identifiers(b.Actions())),
+			Tags:       relationship(flow.Type, flow.ID, "tags", identifiers(b.tags)),
+		}
+	}
+	return flow
+}
+
+// Returns the actions of the flow
+func (b *FlowBuilder) Actions() []models.FlowAction {
+	actions := make([]models.FlowAction, 0, len(b.actions))
+	for _, action := range b.actions {
+		actions = append(actions, action.Build())
+	}
+	return actions
+}
+
+// Returns the actions and tags of the flow
+func (b *FlowBuilder) Included() []any {
+	return append(anys(b.Actions()), anys(b.tags)...)
+}
+
+// Returns document of the flow including its actions and tags
+func (b *FlowBuilder) Document() models.FlowResource {
+	return Document(b.Build(), b.Included()...)
+}
+
+// Builder of a models.FlowAction and its messages
+type FlowActionBuilder struct {
+	generator *Generator
+	action    models.FlowAction
+	flowId    string
+	messages  []models.FlowMessage
+}
+
+// Returns builder of a live email action with the default generator
+func FlowAction() *FlowActionBuilder {
+	return generator().FlowAction()
+}
+
+// Returns builder of a live email action
+func (g *Generator) FlowAction() *FlowActionBuilder {
+	id := g.id()
+	created := g.now()
+
+	return &FlowActionBuilder{
+		generator: g,
+		action: models.FlowAction{
+			Type: "flow-action",
+			ID:   id,
+			Attributes: models.FlowActionAttribute{
+				ActionType: ptr("SEND_EMAIL"),
+				Status:     ptr("live"),
+				CreatedAt:  &created,
+				UpdatedAt:  &created,
+			},
+			Links: models.DataLinks{Self: selfLink("flow-action", id)},
+		},
+	}
+}
+
+func (b *FlowActionBuilder) WithID(id string) *FlowActionBuilder {
+	b.action.ID = id
+	b.action.Links.Self = selfLink("flow-action", id)
+	return b
+}
+
+// Sets the action type, eg. SEND_EMAIL, SEND_SMS or TIME_DELAY
+func (b *FlowActionBuilder) WithActionType(actionType string) *FlowActionBuilder {
+	b.action.Attributes.ActionType = &actionType
+	return b
+}
+
+func (b *FlowActionBuilder) WithStatus(status string) *FlowActionBuilder {
+	b.action.Attributes.Status = &status
+	return b
+}
+
+// Links the action to flow `flowId`
+func (b *FlowActionBuilder) WithFlow(flowId string) *FlowActionBuilder {
+	b.flowId = flowId
+	return b
+}
+
+// Adds `n` messages on the channel of the action type
+func (b *FlowActionBuilder) WithMessages(n int) *FlowActionBuilder {
+	channel := models.ChannelEmail
+	if b.action.Attributes.ActionType != nil && *b.action.Attributes.ActionType == "SEND_SMS" {
+		channel = models.ChannelSms
+	}
+
+	for i := 0; i < n; i++ {
+		b.messages = append(b.messages, b.generator.FlowMessage().WithChannel(channel).WithAction(b.action.ID).Build())
+	}
+	return b
+}
+
+func (b *FlowActionBuilder) Build() models.FlowAction {
+	action := b.action
+	if b.flowId != "" || len(b.messages) > 0 {
+		action.Relationships = &models.FlowActionRelationships{
+			FlowMessage: relationship(action.Type, action.ID, "flow-messages", identifiers(b.messages)),
+		}
+		if b.flowId != "" {
+			action.Relationships.Flow = relationship(action.Type, action.ID, "flow", []models.RelationshipData{{Type: "flow", ID: b.flowId}})
+		}
+	}
+	return action
+}
+
+// Returns the messages of the action
+func (b *FlowActionBuilder) Messages() []models.FlowMessage {
+	return append([]models.FlowMessage(nil), b.messages...)
+}
+
+// Returns document of the action including its messages
+func (b *FlowActionBuilder) Document() models.FlowActionResource {
+	return Document(b.Build(), anys(b.messages)...)
+}
+
+// Builder of a models.FlowMessage
+type FlowMessageBuilder struct {
+	generator *Generator
+	message   models.FlowMessage
+}
+
+// Returns builder of an email flow message with the default generator
+func FlowMessage() *FlowMessageBuilder {
+	return generator().FlowMessage()
+}
+
+// Returns builder of an email flow message
+func (g *Generator) FlowMessage() *FlowMessageBuilder {
+	id := g.id()
+	created := g.now()
+
+	b := &FlowMessageBuilder{
+		generator: g,
+		message: models.FlowMessage{
+			Type: "flow-message",
+			ID:   id,
+			Attributes: models.FlowMessageAttributes{
+				Name:      fmt.Sprintf("Message %s", g.words(2)),
+				CreatedAt: &created,
+				UpdatedAt: &created,
+			},
+			Links: models.DataLinks{Self: selfLink("flow-message", id)},
+		},
+	}
+	return b.WithChannel(models.ChannelEmail)
+}
+
+func (b *FlowMessageBuilder) WithID(id string) *FlowMessageBuilder {
+	b.message.ID = id
+	b.message.Links.Self = selfLink("flow-message", id)
+	return b
+}
+
+func (b *FlowMessageBuilder) WithName(name string) *FlowMessageBuilder {
+	b.message.Attributes.Name = name
+	return b
+}
+
+// Sets the channel and content of the message, email or sms
+func (b *FlowMessageBuilder) WithChannel(channel models.Channel) *FlowMessageBuilder {
+	b.message.Attributes.Channel = channel
+	b.message.Attributes.Content = b.generator.messageContent(channel)
+	return b
+}
+
+func (b *FlowMessageBuilder) WithContent(content models.MessageContent) *FlowMessageBuilder {
+	b.message.Attributes.Content = content
+	return b
+}
+
+// Links the message to flow action `actionId`
+func (b *FlowMessageBuilder) WithAction(actionId string) *FlowMessageBuilder {
+	b.message.Relationships.FlowAction = models.RelationshipData{Type: "flow-action", ID: actionId}
+	return b
+}
+
+// Links the message to template `templateId`
+func (b *FlowMessageBuilder) WithTemplate(templateId string) *FlowMessageBuilder {
+	b.message.Relationships.Template = relationship(b.message.Type, b.message.ID, "template", []models.RelationshipData{{Type: "template", ID: templateId}})
+	return b
+}
+
+func (b *FlowMessageBuilder) Build() models.FlowMessage {
+	return b.message
+}
@@ -0,0 +1,165 @@
+package fixtures
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Builder of a models.Image
+type ImageBuilder struct {
+	image models.Image
+}
+
+// Returns builder of a visible png image with the default generator
+func Image() *ImageBuilder {
+	return generator().Image()
+}
+
+// Returns builder of a visible png image
+func (g *Generator) Image() *ImageBuilder {
+	id := g.id()
+
+	b := &ImageBuilder{
+		image: models.Image{
+			Type: "image",
+			ID:   id,
+			Attributes: models.ImageAttributes{
+				Size:      g.intBetween(1<<10, 1<<20),
+				UpdatedAt: g.now(),
+			},
+			Links: models.DataLinks{Self: selfLink("image", id)},
+		},
+	}
+	return b.WithName(strings.ReplaceAll(g.words(2), " ", "-")).WithFormat("png")
+}
+
+func (b *ImageBuilder) WithID(id string) *ImageBuilder {
+	b.image.ID = id
+	b.image.Links.Self = selfLink("image", id)
+	return b.WithFormat(b.image.Attributes.Format)
+}
+
+func (b *ImageBuilder) WithName(name string) *ImageBuilder {
+	b.image.Attributes.Name = name
+	return b
+}
+
+// Sets the format and URL of the image, jpeg, png or gif
+func (b *ImageBuilder) WithFormat(format string) *ImageBuilder {
+	b.image.Attributes.Format = format
+	b.image.Attributes.ImageUrl = fmt.Sprintf("https://d3k81ch9hvuctc.cloudfront.net/company/images/%s.%s", b.image.ID, format)
+	return b
+}
+
+func (b *ImageBuilder) WithSize(size int) *ImageBuilder {
+	b.image.Attributes.Size = size
+	return b
+}
+
+func (b *ImageBuilder) WithHidden(hidden bool) *ImageBuilder {
+	b.image.Attributes.Hidden = hidden
+	return b
+}
+
+func (b *ImageBuilder) Build() models.Image {
+	return b.image
+}
+
+// Builder of a models.Template
+type TemplateBuilder struct {
+	template models.Template
+}
+
+// Returns builder of a code template with the default generator
+func Template() *TemplateBuilder {
+	return generator().Template()
+}
+
+// Returns builder of a code template
+func (g *Generator) Template() *TemplateBuilder {
+	id := g.id()
+	created := g.now()
+	text := g.words(10)
+
+	return &TemplateBuilder{
+		template: models.Template{
+			Type: "template",
+			ID:   id,
+			Attributes: models.TemplateAttributes{
+				Name:       fmt.Sprintf("Template %s", g.words(2)),
+				EditorType: models.EditorTypeCode,
+				HTML:       fmt.Sprintf("<html><body><p>%s</p></body></html>", text),
+				Text:       &text,
+				Created:    &created,
+				Updated:    &created,
+			},
+			Links: models.DataLinks{Self: selfLink("template", id)},
+		},
+	}
+}
+
+func (b *TemplateBuilder) WithID(id string) *TemplateBuilder {
+	b.template.ID = id
+	b.template.Links.Self = selfLink("template", id)
+	return b
+}
+
+func (b *TemplateBuilder) WithName(name string) *TemplateBuilder {
+	b.template.Attributes.Name = name
+	return b
+}
+
+func (b *TemplateBuilder) WithEditorType(editorType models.EditorType) *TemplateBuilder {
+	b.template.Attributes.EditorType = editorType
+	return b
+}
+
+func (b *TemplateBuilder) WithHTML(html string) *TemplateBuilder {
+	b.template.Attributes.HTML = html
+	return b
+}
+
+func (b *TemplateBuilder) Build() models.Template {
+	return b.template
+}
+
+// Builder of a models.Tag
+type TagBuilder struct {
+	tag models.Tag
+}
+
+// Returns builder of a tag with the default generator
+func Tag() *TagBuilder {
+	return generator().Tag()
+}
+
+// Returns builder of a tag
+func (g *Generator) Tag() *TagBuilder {
+	id := g.id()
+
+	return &TagBuilder{
+		tag: models.Tag{
+			Type:       "tag",
+			ID:         id,
+			Attributes: models.TagAttributes{Name: g.words(1)},
+			Links:      models.DataLinks{Self: selfLink("tag", id)},
+		},
+	}
+}
+
+func (b *TagBuilder) WithID(id string) *TagBuilder {
+	b.tag.ID = id
+	b.tag.Links.Self = selfLink("tag", id)
+	return b
+}
+
+func (b *TagBuilder) WithName(name string) *TagBuilder {
+	b.tag.Attributes.Name = name
+	return b
+}
+
+func (b *TagBuilder) Build() models.Tag {
+	return b.tag
+}
@@ -0,0 +1,51 @@
+package instrumentation
+
+import (
+	"context"
+	"net/http"
+)
+
+type (
+	// Observes SDK operations and every HTTP attempt made on their behalf.
+	// An operation is a single API method call, eg. `CampaignsApi.GetCampaigns`, and spans all of its retries.
+	Instrumentation interface {
+		//Called once before the first attempt of `operation`. The returned context is passed to StartAttempt
+		StartOperation(ctx context.Context, operation string, req *http.Request) (context.Context, EndFunc)
+
+		//Called before every HTTP attempt. `attempt` starts at 1
+		StartAttempt(ctx context.Context, req *http.Request, attempt int) (context.Context, EndFunc)
+	}
+
+	// Called with the final response or transport error. `res` is nil when `err` is not
+	EndFunc func(res *http.Response, err error)
+
+	noopInstrumentation struct{}
+)
+
+// Instrumentation that records nothing
+func NewNoopInstrumentation() Instrumentation {
+	return noopInstrumentation{}
+}
+
+func (noopInstrumentation) StartOperation(ctx context.Context, _ string, _ *http.Request) (context.Context, EndFunc) {
+	return ctx, noopEnd
+}
+
+func (noopInstrumentation) StartAttempt(ctx context.Context, _ *http.Request, _ int) (context.Context, EndFunc) {
+	return ctx, noopEnd
+}
+
+func noopEnd(*http.Response, error) {}
+
+type operationContextKey struct{}
+
+// Names the SDK operation `ctx` is used for. API methods call this with `<Interface>.<Method>`
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, operation)
+}
+
+// Returns the operation name stored by WithOperation or "" if none
+func OperationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(operationContextKey{}).(string)
+	return operation
+}
@@ -0,0 +1,179 @@
+package otelinstrumentation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/developertom01/klaviyo-go/instrumentation"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/developertom01/klaviyo-go/instrumentation/otelinstrumentation"
+
+// Attribute holding the SDK operation name, eg. `CampaignsApi.GetCampaigns`
+const OperationKey = attribute.Key("klaviyo.operation")
+
+// Metric names
+const (
+	MetricRequests        = "klaviyo.client.requests"         //Number of SDK operations
+	MetricRequestDuration = "klaviyo.client.request.duration" //Duration of SDK operations including retries, in seconds
+	MetricRetries         = "klaviyo.client.retries"          //Number of HTTP attempts made after the first one
+	MetricRateLimited     = "klaviyo.client.rate_limited"     //Number of HTTP attempts rejected with 429 Too Many Requests
+)
+
+type (
+	Option func(*config)
+
+	config struct {
+		tracerProvider trace.TracerProvider
+		meterProvider  metric.MeterProvider
+	}
+
+	otelInstrumentation struct {
+		tracer trace.Tracer
+
+		requests        metric.Int64Counter
+		requestDuration metric.Float64Histogram
+		retries         metric.Int64Counter
+		rateLimited     metric.Int64Counter
+	}
+)
+
+// Use `provider` instead of the global tracer provider
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// Use `provider` instead of the global meter provider
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// Creates OpenTelemetry instrumentation. Pass it to options.WithInstrumentation
+func New(opts ...Option) (instrumentation.Instrumentation, error) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	requests, err := meter.Int64Counter(MetricRequests, metric.WithDescription("Number of Klaviyo SDK operations"), metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+
+	requestDuration, err := meter.Float64Histogram(MetricRequestDuration, metric.WithDescription("Duration of Klaviyo SDK operations including retries"), metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	retries, err := meter.Int64Counter(MetricRetries, metric.WithDescription("Number of retried HTTP attempts"), metric.WithUnit("{attempt}"))
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimited, err := meter.Int64Counter(MetricRateLimited, metric.WithDescription("Number of HTTP attempts rejected by Klaviyo rate limits"), metric.WithUnit("{attempt}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &otelInstrumentation{
+		tracer:          cfg.tracerProvider.Tracer(instrumentationName),
+		requests:        requests,
+		requestDuration: requestDuration,
+		retries:         retries,
+		rateLimited:     rateLimited,
+	}, nil
+}
+
+func (instr *otelInstrumentation) StartOperation(ctx context.Context, operation string, req *http.Request) (context.Context, instrumentation.EndFunc) {
+	if operation == "" {
+		operation = fmt.Sprintf("Klaviyo %s", req.Method)
+	}
+
+	attrs := []attribute.KeyValue{
+		OperationKey.String(operation),
+		semconv.HTTPRequestMethodKey.String(req.Method),
+	}
+
+	start := time.Now()
+	ctx, span := instr.tracer.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(attrs...))
+
+	return ctx, func(res *http.Response, err error) {
+		defer span.End()
+
+		resAttrs := responseAttributes(res, err)
+		span.SetAttributes(resAttrs...)
+		setSpanStatus(span, res, err)
+
+		metricAttrs := metric.WithAttributes(append(attrs, resAttrs...)...)
+		instr.requests.Add(ctx, 1, metricAttrs)
+		instr.requestDuration.Record(ctx, time.Since(start).Seconds(), metricAttrs)
+	}
+}
+
+func (instr *otelInstrumentation) StartAttempt(ctx context.Context, req *http.Request, attempt int) (context.Context, instrumentation.EndFunc) {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(req.URL.String()),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	if attempt > 1 {
+		attrs = append(attrs, semconv.HTTPRequestResendCount(attempt-1))
+		instr.retries.Add(ctx, 1, metric.WithAttributes(OperationKey.String(instrumentation.OperationFromContext(ctx))))
+	}
+
+	ctx, span := instr.tracer.Start(ctx, req.Method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+
+	return ctx, func(res *http.Response, err error) {
+		defer span.End()
+
+		span.SetAttributes(responseAttributes(res, err)...)
+		setSpanStatus(span, res, err)
+
+		if res != nil && res.StatusCode == http.StatusTooManyRequests {
+			instr.rateLimited.Add(ctx, 1, metric.WithAttributes(OperationKey.String(instrumentation.OperationFromContext(ctx))))
+		}
+	}
+}
+
+func responseAttributes(res *http.Response, err error) []attribute.KeyValue {
+	if err != nil {
+		return []attribute.KeyValue{semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err))}
+	}
+
+	attrs := []attribute.KeyValue{semconv.HTTPResponseStatusCode(res.StatusCode)}
+	if res.StatusCode >= http.StatusBadRequest {
+		attrs = append(attrs, semconv.ErrorTypeKey.String(strconv.Itoa(res.StatusCode)))
+	}
+
+	return attrs
+}
+
+func setSpanStatus(span trace.Span, res *http.Response, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+	}
+}
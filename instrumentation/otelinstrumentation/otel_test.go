@@ -0,0 +1,125 @@
+package otelinstrumentation
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	campaigns "github.com/developertom01/klaviyo-go/api/campaignsApi"
+	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/options"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type OtelInstrumentationTestSuite struct {
+	suite.Suite
+	api          campaigns.CampaignsApi
+	mockedClient *common.MockHTTPClient
+	spans        *tracetest.InMemoryExporter
+	metrics      *sdkmetric.ManualReader
+}
+
+func (suit *OtelInstrumentationTestSuite) SetupTest() {
+	suit.spans = tracetest.NewInMemoryExporter()
+	suit.metrics = sdkmetric.NewManualReader()
+
+	instr, err := New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(suit.spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(suit.metrics))),
+	)
+	if err != nil {
+		suit.T().Fatal(err)
+	}
+
+	opt := options.NewOptionsWithDefaultValues().WithApiKey("test-key").WithInstrumentation(instr)
+	session := common.NewApiKeySession(opt, &common.RetryOptions{MaxRetries: 3, Interval: time.Millisecond})
+	suit.mockedClient = common.NewMockHTTPClient()
+	suit.api = campaigns.NewCampaignsApi(session, suit.mockedClient)
+}
+
+func mockedResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Status:     http.StatusText(statusCode),
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func (suit *OtelInstrumentationTestSuite) TestOperationSpanWrapsAttemptSpans() {
+	suit.mockedClient.On("Do", mock.Anything).Return(mockedResponse(http.StatusServiceUnavailable, `{"errors":[]}`), nil).Once()
+	suit.mockedClient.On("Do", mock.Anything).Return(mockedResponse(http.StatusOK, `{"data":[]}`), nil).Once()
+
+	_, err := suit.api.GetCampaigns(context.Background(), `equals(messages.channel,"email")`, nil)
+	suit.Nil(err)
+
+	spans := suit.spans.GetSpans()
+	suit.Len(spans, 3)
+
+	operation := spans[len(spans)-1]
+	suit.Equal("CampaignsApi.GetCampaigns", operation.Name)
+	suit.Equal(trace.SpanKindInternal, operation.SpanKind)
+
+	for _, attempt := range spans[:2] {
+		suit.Equal(http.MethodGet, attempt.Name)
+		suit.Equal(trace.SpanKindClient, attempt.SpanKind)
+		suit.Equal(operation.SpanContext.SpanID(), attempt.Parent.SpanID())
+	}
+
+	var statusCodes []int64
+	for _, attempt := range spans[:2] {
+		for _, attr := range attempt.Attributes {
+			if attr.Key == "http.response.status_code" {
+				statusCodes = append(statusCodes, attr.Value.AsInt64())
+			}
+		}
+	}
+	suit.Equal([]int64{http.StatusServiceUnavailable, http.StatusOK}, statusCodes)
+}
+
+func (suit *OtelInstrumentationTestSuite) TestRecordsMetrics() {
+	suit.mockedClient.On("Do", mock.Anything).Return(mockedResponse(http.StatusTooManyRequests, `{"errors":[]}`), nil).Once()
+
+	_, err := suit.api.GetCampaigns(context.Background(), `equals(messages.channel,"email")`, nil)
+	suit.NotNil(err)
+
+	var collected metricdata.ResourceMetrics
+	if err := suit.metrics.Collect(context.Background(), &collected); err != nil {
+		suit.T().Fatal(err)
+	}
+
+	sums := make(map[string]int64)
+	durations := make(map[string]uint64)
+	for _, scope := range collected.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, point := range data.DataPoints {
+					sums[m.Name] += point.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, point := range data.DataPoints {
+					durations[m.Name] += point.Count
+				}
+			}
+		}
+	}
+
+	suit.Equal(int64(1), sums[MetricRequests])
+	suit.Equal(int64(1), sums[MetricRateLimited])
+	suit.Equal(int64(0), sums[MetricRetries])
+	suit.Equal(uint64(1), durations[MetricRequestDuration])
+}
+
+func TestOtelInstrumentationTestSuite(t *testing.T) {
+	suite.Run(t, new(OtelInstrumentationTestSuite))
+}
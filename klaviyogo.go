@@ -21,11 +21,20 @@ type KlaviyoApi struct {
 func NewKlaviyoApi(options options.Options, retryOption *common.RetryOptions) *KlaviyoApi {
 	session := common.NewApiKeySession(options, retryOption)
 
+	return NewKlaviyoApiWithSession(session)
+}
+
+// Creates KlaviyoApi authorized by `session`, eg. a common.OAuthSession
+func NewKlaviyoApiWithSession(session common.Session) *KlaviyoApi {
+	return newKlaviyoApi(session, nil)
+}
+
+func newKlaviyoApi(session common.Session, httpClient common.HTTPClient) *KlaviyoApi {
 	return &KlaviyoApi{
-		Accounts:  accounts.NewAccountsApi(session, nil),
-		Campaigns: campaigns.NewCampaignsApi(session, nil),
-		Flows:     flows.NewFlowsApi(session, nil),
-		Images:    images.NewImagesApi(session, nil),
-		Catalog:   catalog.NewCatalogApi(session, nil),
+		Accounts:  accounts.NewAccountsApi(session, httpClient),
+		Campaigns: campaigns.NewCampaignsApi(session, httpClient),
+		Flows:     flows.NewFlowsApi(session, httpClient),
+		Images:    images.NewImagesApi(session, httpClient),
+		Catalog:   catalog.NewCatalogApi(session, httpClient),
 	}
 }
@@ -0,0 +1,174 @@
+package klaviyotest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Channels a campaign message can be sent on
+var channels = []string{"email", "sms"}
+
+// Send strategy methods a campaign can be sent with
+var sendStrategyMethods = []string{"static", "throttled", "immediate", "smart_send_time"}
+
+// Creates a draft campaign along with the messages in its `campaign-messages` attribute
+func (s *Server) createCampaign(w http.ResponseWriter, r *http.Request) {
+	object, ok := s.decodeObject(w, r)
+	if !ok {
+		return
+	}
+
+	var messages []any
+	if attributes, ok := member(object, "attributes").(map[string]any); ok {
+		if wrapper, ok := attributes["campaign-messages"].(map[string]any); ok {
+			messages, _ = wrapper["data"].([]any)
+		}
+	}
+
+	campaign, ok := s.resourceOf(w, object, "campaign")
+	if !ok {
+		return
+	}
+
+	if name, _ := campaign.Attributes["name"].(string); name == "" {
+		writeValidationError(w, "The campaign name is required.", "/data/attributes/name")
+		return
+	}
+	if included, _ := lookup(campaign.Attributes, []string{"audiences", "included"}); isEmpty(included) {
+		writeValidationError(w, "At least one audience must be included.", "/data/attributes/audiences/included")
+		return
+	}
+	if method, ok := lookup(campaign.Attributes, []string{"send_strategy", "method"}); ok && !slices.Contains(sendStrategyMethods, fmt.Sprint(method)) {
+		writeValidationError(w, fmt.Sprintf("%v is not a valid send strategy method.", method), "/data/attributes/send_strategy/method")
+		return
+	}
+	if len(messages) == 0 {
+		writeValidationError(w, "At least one campaign message is required.", "/data/attributes/campaign-messages/data")
+		return
+	}
+
+	messageResources := make([]*resource, 0, len(messages))
+	for i, message := range messages {
+		object, _ := message.(map[string]any)
+		messageRes, err := decodeResource(object)
+		if err != nil {
+			writeValidationError(w, err.Error(), fmt.Sprintf("/data/attributes/campaign-messages/data/%d", i))
+			return
+		}
+		if channel, _ := messageRes.Attributes["channel"].(string); !slices.Contains(channels, channel) {
+			writeValidationError(w, fmt.Sprintf("%q is not a valid channel, use one of %v.", channel, channels), fmt.Sprintf("/data/attributes/campaign-messages/data/%d/attributes/channel", i))
+			return
+		}
+
+		messageRes.Type = "campaign-message"
+		messageRes.Relationships = map[string][]models.RelationshipData{}
+		if _, ok := messageRes.Attributes["label"]; !ok {
+			messageRes.Attributes["label"] = campaign.Attributes["name"]
+		}
+		messageRes.Attributes["send_times"] = []any{}
+		messageResources = append(messageResources, messageRes)
+	}
+
+	campaign.ID = s.newID()
+	campaign.Attributes["status"] = "Draft"
+	campaign.Attributes["archived"] = false
+	setDefault(campaign.Attributes, "send_strategy", map[string]any{"method": "immediate"})
+	setDefault(campaign.Attributes, "send_options", map[string]any{"use_smart_sending": true})
+	setDefault(campaign.Attributes, "scheduled_at", nil)
+	setDefault(campaign.Attributes, "send_time", nil)
+	s.stamp(campaign, true)
+	s.put(campaign)
+
+	for _, messageRes := range messageResources {
+		messageRes.ID = s.newID()
+		messageRes.Relationships["campaign"] = []models.RelationshipData{campaign.identifier()}
+		s.stamp(messageRes, true)
+		s.put(messageRes)
+	}
+
+	s.writeResource(w, r, http.StatusCreated, campaign)
+}
+
+// Copies a campaign and its messages as a draft named after the `new_name` attribute
+func (s *Server) cloneCampaign(w http.ResponseWriter, r *http.Request) {
+	request, ok := s.decodeBody(w, r, "campaign")
+	if !ok {
+		return
+	}
+
+	source := s.get(models.RelationshipData{Type: "campaign", ID: request.ID})
+	if source == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Not found.", fmt.Sprintf("Campaign %s was not found.", request.ID), pointer("/data/id"))
+		return
+	}
+
+	campaign := source.clone()
+	campaign.ID = s.newID()
+	campaign.Relationships = map[string][]models.RelationshipData{"tags": campaign.Relationships["tags"]}
+	if name, _ := request.Attributes["new_name"].(string); name != "" {
+		campaign.Attributes["name"] = name
+	} else {
+		campaign.Attributes["name"] = fmt.Sprintf("%v (Clone)", source.Attributes["name"])
+	}
+	campaign.Attributes["status"] = "Draft"
+	campaign.Attributes["send_time"] = nil
+	delete(campaign.Attributes, schemas["campaign"].created)
+	s.stamp(campaign, true)
+	s.put(campaign)
+
+	for _, message := range s.resolve(source.Relationships["campaign-messages"]) {
+		copied := message.clone()
+		copied.ID = s.newID()
+		copied.Relationships = map[string][]models.RelationshipData{
+			"campaign": {campaign.identifier()},
+			"template": copied.Relationships["template"],
+		}
+		delete(copied.Attributes, schemas["campaign-message"].created)
+		s.stamp(copied, true)
+		s.put(copied)
+	}
+
+	s.writeResource(w, r, http.StatusCreated, campaign)
+}
+
+// Assigns the template in the `template` relationship to the campaign message in the body
+func (s *Server) assignTemplate(w http.ResponseWriter, r *http.Request) {
+	request, ok := s.decodeBody(w, r, "campaign-message")
+	if !ok {
+		return
+	}
+
+	message := s.get(request.identifier())
+	if message == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Not found.", fmt.Sprintf("Campaign message %s was not found.", request.ID), pointer("/data/id"))
+		return
+	}
+
+	linkage := request.Relationships["template"]
+	if len(linkage) == 0 {
+		writeValidationError(w, "A template is required.", "/data/relationships/template/data")
+		return
+	}
+	if s.get(linkage[0]) == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Not found.", fmt.Sprintf("Template %s was not found.", linkage[0].ID), pointer("/data/relationships/template/data/id"))
+		return
+	}
+
+	s.setLinkage(message, "template", linkage)
+	s.stamp(message, false)
+	s.writeResource(w, r, http.StatusOK, message)
+}
+
+func setDefault(attributes map[string]any, key string, value any) {
+	if _, ok := attributes[key]; !ok {
+		attributes[key] = value
+	}
+}
+
+func isEmpty(value any) bool {
+	items, ok := value.([]any)
+	return !ok || len(items) == 0
+}
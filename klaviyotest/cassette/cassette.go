@@ -0,0 +1,124 @@
+package cassette
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version of the cassette format written by this package
+const CASSETTE_VERSION = 1
+
+// Encoding of bodies that are not valid UTF-8
+const BODY_ENCODING_BASE64 = "base64"
+
+type (
+	// Request/response pairs recorded from an API. Stored as YAML when the file extension is .yaml or .yml, as JSON otherwise
+	Cassette struct {
+		Version      int           `json:"version" yaml:"version"`
+		Interactions []Interaction `json:"interactions" yaml:"interactions"`
+	}
+
+	// Recorded request and the response it received
+	Interaction struct {
+		Request  RecordedRequest  `json:"request" yaml:"request"`
+		Response RecordedResponse `json:"response" yaml:"response"`
+	}
+
+	RecordedRequest struct {
+		Method       string              `json:"method" yaml:"method"`
+		URL          string              `json:"url" yaml:"url"`
+		Header       map[string][]string `json:"header,omitempty" yaml:"header,omitempty"`
+		Body         string              `json:"body,omitempty" yaml:"body,omitempty"`
+		BodyEncoding string              `json:"body_encoding,omitempty" yaml:"body_encoding,omitempty"` //BODY_ENCODING_BASE64 or empty for UTF-8 text
+	}
+
+	RecordedResponse struct {
+		Status       int                 `json:"status" yaml:"status"`
+		Header       map[string][]string `json:"header,omitempty" yaml:"header,omitempty"`
+		Body         string              `json:"body,omitempty" yaml:"body,omitempty"`
+		BodyEncoding string              `json:"body_encoding,omitempty" yaml:"body_encoding,omitempty"` //BODY_ENCODING_BASE64 or empty for UTF-8 text
+	}
+)
+
+// Reads the cassette stored at `path`
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cassette Cassette
+	if isYAML(path) {
+		err = yaml.Unmarshal(data, &cassette)
+	} else {
+		err = json.Unmarshal(data, &cassette)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cassette %s: %w", path, err)
+	}
+
+	return &cassette, nil
+}
+
+// Writes the cassette to `path`, creating its directory
+func (c *Cassette) Save(path string) error {
+	c.Version = CASSETTE_VERSION
+
+	var (
+		data []byte
+		err  error
+	)
+	if isYAML(path) {
+		data, err = yaml.Marshal(c)
+	} else {
+		data, err = json.MarshalIndent(c, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Returns the decoded request body
+func (r RecordedRequest) Bytes() ([]byte, error) {
+	return decodeBody(r.Body, r.BodyEncoding)
+}
+
+// Returns the decoded response body
+func (r RecordedResponse) Bytes() ([]byte, error) {
+	return decodeBody(r.Body, r.BodyEncoding)
+}
+
+func isYAML(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// Returns `body` as text, base64 encoded when it is not valid UTF-8
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), BODY_ENCODING_BASE64
+}
+
+func decodeBody(body string, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case BODY_ENCODING_BASE64:
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return nil, fmt.Errorf("unknown body encoding %q", encoding)
+}
@@ -0,0 +1,135 @@
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	klaviyo "github.com/developertom01/klaviyo-go"
+	images "github.com/developertom01/klaviyo-go/api/imagesApi"
+	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/klaviyotest"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/developertom01/klaviyo-go/options"
+	"github.com/stretchr/testify/suite"
+)
+
+type CassetteTestSuite struct {
+	suite.Suite
+	server *klaviyotest.Server
+	dir    string
+}
+
+func (suite *CassetteTestSuite) SetupTest() {
+	suite.server = klaviyotest.NewServer()
+	suite.dir = suite.T().TempDir()
+}
+
+func (suite *CassetteTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *CassetteTestSuite) client(recorder *Recorder) *klaviyo.KlaviyoApi {
+	opt := options.NewOptionsWithDefaultValues().
+		WithBaseUrl(suite.server.URL).
+		WithApiKey(klaviyotest.API_KEY).
+		WithHTTPClient(recorder)
+
+	return klaviyo.NewKlaviyoApi(opt, &common.RetryOptions{MaxRetries: 1})
+}
+
+func (suite *CassetteTestSuite) TestRecordAndReplay() {
+	for _, name := range []string{"images.yaml", "images.json"} {
+		suite.Run(name, func() {
+			path := filepath.Join(suite.dir, name)
+			suite.server.Images().Put(models.Image{Type: "image", Attributes: models.ImageAttributes{Name: "logo", Format: "png"}})
+
+			recorder, err := New(path, ModeRecord)
+			suite.Require().NoError(err)
+			recorded, err := suite.client(recorder).Images.GetImages(context.Background(), "", nil)
+			suite.Require().NoError(err)
+			suite.Require().NoError(recorder.Save())
+
+			data, err := os.ReadFile(path)
+			suite.Require().NoError(err)
+			suite.NotContains(string(data), klaviyotest.API_KEY)
+			suite.Contains(string(data), SCRUBBED)
+
+			suite.server.Close()
+
+			replayer, err := New(path, ModeReplay)
+			suite.Require().NoError(err)
+			replayed, err := suite.client(replayer).Images.GetImages(context.Background(), "", nil)
+			suite.Require().NoError(err)
+			suite.Equal(recorded.Data, replayed.Data)
+			suite.Empty(replayer.Unused())
+
+			_, err = suite.client(replayer).Images.GetImages(context.Background(), "", nil)
+			var unmatched *UnmatchedRequestError
+			suite.ErrorAs(err, &unmatched)
+			suite.Equal(http.MethodGet, unmatched.Method)
+
+			suite.server = klaviyotest.NewServer()
+		})
+	}
+}
+
+func (suite *CassetteTestSuite) TestReplayMultipartUpload() {
+	var content bytes.Buffer
+	suite.Require().NoError(png.Encode(&content, image.NewGray(image.Rect(0, 0, 1, 1))))
+	name := "logo"
+	path := filepath.Join(suite.dir, "upload.json")
+
+	recorder, err := New(path, ModeRecordMissing)
+	suite.Require().NoError(err)
+	_, err = suite.client(recorder).Images.UploadImageFromFile(context.Background(), bytes.NewReader(content.Bytes()), images.UploadImageFromFilePayload{Name: &name})
+	suite.Require().NoError(err)
+	suite.Require().NoError(recorder.Save())
+
+	replayer, err := New(path, ModeReplay)
+	suite.Require().NoError(err)
+	uploaded, err := suite.client(replayer).Images.UploadImageFromFile(context.Background(), bytes.NewReader(content.Bytes()), images.UploadImageFromFilePayload{Name: &name})
+	suite.Require().NoError(err)
+	suite.Equal("png", uploaded.Data.Attributes.Format)
+	suite.Len(suite.server.Requests(), 1)
+}
+
+func (suite *CassetteTestSuite) TestScrubFields() {
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Header: map[string][]string{"authorization": {"Klaviyo-API-Key pk_secret"}},
+			Body:   `{"data":{"attributes":{"email":"jane@example.com","properties":[{"phone_number":"+15005550006"}],"title":null}}}`,
+		},
+		Response: RecordedResponse{Body: "not json"},
+	}
+
+	ScrubFields(DEFAULT_SCRUBBED_HEADERS, DEFAULT_SCRUBBED_FIELDS)(&interaction)
+
+	suite.Equal([]string{SCRUBBED}, interaction.Request.Header["authorization"])
+	suite.NotContains(interaction.Request.Body, "jane@example.com")
+	suite.NotContains(interaction.Request.Body, "+15005550006")
+	suite.Equal("not json", interaction.Response.Body)
+}
+
+func (suite *CassetteTestSuite) TestMatchers() {
+	recorded := RecordedRequest{Method: "GET", URL: "https://a.klaviyo.com/api/images/?page[size]=2&filter=x", Body: `{"a":1,"b":2}`}
+	req := RecordedRequest{Method: "get", URL: "http://127.0.0.1:1234/api/images?filter=x&page[size]=2", Body: `{"b":2, "a":1}`}
+
+	for _, matcher := range DEFAULT_MATCHERS {
+		suite.True(matcher(req, recorded))
+	}
+
+	req.URL = strings.Replace(req.URL, "page[size]=2", "page[size]=3", 1)
+	suite.False(MatchQuery(req, recorded))
+	suite.True(MatchQueryKeys("filter")(req, recorded))
+}
+
+func TestCassetteTestSuite(t *testing.T) {
+	suite.Run(t, new(CassetteTestSuite))
+}
@@ -0,0 +1,101 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Decides whether a request, scrubbed like recorded ones, matches a recorded request
+type Matcher func(req RecordedRequest, recorded RecordedRequest) bool
+
+// Matchers used unless the recorder is given others
+var DEFAULT_MATCHERS = []Matcher{MatchMethod, MatchPath, MatchQuery, MatchBody}
+
+func MatchMethod(req RecordedRequest, recorded RecordedRequest) bool {
+	return strings.EqualFold(req.Method, recorded.Method)
+}
+
+// Matches the URL path, ignoring scheme and host so recordings of a sandbox replay against any base URL
+func MatchPath(req RecordedRequest, recorded RecordedRequest) bool {
+	reqURL, recordedURL, ok := parseURLs(req, recorded)
+	return ok && strings.TrimSuffix(reqURL.Path, "/") == strings.TrimSuffix(recordedURL.Path, "/")
+}
+
+// Matches query parameters regardless of their order
+func MatchQuery(req RecordedRequest, recorded RecordedRequest) bool {
+	reqURL, recordedURL, ok := parseURLs(req, recorded)
+	return ok && reflect.DeepEqual(normalizeQuery(reqURL.Query()), normalizeQuery(recordedURL.Query()))
+}
+
+// Matches JSON bodies by value, other bodies byte for byte
+func MatchBody(req RecordedRequest, recorded RecordedRequest) bool {
+	reqBody, err := req.Bytes()
+	if err != nil {
+		return false
+	}
+	recordedBody, err := recorded.Bytes()
+	if err != nil {
+		return false
+	}
+
+	var reqValue, recordedValue any
+	if json.Unmarshal(reqBody, &reqValue) == nil && json.Unmarshal(recordedBody, &recordedValue) == nil {
+		return reflect.DeepEqual(reqValue, recordedValue)
+	}
+	return bytes.Equal(reqBody, recordedBody)
+}
+
+// Returns matcher of the `keys` query parameters only, eg. MatchQueryKeys("filter") to ignore page cursors
+func MatchQueryKeys(keys ...string) Matcher {
+	return func(req RecordedRequest, recorded RecordedRequest) bool {
+		reqURL, recordedURL, ok := parseURLs(req, recorded)
+		if !ok {
+			return false
+		}
+
+		for _, key := range keys {
+			if !reflect.DeepEqual(reqURL.Query()[key], recordedURL.Query()[key]) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Returns matcher of the `name` header
+func MatchHeader(name string) Matcher {
+	return func(req RecordedRequest, recorded RecordedRequest) bool {
+		return reflect.DeepEqual(headerValues(req.Header, name), headerValues(recorded.Header, name))
+	}
+}
+
+func parseURLs(req RecordedRequest, recorded RecordedRequest) (*url.URL, *url.URL, bool) {
+	reqURL, err := url.Parse(req.URL)
+	if err != nil {
+		return nil, nil, false
+	}
+	recordedURL, err := url.Parse(recorded.URL)
+	if err != nil {
+		return nil, nil, false
+	}
+	return reqURL, recordedURL, true
+}
+
+func normalizeQuery(query url.Values) url.Values {
+	if len(query) == 0 {
+		return nil
+	}
+	return query
+}
+
+func headerValues(header map[string][]string, name string) []string {
+	for key, values := range header {
+		if strings.EqualFold(key, name) {
+			return values
+		}
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/developertom01/klaviyo-go/common"
+)
+
+// Environment variable selecting the mode of recorders created by Start, eg. KLAVIYO_CASSETTE_MODE=record
+const MODE_ENV = "KLAVIYO_CASSETTE_MODE"
+
+type Mode string
+
+const (
+	ModeReplay        Mode = "replay"         //Serves recorded responses and fails requests without a match
+	ModeRecord        Mode = "record"         //Sends every request and overwrites the cassette
+	ModeRecordMissing Mode = "record_missing" //Serves recorded responses and records requests without a match
+)
+
+// Error returned in replay mode for a request matching no recorded interaction
+type UnmatchedRequestError struct {
+	Method   string
+	URL      string
+	Cassette string
+}
+
+func (e *UnmatchedRequestError) Error() string {
+	return fmt.Sprintf("cassette %s: no recorded interaction matches %s %s, record it with %s=%s", e.Cassette, e.Method, e.URL, MODE_ENV, ModeRecordMissing)
+}
+
+// HTTPClient recording request/response pairs to a cassette or replaying them from it.
+// Each recorded interaction is replayed once, in the order it was recorded
+type Recorder struct {
+	path        string
+	mode        Mode
+	client      common.HTTPClient
+	matchers    []Matcher
+	headers     []string
+	fields      []string
+	scrubbers   []Scrubber
+	onUnmatched func(err error)
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+	changed  bool
+}
+
+// Returns recorder of the cassette at `path`. The cassette must exist in replay mode
+func New(path string, mode Mode) (*Recorder, error) {
+	recorder := &Recorder{
+		path:     path,
+		mode:     mode,
+		client:   http.DefaultClient,
+		matchers: DEFAULT_MATCHERS,
+		headers:  DEFAULT_SCRUBBED_HEADERS,
+		fields:   DEFAULT_SCRUBBED_FIELDS,
+		cassette: &Cassette{Version: CASSETTE_VERSION},
+	}
+
+	switch mode {
+	case ModeRecord:
+		return recorder, nil
+	case ModeReplay, ModeRecordMissing:
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q", mode)
+	}
+
+	cassette, err := Load(path)
+	if err != nil {
+		if mode == ModeRecordMissing && errors.Is(err, os.ErrNotExist) {
+			return recorder, nil
+		}
+		return nil, err
+	}
+	recorder.cassette = cassette
+	recorder.used = make([]bool, len(cassette.Interactions))
+
+	return recorder, nil
+}
+
+// Returns recorder of the cassette at `path` in the mode set by MODE_ENV, replay by default.
+// Unmatched requests fail the test and the cassette is saved when the test ends
+func Start(t testing.TB, path string) *Recorder {
+	t.Helper()
+
+	mode := Mode(os.Getenv(MODE_ENV))
+	if mode == "" {
+		mode = ModeReplay
+	}
+
+	recorder, err := New(path, mode)
+	if err != nil {
+		t.Fatalf("cassette: %v", err)
+	}
+	recorder.onUnmatched = func(err error) { t.Error(err) }
+
+	t.Cleanup(func() {
+		if err := recorder.Save(); err != nil {
+			t.Errorf("cassette: %v", err)
+		}
+	})
+
+	return recorder
+}
+
+// Sets the client sending requests while recording. Defaults to http.DefaultClient
+func (r *Recorder) WithClient(client common.HTTPClient) *Recorder {
+	r.client = client
+	return r
+}
+
+// Sets the matchers a request must satisfy to replay an interaction. Defaults to DEFAULT_MATCHERS
+func (r *Recorder) WithMatchers(matchers ...Matcher) *Recorder {
+	r.matchers = matchers
+	return r
+}
+
+// Sets the scrubbed headers. Defaults to DEFAULT_SCRUBBED_HEADERS
+func (r *Recorder) WithScrubbedHeaders(headers ...string) *Recorder {
+	r.headers = headers
+	return r
+}
+
+// Sets the scrubbed JSON fields. Defaults to DEFAULT_SCRUBBED_FIELDS
+func (r *Recorder) WithScrubbedFields(fields ...string) *Recorder {
+	r.fields = fields
+	return r
+}
+
+// Adds a scrubber run after headers and fields are scrubbed
+func (r *Recorder) WithScrubber(scrubber Scrubber) *Recorder {
+	r.scrubbers = append(r.scrubbers, scrubber)
+	return r
+}
+
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{Request: recordRequest(req, body)}
+	r.scrub(&interaction)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode != ModeRecord {
+		if recorded, ok := r.match(interaction.Request); ok {
+			return replay(req, recorded.Response)
+		}
+	}
+
+	if r.mode == ModeReplay {
+		err := &UnmatchedRequestError{Method: req.Method, URL: req.URL.String(), Cassette: r.path}
+		if r.onUnmatched != nil {
+			r.onUnmatched(err)
+		}
+		return nil, err
+	}
+
+	return r.record(req, interaction)
+}
+
+// Writes the cassette when interactions were recorded
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.changed {
+		return nil
+	}
+	if err := r.cassette.Save(r.path); err != nil {
+		return err
+	}
+	r.changed = false
+
+	return nil
+}
+
+// Returns recorded interactions that were not replayed
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+func (r *Recorder) scrub(interaction *Interaction) {
+	ScrubFields(r.headers, r.fields)(interaction)
+	for _, scrubber := range r.scrubbers {
+		scrubber(interaction)
+	}
+}
+
+// Returns the first unused interaction matching `req` and marks it used
+func (r *Recorder) match(req RecordedRequest) (Interaction, bool) {
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matches(req, interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+		return interaction, true
+	}
+	return Interaction{}, false
+}
+
+func (r *Recorder) matches(req RecordedRequest, recorded RecordedRequest) bool {
+	for _, matcher := range r.matchers {
+		if !matcher(req, recorded) {
+			return false
+		}
+	}
+	return true
+}
+
+// Sends `req` and stores the scrubbed interaction. The response is returned unscrubbed
+func (r *Recorder) record(req *http.Request, interaction Interaction) (*http.Response, error) {
+	res, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	response := RecordedResponse{Status: res.StatusCode, Header: res.Header.Clone()}
+	response.Body, response.BodyEncoding = encodeBody(body)
+
+	recorded := Interaction{Request: interaction.Request, Response: response}
+	r.scrubResponse(&recorded)
+
+	r.cassette.Interactions = append(r.cassette.Interactions, recorded)
+	r.used = append(r.used, true)
+	r.changed = true
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return res, nil
+}
+
+// Scrubs the response of an interaction whose request is already scrubbed
+func (r *Recorder) scrubResponse(interaction *Interaction) {
+	request := interaction.Request
+	r.scrub(interaction)
+	interaction.Request = request
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+func recordRequest(req *http.Request, body []byte) RecordedRequest {
+	recorded := RecordedRequest{
+		Method: strings.ToUpper(req.Method),
+		URL:    req.URL.String(),
+		Header: req.Header.Clone(),
+	}
+	recorded.Body, recorded.BodyEncoding = encodeBody(body)
+	normalizeMultipart(&recorded)
+
+	return recorded
+}
+
+func replay(req *http.Request, recorded RecordedResponse) (*http.Response, error) {
+	body, err := recorded.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header(recorded.Header).Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
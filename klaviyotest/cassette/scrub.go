@@ -0,0 +1,118 @@
+package cassette
+
+import (
+	"encoding/json"
+	"mime"
+	"strings"
+)
+
+// Value replacing scrubbed headers and fields
+const SCRUBBED = "[SCRUBBED]"
+
+// Boundary replacing the random boundary of multipart requests so that they match when replayed
+const MULTIPART_BOUNDARY = "cassette-boundary"
+
+// Headers scrubbed unless the recorder is given others
+var DEFAULT_SCRUBBED_HEADERS = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// JSON fields holding personal data, scrubbed at any depth of request and response bodies unless the recorder is given others
+var DEFAULT_SCRUBBED_FIELDS = []string{"email", "phone_number", "first_name", "last_name", "address1", "address2"}
+
+// Changes an interaction before it is stored or matched, eg. to scrub a custom field
+type Scrubber func(interaction *Interaction)
+
+// Returns scrubber replacing `headers` and JSON body `fields` with SCRUBBED
+func ScrubFields(headers []string, fields []string) Scrubber {
+	return func(interaction *Interaction) {
+		scrubHeader(interaction.Request.Header, headers)
+		scrubHeader(interaction.Response.Header, headers)
+		interaction.Request.Body = scrubBody(interaction.Request.Body, interaction.Request.BodyEncoding, fields)
+		interaction.Response.Body = scrubBody(interaction.Response.Body, interaction.Response.BodyEncoding, fields)
+	}
+}
+
+// Replaces the random boundary of a multipart request with MULTIPART_BOUNDARY
+func normalizeMultipart(req *RecordedRequest) {
+	contentType := headerValues(req.Header, "Content-Type")
+	if len(contentType) == 0 {
+		return
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType[0])
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") || params["boundary"] == "" {
+		return
+	}
+
+	boundary := params["boundary"]
+	for key := range req.Header {
+		if strings.EqualFold(key, "Content-Type") {
+			req.Header[key] = []string{strings.Replace(contentType[0], boundary, MULTIPART_BOUNDARY, 1)}
+		}
+	}
+
+	body, err := req.Bytes()
+	if err != nil {
+		return
+	}
+	req.Body, req.BodyEncoding = encodeBody([]byte(strings.ReplaceAll(string(body), boundary, MULTIPART_BOUNDARY)))
+}
+
+func scrubHeader(header map[string][]string, names []string) {
+	for key := range header {
+		for _, name := range names {
+			if strings.EqualFold(key, name) {
+				header[key] = []string{SCRUBBED}
+			}
+		}
+	}
+}
+
+// Returns `body` with `fields` scrubbed. Bodies that are not JSON are returned unchanged
+func scrubBody(body string, encoding string, fields []string) string {
+	if encoding != "" || len(fields) == 0 {
+		return body
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return body
+	}
+	if !scrubValue(value, fields) {
+		return body
+	}
+
+	scrubbed, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return string(scrubbed)
+}
+
+// Scrubs `fields` of the objects in `value`. Reports whether anything was scrubbed
+func scrubValue(value any, fields []string) bool {
+	scrubbed := false
+	switch value := value.(type) {
+	case map[string]any:
+		for key, item := range value {
+			if item != nil && isScrubbedField(key, fields) {
+				value[key] = SCRUBBED
+				scrubbed = true
+				continue
+			}
+			scrubbed = scrubValue(item, fields) || scrubbed
+		}
+	case []any:
+		for _, item := range value {
+			scrubbed = scrubValue(item, fields) || scrubbed
+		}
+	}
+	return scrubbed
+}
+
+func isScrubbedField(key string, fields []string) bool {
+	for _, field := range fields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package klaviyotest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/developertom01/klaviyo-go/exceptions"
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Maximum number of items of a bulk job
+const MAX_BULK_JOB_ITEMS = 100
+
+// Days a bulk job is kept after its creation
+const bulkJobExpiry = 7 * 24 * time.Hour
+
+// Returns compound ID {integration}:::{catalog}:::{external_id} of a catalog item or variant
+func catalogID(attributes map[string]any) (string, error) {
+	externalID, _ := attributes["external_id"].(string)
+	integration, _ := attributes["integration_type"].(string)
+	catalogType, _ := attributes["catalog_type"].(string)
+
+	id := models.CatalogID{Integration: integration, Catalog: catalogType, ExternalID: externalID}
+	if err := id.Validate(); err != nil {
+		return "", fmt.Errorf("%v.", err)
+	}
+
+	return id.String(), nil
+}
+
+// Creates a bulk create, update or delete job of catalog items. Items are changed when the job completes
+func (s *Server) createBulkJob(w http.ResponseWriter, r *http.Request) {
+	jobType := strings.TrimSuffix(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/"), "/"), "s")
+
+	jobRes, ok := s.decodeBody(w, r, jobType)
+	if !ok {
+		return
+	}
+
+	items := bulkJobItems(jobRes.Attributes)
+	if len(items) == 0 || len(items) > MAX_BULK_JOB_ITEMS {
+		writeValidationError(w, fmt.Sprintf("A bulk job takes between 1 and %d items.", MAX_BULK_JOB_ITEMS), "/data/attributes/items/data")
+		return
+	}
+
+	operations := make([]*resource, 0, len(items))
+	for i, item := range items {
+		object, _ := item.(map[string]any)
+		if wrapped, ok := member(object, "data").(map[string]any); ok {
+			object = wrapped
+		}
+
+		itemRes, err := decodeResource(object)
+		if err == nil && itemRes.Type != "catalog-item" {
+			err = fmt.Errorf("The type %q does not match catalog-item.", itemRes.Type)
+		}
+		if err != nil {
+			writeValidationError(w, err.Error(), fmt.Sprintf("/data/attributes/items/data/%d", i))
+			return
+		}
+		operations = append(operations, itemRes)
+	}
+
+	now := s.now()
+	jobRes.ID = s.newID()
+	jobRes.Attributes = map[string]any{
+		"created_at":      timestamp(now),
+		"total_count":     len(operations),
+		"completed_count": 0,
+		"failed_count":    0,
+		"completed_at":    nil,
+		"errors":          []any{},
+		"expires_at":      timestamp(now.Add(bulkJobExpiry)),
+	}
+
+	s.startJob(jobRes, func(res *resource) {
+		var (
+			changed   []models.RelationshipData
+			apiErrors []exceptions.ApiError
+		)
+		for i, operation := range operations {
+			id, err := s.applyBulkOperation(jobType, operation)
+			if err != nil {
+				apiErrors = append(apiErrors, exceptions.ApiError{
+					Code:   "invalid",
+					Title:  "Invalid input.",
+					Detail: err.Error(),
+					Source: pointer(fmt.Sprintf("/data/attributes/items/data/%d", i)),
+				})
+				continue
+			}
+			changed = append(changed, id)
+		}
+
+		res.Attributes["status"] = string(models.CatalogItemBulkJobStatusComplete)
+		res.Attributes["completed_count"] = len(changed)
+		res.Attributes["failed_count"] = len(apiErrors)
+		res.Attributes["completed_at"] = timestamp(s.now())
+		if value, err := toValue(apiErrors); err == nil && apiErrors != nil {
+			res.Attributes["errors"] = value
+		}
+		s.setLinkage(res, "items", changed)
+	})
+	s.writeResource(w, r, http.StatusAccepted, jobRes)
+}
+
+// Returns the items of a bulk job payload, sent either in items.data or, as by the SDK, in data
+func bulkJobItems(attributes map[string]any) []any {
+	switch items := member(attributes, "items").(type) {
+	case []any:
+		return items
+	case map[string]any:
+		list, _ := member(items, "data").([]any)
+		return list
+	}
+
+	list, _ := member(attributes, "data").([]any)
+	return list
+}
+
+// Creates, updates or deletes a catalog item. Returns its identifier
+func (s *Server) applyBulkOperation(jobType string, item *resource) (models.RelationshipData, error) {
+	create := jobType == "catalog-item-bulk-create-job"
+	if create {
+		item.ID = ""
+		if err := s.assignID(item); err != nil {
+			return models.RelationshipData{}, err
+		}
+	}
+	for _, attribute := range schemas["catalog-item"].writeOnly {
+		delete(item.Attributes, attribute)
+	}
+
+	existing := s.get(item.identifier())
+	switch {
+	case create && existing != nil:
+		return models.RelationshipData{}, fmt.Errorf("A catalog item with id %s already exists.", item.ID)
+	case create:
+		s.stamp(item, true)
+		s.put(item)
+		return item.identifier(), nil
+	case existing == nil:
+		return models.RelationshipData{}, fmt.Errorf("Catalog item %s was not found.", item.ID)
+	case jobType == "catalog-item-bulk-delete-job":
+		s.remove(existing)
+		return existing.identifier(), nil
+	}
+
+	for key, value := range item.Attributes {
+		existing.Attributes[key] = value
+	}
+	for name, linkage := range item.Relationships {
+		s.setLinkage(existing, name, linkage)
+	}
+	s.stamp(existing, false)
+	return existing.identifier(), nil
+}
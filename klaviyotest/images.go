@@ -0,0 +1,175 @@
+package klaviyotest
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Maximum size of an uploaded image in bytes
+const MAX_IMAGE_SIZE = 5 << 20
+
+// Formats of uploaded images by content type
+var imageFormats = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+// Creates an image from the `file` part of a multipart form, named after the `name` part or the file name
+func (s *Server) uploadImage(w http.ResponseWriter, r *http.Request) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		writeParameterError(w, "The request body must be a multipart form.", "file")
+		return
+	}
+
+	var (
+		content  []byte
+		fileName string
+		fields   = map[string]string{}
+	)
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			if !errors.Is(err, io.EOF) && content == nil {
+				writeParameterError(w, "The multipart form is malformed.", "file")
+				return
+			}
+			break
+		}
+
+		value, err := io.ReadAll(io.LimitReader(part, MAX_IMAGE_SIZE+1))
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			writeParameterError(w, "The multipart form is malformed.", "file")
+			return
+		}
+		if part.FileName() != "" {
+			content, fileName = value, part.FileName()
+		} else {
+			fields[part.FormName()] = string(value)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if content == nil {
+		writeParameterError(w, "An image file is required.", "file")
+		return
+	}
+
+	name := fields["name"]
+	if name == "" {
+		name = fileName
+	}
+	s.createImage(w, r, content, name, fields["hidden"] == "true", "file")
+}
+
+// Creates an image from the `import_from_url` attribute, a data URI or an URL of a jpeg, png or gif image
+func (s *Server) importImage(w http.ResponseWriter, r *http.Request) {
+	object, ok := s.decodeObject(w, r)
+	if !ok {
+		return
+	}
+
+	var source string
+	if attributes, ok := member(object, "attributes").(map[string]any); ok {
+		source, _ = attributes["import_from_url"].(string)
+	}
+
+	request, ok := s.resourceOf(w, object, "image")
+	if !ok {
+		return
+	}
+	name, _ := request.Attributes["name"].(string)
+	hidden, _ := request.Attributes["hidden"].(bool)
+
+	if data, ok := strings.CutPrefix(source, "data:"); ok {
+		_, encoded, _ := strings.Cut(data, ";base64,")
+		content, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			writeValidationError(w, "import_from_url is not a valid base64 data URI.", "/data/attributes/import_from_url")
+			return
+		}
+		if name == "" {
+			name = "image"
+		}
+		s.createImage(w, r, content, name, hidden, "/data/attributes/import_from_url")
+		return
+	}
+
+	sourceURL, err := url.Parse(source)
+	if err != nil || (sourceURL.Scheme != "http" && sourceURL.Scheme != "https") {
+		writeValidationError(w, "import_from_url must be an http(s) URL or a data URI.", "/data/attributes/import_from_url")
+		return
+	}
+
+	format := strings.TrimPrefix(strings.ToLower(path.Ext(sourceURL.Path)), ".")
+	if format == "jpg" {
+		format = "jpeg"
+	}
+	if _, ok := imageFormats["image/"+format]; !ok {
+		writeValidationError(w, "Supported image formats: jpeg, png, gif.", "/data/attributes/import_from_url")
+		return
+	}
+	if name == "" {
+		name = path.Base(sourceURL.Path)
+	}
+
+	image := s.newImage(name, format, 0, hidden)
+	image.Attributes["image_url"] = source
+	s.put(image)
+	s.writeResource(w, r, http.StatusCreated, image)
+}
+
+// Creates an image of `content`, writing an error about `source` when it is not a supported image
+func (s *Server) createImage(w http.ResponseWriter, r *http.Request, content []byte, name string, hidden bool, source string) {
+	detail := ""
+	format, ok := imageFormats[http.DetectContentType(content)]
+	switch {
+	case len(content) > MAX_IMAGE_SIZE:
+		detail = "Maximum image size is 5MB."
+	case !ok:
+		detail = "Supported image formats: jpeg, png, gif."
+	}
+	if detail != "" {
+		if strings.HasPrefix(source, "/") {
+			writeValidationError(w, detail, source)
+		} else {
+			writeParameterError(w, detail, source)
+		}
+		return
+	}
+
+	image := s.newImage(name, format, len(content), hidden)
+	s.media[image.ID] = content
+	s.put(image)
+	s.writeResource(w, r, http.StatusCreated, image)
+}
+
+func (s *Server) newImage(name string, format string, size int, hidden bool) *resource {
+	id := s.newID()
+	image := &resource{
+		Type: "image",
+		ID:   id,
+		Attributes: map[string]any{
+			"name":      name,
+			"image_url": fmt.Sprintf("%s/media/%s.%s", s.URL, id, format),
+			"format":    format,
+			"size":      size,
+			"hidden":    hidden,
+		},
+		Relationships: map[string][]models.RelationshipData{},
+	}
+	s.stamp(image, true)
+
+	return image
+}
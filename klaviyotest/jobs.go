@@ -0,0 +1,166 @@
+package klaviyotest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Job statuses, see models.CampaignSendJobStatus and models.CatalogItemBulkJobStatus
+const (
+	statusQueued     = "queued"
+	statusProcessing = "processing"
+)
+
+// Job in progress, completed after being fetched WithJobPolls times
+type job struct {
+	polls    int
+	complete func(res *resource)
+}
+
+func isJobCollection(collection string) bool {
+	return slices.Contains([]string{
+		"campaign-send-jobs",
+		"campaign-recipient-estimation-jobs",
+		"catalog-item-bulk-create-jobs",
+		"catalog-item-bulk-update-jobs",
+		"catalog-item-bulk-delete-jobs",
+	}, collection)
+}
+
+// Stores job `res` as queued. `complete` sets its final state
+func (s *Server) startJob(res *resource, complete func(res *resource)) {
+	res.Attributes["status"] = statusQueued
+	s.put(res)
+
+	if s.jobPolls <= 0 {
+		complete(res)
+		return
+	}
+	s.jobs[res.identifier()] = &job{complete: complete}
+}
+
+// Writes a job, completing it when it was fetched enough times
+func (s *Server) pollJob(w http.ResponseWriter, r *http.Request, collection string, id string) {
+	resourceType, _ := typeOfCollection(collection)
+
+	res := s.get(models.RelationshipData{Type: resourceType, ID: id})
+	if res == nil {
+		writeNotFound(w, r)
+		return
+	}
+
+	if pending, ok := s.jobs[res.identifier()]; ok {
+		pending.polls++
+		if pending.polls >= s.jobPolls {
+			delete(s.jobs, res.identifier())
+			pending.complete(res)
+		} else {
+			res.Attributes["status"] = statusProcessing
+		}
+	}
+
+	s.writeResource(w, r, http.StatusOK, res)
+}
+
+// Creates a send job of the campaign in the body. The campaign is sent when the job completes
+func (s *Server) createSendJob(w http.ResponseWriter, r *http.Request) {
+	jobRes, ok := s.decodeBody(w, r, "campaign-send-job")
+	if !ok {
+		return
+	}
+
+	campaign := s.get(models.RelationshipData{Type: "campaign", ID: jobRes.ID})
+	if campaign == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Not found.", fmt.Sprintf("Campaign %s was not found.", jobRes.ID), pointer("/data/id"))
+		return
+	}
+	if status := campaign.Attributes["status"]; status != "Draft" {
+		writeValidationError(w, fmt.Sprintf("Campaign %s can not be sent, its status is %v.", campaign.ID, status), "/data/id")
+		return
+	}
+
+	campaign.Attributes["status"] = "Sending"
+	s.stamp(campaign, false)
+
+	s.startJob(jobRes, func(res *resource) {
+		res.Attributes["status"] = string(models.CampaignSendJobStatusCompleted)
+		campaign.Attributes["status"] = "Sent"
+		campaign.Attributes["send_time"] = timestamp(s.now())
+	})
+	s.writeResource(w, r, http.StatusCreated, jobRes)
+}
+
+// Cancels or reverts the send job of a campaign
+func (s *Server) updateSendJob(w http.ResponseWriter, r *http.Request, id string) {
+	jobRes := s.get(models.RelationshipData{Type: "campaign-send-job", ID: id})
+	if jobRes == nil {
+		writeNotFound(w, r)
+		return
+	}
+
+	patch, ok := s.decodeBody(w, r, "campaign-send-job")
+	if !ok {
+		return
+	}
+	if _, pending := s.jobs[jobRes.identifier()]; !pending {
+		writeValidationError(w, fmt.Sprintf("Send job %s is %v and can not be changed.", id, jobRes.Attributes["status"]), "/data/attributes/action")
+		return
+	}
+
+	campaign := s.get(models.RelationshipData{Type: "campaign", ID: id})
+	switch action := patch.Attributes["action"]; action {
+	case "cancel":
+		campaign.Attributes["status"] = "Cancelled"
+	case "revert":
+		campaign.Attributes["status"] = "Draft"
+	default:
+		writeValidationError(w, fmt.Sprintf("%v is not a valid action, use cancel or revert.", action), "/data/attributes/action")
+		return
+	}
+
+	delete(s.jobs, jobRes.identifier())
+	jobRes.Attributes["status"] = string(models.CampaignSendJobStatusCancelled)
+	s.writeResource(w, r, http.StatusOK, jobRes)
+}
+
+// Creates a recipient estimation job of the campaign in the body
+func (s *Server) createEstimationJob(w http.ResponseWriter, r *http.Request) {
+	jobRes, ok := s.decodeBody(w, r, "campaign-recipient-estimation-job")
+	if !ok {
+		return
+	}
+
+	if s.get(models.RelationshipData{Type: "campaign", ID: jobRes.ID}) == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Not found.", fmt.Sprintf("Campaign %s was not found.", jobRes.ID), pointer("/data/id"))
+		return
+	}
+
+	s.startJob(jobRes, func(res *resource) {
+		res.Attributes["status"] = string(models.CampaignSendJobStatusCompleted)
+	})
+	s.writeResource(w, r, http.StatusCreated, jobRes)
+}
+
+// Writes the recipient estimation of a campaign, zero unless one was stored
+func (s *Server) getEstimation(w http.ResponseWriter, r *http.Request, id string) {
+	estimation := s.get(models.RelationshipData{Type: "campaign-recipient-estimation", ID: id})
+	if estimation == nil {
+		if s.get(models.RelationshipData{Type: "campaign", ID: id}) == nil {
+			writeNotFound(w, r)
+			return
+		}
+
+		estimation = &resource{
+			Type:          "campaign-recipient-estimation",
+			ID:            id,
+			Attributes:    map[string]any{"estimated_recipient_count": 0},
+			Relationships: map[string][]models.RelationshipData{},
+		}
+		s.put(estimation)
+	}
+
+	s.writeResource(w, r, http.StatusOK, estimation)
+}
@@ -0,0 +1,479 @@
+package klaviyotest
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	campaigns "github.com/developertom01/klaviyo-go/api/campaignsApi"
+	catalog "github.com/developertom01/klaviyo-go/api/catalogApi"
+	flows "github.com/developertom01/klaviyo-go/api/flowsApi"
+	images "github.com/developertom01/klaviyo-go/api/imagesApi"
+	"github.com/developertom01/klaviyo-go/common/filter"
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Maximum value of page[size]
+const MAX_PAGE_SIZE = 100
+
+// Filters accepted when listing resources of a type. Filters of other types are not checked against a schema
+var filterSchemas = map[string]filter.Schema{
+	"campaign":                     campaigns.GetCampaignsFilterSchema,
+	"image":                        images.GetImagesFilterSchema,
+	"catalog-item":                 catalog.GetCatalogItemsFilterSchema,
+	"catalog-variant":              catalog.GetCatalogVariantsFilterSchema,
+	"catalog-item-bulk-create-job": catalog.GetBulkItemsJobsFilterSchema,
+	"catalog-item-bulk-update-job": catalog.GetBulkItemsJobsFilterSchema,
+	"catalog-item-bulk-delete-job": catalog.GetBulkItemsJobsFilterSchema,
+	"flow":                         flows.GetFlowsFilterSchema,
+	"flow-action":                  flows.GetFlowActionsFilterSchema,
+	"flow-message":                 flows.GetFlowMessagesFilterSchema,
+}
+
+// Writes a page of `resources` of `resourceType`, filtered and sorted as requested
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, resourceType string, resources []*resource) {
+	page, links, ok := s.page(w, r, resourceType, resources)
+	if !ok {
+		return
+	}
+
+	fields := fieldsParam(r)
+	data := make([]any, 0, len(page))
+	for _, res := range page {
+		data = append(data, s.render(res, fields[res.Type]))
+	}
+
+	included, err := s.include(r, page)
+	if err != nil {
+		writeParameterError(w, err.Error(), "include")
+		return
+	}
+
+	document := map[string]any{"data": data, "links": links}
+	if included != nil {
+		document["included"] = s.renderAll(included, fields)
+	}
+	writeJSON(w, http.StatusOK, document)
+}
+
+// Writes linkage of a page of `resources` of `resourceType`
+func (s *Server) writeLinkage(w http.ResponseWriter, r *http.Request, resourceType string, resources []*resource) {
+	page, links, ok := s.page(w, r, resourceType, resources)
+	if !ok {
+		return
+	}
+
+	data := make([]models.RelationshipData, 0, len(page))
+	for _, res := range page {
+		data = append(data, res.identifier())
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": data, "links": links})
+}
+
+// Writes a document holding `res` and the resources requested by include
+func (s *Server) writeResource(w http.ResponseWriter, r *http.Request, status int, res *resource) {
+	fields := fieldsParam(r)
+
+	included, err := s.include(r, []*resource{res})
+	if err != nil {
+		writeParameterError(w, err.Error(), "include")
+		return
+	}
+
+	document := map[string]any{
+		"data":  s.render(res, fields[res.Type]),
+		"links": map[string]any{"self": s.resourceURL(res.Type, res.ID)},
+	}
+	if included != nil {
+		document["included"] = s.renderAll(included, fields)
+	}
+	writeJSON(w, status, document)
+}
+
+func (s *Server) renderAll(resources []*resource, fields map[string][]string) []any {
+	rendered := make([]any, 0, len(resources))
+	for _, res := range resources {
+		rendered = append(rendered, s.render(res, fields[res.Type]))
+	}
+	return rendered
+}
+
+// Filters, sorts and pages `resources`. Writes an error and returns false when a parameter is invalid
+func (s *Server) page(w http.ResponseWriter, r *http.Request, resourceType string, resources []*resource) ([]*resource, map[string]any, bool) {
+	query := r.URL.Query()
+
+	matched, err := s.filter(resourceType, resources, query.Get("filter"))
+	if err != nil {
+		writeParameterError(w, err.Error(), "filter")
+		return nil, nil, false
+	}
+
+	if sortParam := query.Get("sort"); sortParam != "" {
+		sortResources(matched, strings.Split(sortParam, ","))
+	}
+
+	size := DEFAULT_PAGE_SIZE
+	if sizeParam := query.Get("page[size]"); sizeParam != "" {
+		size, err = strconv.Atoi(sizeParam)
+		if err != nil || size < 1 || size > MAX_PAGE_SIZE {
+			writeParameterError(w, fmt.Sprintf("page[size] must be an integer between 1 and %d.", MAX_PAGE_SIZE), "page[size]")
+			return nil, nil, false
+		}
+	}
+
+	offset := 0
+	if cursor := query.Get("page[cursor]"); cursor != "" {
+		offset, err = decodeCursor(cursor)
+		if err != nil {
+			writeParameterError(w, "page[cursor] is invalid.", "page[cursor]")
+			return nil, nil, false
+		}
+	}
+
+	start := min(offset, len(matched))
+	end := min(offset+size, len(matched))
+
+	links := map[string]any{"self": s.requestURL(r), "next": nil, "prev": nil}
+	if end < len(matched) {
+		links["next"] = s.pageURL(r, end)
+	}
+	if start > 0 {
+		links["prev"] = s.pageURL(r, max(start-size, 0))
+	}
+
+	return matched[start:end], links, true
+}
+
+// Returns URL of the request with the page starting at `offset`
+func (s *Server) pageURL(r *http.Request, offset int) string {
+	query := r.URL.Query()
+	query.Set("page[cursor]", encodeCursor(offset))
+
+	pageURL := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+	return s.URL + pageURL.RequestURI()
+}
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	offset, ok := strings.CutPrefix(string(decoded), "offset:")
+	if !ok {
+		return 0, errors.New("invalid cursor")
+	}
+	value, err := strconv.Atoi(offset)
+	if err != nil || value < 0 {
+		return 0, errors.New("invalid cursor")
+	}
+	return value, nil
+}
+
+// Returns resources matching every expression of `filterParam`
+func (s *Server) filter(resourceType string, resources []*resource, filterParam string) ([]*resource, error) {
+	expressions, err := filter.Parse(filterParam)
+	if err != nil {
+		return nil, err
+	}
+	if schema, ok := filterSchemas[resourceType]; ok {
+		if err := schema.Validate(expressions...); err != nil {
+			return nil, err
+		}
+	}
+
+	matched := make([]*resource, 0, len(resources))
+	for _, res := range resources {
+		if s.matchAll(res, expressions) {
+			matched = append(matched, res)
+		}
+	}
+	return matched, nil
+}
+
+func (s *Server) matchAll(res *resource, expressions []filter.Expression) bool {
+	for _, expression := range expressions {
+		if !s.match(res, expression) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) match(res *resource, expression filter.Expression) bool {
+	switch expr := expression.(type) {
+	case filter.Comparison:
+		for _, value := range s.fieldValues(res, expr.Field) {
+			if compare(expr.Operator, value, expr.Value) {
+				return true
+			}
+		}
+		return false
+	case filter.Logical:
+		if expr.Operator == filter.OperatorOr {
+			return slices.ContainsFunc(expr.Operands, func(operand filter.Expression) bool { return s.match(res, operand) })
+		}
+		return s.matchAll(res, expr.Operands)
+	case filter.Negation:
+		return !s.match(res, expr.Operand)
+	}
+	return false
+}
+
+// Returns values of `field` for a resource: its ID for id and ids, attributes of related resources for fields
+// starting with a relationship, eg. messages.channel, and its attribute otherwise. Missing attributes are nil
+func (s *Server) fieldValues(res *resource, field string) []any {
+	if field == "id" || field == "ids" {
+		return []any{res.ID}
+	}
+
+	path := strings.Split(field, ".")
+	schema := schemas[res.Type]
+	name := path[0]
+	if alias, ok := schema.aliases[name]; ok {
+		name = alias
+	}
+	if _, ok := schema.relationships[name]; ok && len(path) > 1 {
+		var values []any
+		if len(path) == 2 && path[1] == "id" {
+			for _, id := range res.Relationships[name] {
+				values = append(values, id.ID)
+			}
+			return values
+		}
+
+		for _, related := range s.resolve(res.Relationships[name]) {
+			values = append(values, s.fieldValues(related, strings.Join(path[1:], "."))...)
+		}
+		return values
+	}
+
+	value, _ := lookup(res.Attributes, path)
+	return []any{value}
+}
+
+// Reports whether attribute value `actual` satisfies `operator` applied to `expected`
+func compare(operator filter.Operator, actual any, expected filter.Value) bool {
+	switch operator {
+	case filter.OperatorEquals:
+		return order(actual, expected) == 0
+	case filter.OperatorLessThan:
+		return order(actual, expected) == -1
+	case filter.OperatorLessOrEqual:
+		result := order(actual, expected)
+		return result == -1 || result == 0
+	case filter.OperatorGreaterThan:
+		return order(actual, expected) == 1
+	case filter.OperatorGreaterOrEqual:
+		result := order(actual, expected)
+		return result == 1 || result == 0
+	case filter.OperatorAny:
+		list, _ := expected.(filter.ListValue)
+		return slices.ContainsFunc(list, func(item filter.Value) bool { return order(actual, item) == 0 })
+	case filter.OperatorHas:
+		return actual != nil
+	case filter.OperatorStartsWith, filter.OperatorEndsWith:
+		text, ok := actual.(string)
+		prefix, isString := expected.(filter.StringValue)
+		if !ok || !isString {
+			return false
+		}
+		if operator == filter.OperatorStartsWith {
+			return strings.HasPrefix(text, string(prefix))
+		}
+		return strings.HasSuffix(text, string(prefix))
+	case filter.OperatorContains:
+		if text, ok := actual.(string); ok {
+			substring, isString := expected.(filter.StringValue)
+			return isString && strings.Contains(text, string(substring))
+		}
+		items, _ := actual.([]any)
+		return slices.ContainsFunc(items, func(item any) bool { return order(item, expected) == 0 })
+	case filter.OperatorContainsAny, filter.OperatorContainsAll:
+		items, _ := actual.([]any)
+		list, _ := expected.(filter.ListValue)
+		contained := func(value filter.Value) bool {
+			return slices.ContainsFunc(items, func(item any) bool { return order(item, value) == 0 })
+		}
+		if operator == filter.OperatorContainsAny {
+			return slices.ContainsFunc(list, contained)
+		}
+		return len(list) > 0 && !slices.ContainsFunc(list, func(value filter.Value) bool { return !contained(value) })
+	}
+	return false
+}
+
+// Orders attribute value `actual` against `expected`: -1, 0 or 1, or 2 when they can not be compared
+func order(actual any, expected filter.Value) int {
+	switch value := expected.(type) {
+	case filter.NullValue:
+		if actual == nil {
+			return 0
+		}
+	case filter.StringValue:
+		if text, ok := actual.(string); ok {
+			return strings.Compare(text, string(value))
+		}
+	case filter.BoolValue:
+		if flag, ok := actual.(bool); ok && flag == bool(value) {
+			return 0
+		}
+	case filter.IntValue:
+		if number, ok := toNumber(actual); ok {
+			return compareNumbers(number, float64(value))
+		}
+	case filter.FloatValue:
+		if number, ok := toNumber(actual); ok {
+			return compareNumbers(number, float64(value))
+		}
+	case filter.LiteralValue:
+		return order(actual, value.Value)
+	case filter.DateTimeValue:
+		text, _ := actual.(string)
+		if moment, err := time.Parse(time.RFC3339Nano, text); err == nil {
+			return moment.Compare(value.Time)
+		}
+	}
+	return 2
+}
+
+// Returns number decoded from JSON or set by the server
+func toNumber(value any) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case int:
+		return float64(number), true
+	}
+	return 0, false
+}
+
+func compareNumbers(a float64, b float64) int {
+	switch {
+	case math.IsNaN(a) || math.IsNaN(b):
+		return 2
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Sorts resources by `keys`, eg. -created_at. Missing values sort first
+func sortResources(resources []*resource, keys []string) {
+	sort.SliceStable(resources, func(i, j int) bool {
+		for _, key := range keys {
+			field, descending := strings.CutPrefix(key, "-")
+			result := compareValues(sortValue(resources[i], field), sortValue(resources[j], field))
+			if result == 0 {
+				continue
+			}
+			return (result < 0) != descending
+		}
+		return false
+	})
+}
+
+func sortValue(res *resource, field string) any {
+	if field == "id" {
+		return res.ID
+	}
+	value, _ := lookup(res.Attributes, strings.Split(field, "."))
+	return value
+}
+
+func compareValues(a any, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	if first, ok := toNumber(a); ok {
+		second, _ := toNumber(b)
+		return compareNumbers(first, second)
+	}
+
+	switch first := a.(type) {
+	case string:
+		second, _ := b.(string)
+		return strings.Compare(first, second)
+	case bool:
+		second, _ := b.(bool)
+		switch {
+		case first == second:
+			return 0
+		case second:
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// Returns sparse fieldsets requested by fields[type] parameters
+func fieldsParam(r *http.Request) map[string][]string {
+	fields := map[string][]string{}
+	for key, values := range r.URL.Query() {
+		resourceType, ok := strings.CutPrefix(key, "fields[")
+		if !ok || !strings.HasSuffix(resourceType, "]") || len(values) == 0 {
+			continue
+		}
+		fields[strings.TrimSuffix(resourceType, "]")] = strings.Split(values[0], ",")
+	}
+	return fields
+}
+
+// Returns resources requested by the include parameter, eg. campaign-messages.template, other than `primary`.
+// Returns nil when nothing is requested
+func (s *Server) include(r *http.Request, primary []*resource) ([]*resource, error) {
+	includeParam := r.URL.Query().Get("include")
+	if includeParam == "" {
+		return nil, nil
+	}
+
+	seen := map[models.RelationshipData]bool{}
+	for _, res := range primary {
+		seen[res.identifier()] = true
+	}
+
+	included := []*resource{}
+	for _, path := range strings.Split(includeParam, ",") {
+		current := primary
+		for _, name := range strings.Split(path, ".") {
+			var next []*resource
+			for _, res := range current {
+				if _, ok := schemas[res.Type].relationships[name]; !ok {
+					return nil, fmt.Errorf("%s is not a relationship of %s.", name, res.Type)
+				}
+				next = append(next, s.resolve(res.Relationships[name])...)
+			}
+
+			for _, res := range next {
+				if !seen[res.identifier()] {
+					seen[res.identifier()] = true
+					included = append(included, res)
+				}
+			}
+			current = next
+		}
+	}
+
+	return included, nil
+}
@@ -0,0 +1,374 @@
+package klaviyotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+type (
+	// Resource held by the server. Attributes are kept as decoded JSON
+	resource struct {
+		Type          string
+		ID            string
+		Attributes    map[string]any
+		Relationships map[string][]models.RelationshipData
+		seq           int
+	}
+
+	relationship struct {
+		target  string //Type of related resources
+		toOne   bool
+		inverse string //Relationship of the related resources pointing back, empty when none
+	}
+
+	resourceSchema struct {
+		relationships map[string]relationship
+		aliases       map[string]string //Filter field prefixes naming a relationship, eg. messages for campaign-messages
+		created       string            //Attribute set on creation, empty when none
+		updated       string            //Attribute set on creation and update, empty when none
+		writeOnly     []string          //Attributes accepted in payloads but not stored
+	}
+)
+
+var schemas = map[string]resourceSchema{
+	"account": {},
+	"campaign": {
+		relationships: map[string]relationship{
+			"campaign-messages": {target: "campaign-message", inverse: "campaign"},
+			"tags":              {target: "tag"},
+		},
+		aliases:   map[string]string{"messages": "campaign-messages"},
+		created:   "created_at",
+		updated:   "updated_at",
+		writeOnly: []string{"campaign-messages"},
+	},
+	"campaign-message": {
+		relationships: map[string]relationship{
+			"campaign": {target: "campaign", toOne: true, inverse: "campaign-messages"},
+			"template": {target: "template", toOne: true},
+		},
+		created: "created_at",
+		updated: "updated_at",
+	},
+	"campaign-send-job":                 {},
+	"campaign-recipient-estimation":     {},
+	"campaign-recipient-estimation-job": {},
+	"template":                          {created: "created", updated: "updated"},
+	"tag":                               {},
+	"flow": {
+		relationships: map[string]relationship{
+			"flow-actions": {target: "flow-action", inverse: "flow"},
+			"tags":         {target: "tag"},
+		},
+		created: "created",
+		updated: "updated",
+	},
+	"flow-action": {
+		relationships: map[string]relationship{
+			"flow":          {target: "flow", toOne: true, inverse: "flow-actions"},
+			"flow-messages": {target: "flow-message", inverse: "flow-action"},
+		},
+		created: "created",
+		updated: "updated",
+	},
+	"flow-message": {
+		relationships: map[string]relationship{
+			"flow-action": {target: "flow-action", toOne: true, inverse: "flow-messages"},
+			"template":    {target: "template", toOne: true},
+		},
+		created: "created",
+		updated: "updated",
+	},
+	"catalog-item": {
+		relationships: map[string]relationship{
+			"variants":   {target: "catalog-variant", inverse: "item"},
+			"categories": {target: "catalog-category"},
+		},
+		aliases:   map[string]string{"category": "categories"},
+		created:   "created",
+		updated:   "updated",
+		writeOnly: []string{"catalog_type", "integration_type"},
+	},
+	"catalog-variant": {
+		relationships: map[string]relationship{
+			"item": {target: "catalog-item", toOne: true, inverse: "variants"},
+		},
+		created:   "created",
+		updated:   "updated",
+		writeOnly: []string{"catalog_type", "integration_type"},
+	},
+	"catalog-category": {},
+	"catalog-item-bulk-create-job": {
+		relationships: map[string]relationship{"items": {target: "catalog-item"}},
+	},
+	"catalog-item-bulk-update-job": {
+		relationships: map[string]relationship{"items": {target: "catalog-item"}},
+	},
+	"catalog-item-bulk-delete-job": {
+		relationships: map[string]relationship{"items": {target: "catalog-item"}},
+	},
+	"image": {
+		updated:   "updated_at",
+		writeOnly: []string{"import_from_url"},
+	},
+}
+
+// Returns resource type served under `collection`, eg. campaign for campaigns
+func typeOfCollection(collection string) (string, bool) {
+	resourceType, found := strings.CutSuffix(collection, "s")
+	if !found {
+		return "", false
+	}
+	_, ok := schemas[resourceType]
+	return resourceType, ok
+}
+
+// Decodes a resource object, eg. the `data` member of a request body
+func decodeResource(raw map[string]any) (*resource, error) {
+	res := &resource{
+		Attributes:    map[string]any{},
+		Relationships: map[string][]models.RelationshipData{},
+	}
+	res.Type, _ = raw["type"].(string)
+	res.ID, _ = raw["id"].(string)
+
+	attributes := member(raw, "attributes")
+	if attributes == nil {
+		attributes = member(raw, "attribute")
+	}
+	if attributes != nil {
+		object, ok := attributes.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("attributes must be an object")
+		}
+		res.Attributes = object
+	}
+
+	relationships, ok := member(raw, "relationships").(map[string]any)
+	if !ok {
+		return res, nil
+	}
+	for name, value := range relationships {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("relationship %q must be an object", name)
+		}
+		data, present := object["data"]
+		if !present {
+			data, present = object["Data"]
+		}
+		if !present {
+			continue
+		}
+
+		linkage, err := decodeLinkage(data)
+		if err != nil {
+			return nil, fmt.Errorf("relationship %q: %w", name, err)
+		}
+		res.Relationships[strings.ToLower(name)] = linkage
+	}
+
+	return res, nil
+}
+
+// Decodes linkage of a to-one or to-many relationship. Linkage without type and id is ignored
+func decodeLinkage(data any) ([]models.RelationshipData, error) {
+	var items []any
+	switch value := data.(type) {
+	case nil:
+		return []models.RelationshipData{}, nil
+	case []any:
+		items = value
+	case map[string]any:
+		items = []any{value}
+	default:
+		return nil, fmt.Errorf("data must be an object, an array or null")
+	}
+
+	linkage := make([]models.RelationshipData, 0, len(items))
+	for _, item := range items {
+		object, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("resource identifier must be an object")
+		}
+		id := models.RelationshipData{}
+		id.Type, _ = object["type"].(string)
+		id.ID, _ = object["id"].(string)
+		if id.Type == "" && id.ID == "" {
+			continue
+		}
+		linkage = append(linkage, id)
+	}
+
+	return linkage, nil
+}
+
+// Returns member `name` of a JSON object, matching the name case-insensitively when it is not found as is
+func member(object map[string]any, name string) any {
+	if value, ok := object[name]; ok {
+		return value
+	}
+	for key, value := range object {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return nil
+}
+
+func (res *resource) identifier() models.RelationshipData {
+	return models.RelationshipData{Type: res.Type, ID: res.ID}
+}
+
+func (res *resource) clone() *resource {
+	copied := *res
+	copied.Attributes = cloneValue(res.Attributes).(map[string]any)
+	copied.Relationships = make(map[string][]models.RelationshipData, len(res.Relationships))
+	for name, linkage := range res.Relationships {
+		copied.Relationships[name] = slices.Clone(linkage)
+	}
+	return &copied
+}
+
+func cloneValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(typed))
+		for key, item := range typed {
+			copied[key] = cloneValue(item)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(typed))
+		for i, item := range typed {
+			copied[i] = cloneValue(item)
+		}
+		return copied
+	}
+	return value
+}
+
+// Decodes JSON of a model, eg. models.Campaign, into a generic value
+func toValue(model any) (any, error) {
+	byteData, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	err = json.Unmarshal(byteData, &value)
+	return value, err
+}
+
+// Current time in the format Klaviyo returns timestamps in
+func timestamp(now time.Time) string {
+	return now.UTC().Format(time.RFC3339Nano)
+}
+
+// Renders the resource object. Only attributes listed in `fields` are kept when it is not nil
+func (s *Server) render(res *resource, fields []string) map[string]any {
+	attributes := res.Attributes
+	if fields != nil {
+		attributes = project(res.Attributes, fields)
+	}
+
+	self := s.resourceURL(res.Type, res.ID)
+	object := map[string]any{
+		"type":       res.Type,
+		"id":         res.ID,
+		"attributes": attributes,
+		"links":      map[string]any{"self": self},
+	}
+
+	names := relationshipNames(res)
+	if len(names) == 0 {
+		return object
+	}
+
+	relationships := make(map[string]any, len(names))
+	for _, name := range names {
+		links := map[string]any{
+			"self":    fmt.Sprintf("%srelationships/%s/", self, name),
+			"related": fmt.Sprintf("%s%s/", self, name),
+		}
+		relationships[name] = map[string]any{"data": linkageData(res, name), "links": links}
+	}
+	object["relationships"] = relationships
+
+	return object
+}
+
+// Returns names of declared relationships and of relationships the resource holds linkage of, sorted
+func relationshipNames(res *resource) []string {
+	names := make([]string, 0, len(res.Relationships))
+	for name := range schemas[res.Type].relationships {
+		names = append(names, name)
+	}
+	for name := range res.Relationships {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Linkage as rendered in documents: an object or null for to-one relationships and an array for to-many
+func linkageData(res *resource, name string) any {
+	linkage := res.Relationships[name]
+	if schemas[res.Type].relationships[name].toOne {
+		if len(linkage) == 0 {
+			return nil
+		}
+		return linkage[0]
+	}
+	if linkage == nil {
+		return []models.RelationshipData{}
+	}
+	return linkage
+}
+
+// Keeps attributes at the dotted paths in `fields`, eg. send_strategy.method
+func project(attributes map[string]any, fields []string) map[string]any {
+	projected := map[string]any{}
+	for _, field := range fields {
+		path := strings.Split(field, ".")
+		value, ok := lookup(attributes, path)
+		if !ok {
+			continue
+		}
+
+		target := projected
+		for _, key := range path[:len(path)-1] {
+			next, ok := target[key].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				target[key] = next
+			}
+			target = next
+		}
+		target[path[len(path)-1]] = value
+	}
+	return projected
+}
+
+// Returns attribute at `path`
+func lookup(attributes map[string]any, path []string) (any, bool) {
+	var value any = attributes
+	for _, key := range path {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
@@ -0,0 +1,55 @@
+package klaviyotest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/developertom01/klaviyo-go/exceptions"
+)
+
+func writeJSON(w http.ResponseWriter, status int, document any) {
+	w.Header().Set("Content-Type", CONTENT_TYPE)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(document)
+}
+
+// Writes a JSON:API error document holding a single error
+func writeError(w http.ResponseWriter, status int, code string, title string, detail string, source *exceptions.ApiErrorSource) {
+	id := make([]byte, 16)
+	rand.Read(id)
+
+	writeJSON(w, status, exceptions.ApiErrorResponse{
+		Errors: []exceptions.ApiError{{
+			Id:     hex.EncodeToString(id),
+			Code:   code,
+			Title:  title,
+			Detail: detail,
+			Source: source,
+		}},
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusNotFound, "not_found", "Not found.", fmt.Sprintf("%s %s was not found.", r.Method, r.URL.Path), nil)
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed.", fmt.Sprintf("Method %q not allowed.", r.Method), nil)
+}
+
+// Writes a 400 error about the request body field at JSON pointer `at`
+func writeValidationError(w http.ResponseWriter, detail string, at string) {
+	writeError(w, http.StatusBadRequest, "invalid", "Invalid input.", detail, pointer(at))
+}
+
+// Writes a 400 error about query parameter `parameter`
+func writeParameterError(w http.ResponseWriter, detail string, parameter string) {
+	writeError(w, http.StatusBadRequest, "invalid", "Invalid input.", detail, &exceptions.ApiErrorSource{Parameter: &parameter})
+}
+
+func pointer(at string) *exceptions.ApiErrorSource {
+	return &exceptions.ApiErrorSource{Pointer: &at}
+}
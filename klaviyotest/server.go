@@ -0,0 +1,451 @@
+package klaviyotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	klaviyo "github.com/developertom01/klaviyo-go"
+	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/developertom01/klaviyo-go/options"
+)
+
+// API key accepted by a server created by NewServer
+const API_KEY = "pk_klaviyotest"
+
+// Media type of JSON:API documents
+const CONTENT_TYPE = "application/vnd.api+json"
+
+// Number of resources in a page when page[size] is not sent
+const DEFAULT_PAGE_SIZE = 20
+
+type (
+	// In-memory stand-in of the Klaviyo API served over HTTP for integration tests.
+	// Resources are kept per type, lists honour filter, sort, page[size], page[cursor], include and fields[type],
+	// and errors are returned as JSON:API error documents
+	Server struct {
+		URL string //Base URL of the server, eg. http://127.0.0.1:52113
+
+		server    *httptest.Server
+		mu        sync.Mutex
+		apiKey    string
+		jobPolls  int
+		now       func() time.Time
+		seq       int
+		resources map[string]map[string]*resource
+		jobs      map[models.RelationshipData]*job
+		media     map[string][]byte
+		requests  []Request
+	}
+
+	// Request received by the server
+	Request struct {
+		Method string
+		Path   string
+		Query  url.Values
+		Header http.Header
+		Body   []byte
+	}
+)
+
+// Starts a server accepting API_KEY. Close it when done
+func NewServer() *Server {
+	s := &Server{
+		apiKey:    API_KEY,
+		jobPolls:  1,
+		now:       time.Now,
+		resources: map[string]map[string]*resource{},
+		jobs:      map[models.RelationshipData]*job{},
+		media:     map[string][]byte{},
+	}
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Set API key requests must be authorized with. Any key is accepted when empty
+func (s *Server) WithApiKey(apiKey string) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.apiKey = apiKey
+	return s
+}
+
+// Set number of times a job is fetched before it completes. Jobs complete when created if zero. Defaults to 1
+func (s *Server) WithJobPolls(polls int) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobPolls = polls
+	return s
+}
+
+// Set clock used for timestamps of created and updated resources
+func (s *Server) WithClock(now func() time.Time) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.now = now
+	return s
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Returns options pointing the SDK at the server
+func (s *Server) Options() options.Options {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return options.NewOptionsWithDefaultValues().WithBaseUrl(s.URL).WithApiKey(s.apiKey)
+}
+
+// Returns client of the server. Requests are not retried
+func (s *Server) Client() *klaviyo.KlaviyoApi {
+	return klaviyo.NewKlaviyoApi(s.Options(), &common.RetryOptions{MaxRetries: 1})
+}
+
+// Returns requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.requests)
+}
+
+// Links a resource to `targets` through `relationship`, replacing its linkage.
+// Linkage of the inverse relationship, eg. campaign of a campaign message, is kept in sync
+func (s *Server) Link(from models.RelationshipData, relationship string, targets ...models.RelationshipData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := s.get(from)
+	if res == nil {
+		return fmt.Errorf("klaviyotest: %s %s not found", from.Type, from.ID)
+	}
+	if _, ok := schemas[from.Type].relationships[relationship]; !ok {
+		return fmt.Errorf("klaviyotest: %s has no relationship %s", from.Type, relationship)
+	}
+
+	s.setLinkage(res, relationship, targets)
+	return nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	w.Header().Set("X-Request-Id", fmt.Sprintf("req-%06d", len(s.requests)))
+
+	if media, ok := strings.CutPrefix(r.URL.Path, "/media/"); ok && r.Method == http.MethodGet {
+		s.serveMedia(w, r, media)
+		return
+	}
+
+	if s.apiKey != "" && r.Header.Get("Authorization") != "Klaviyo-API-Key "+s.apiKey {
+		writeError(w, http.StatusUnauthorized, "not_authenticated", "Authentication credentials were not provided.", "Missing or invalid private key.", nil)
+		return
+	}
+
+	// Split before unescaping, so that IDs may hold escaped slashes, eg. catalog IDs
+	path, ok := strings.CutPrefix(r.URL.EscapedPath(), "/api/")
+	if !ok {
+		writeNotFound(w, r)
+		return
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segments[i] = unescaped
+		}
+	}
+
+	if s.serveSpecial(w, r, segments) {
+		return
+	}
+	s.serveGeneric(w, r, segments)
+}
+
+// Serves endpoints with behaviour of their own, eg. creating a campaign with its messages. Reports whether it did
+func (s *Server) serveSpecial(w http.ResponseWriter, r *http.Request, segments []string) bool {
+	collection := segments[0]
+	single := len(segments) == 2
+
+	switch {
+	case r.Method == http.MethodPost && len(segments) == 1:
+		handler, ok := map[string]http.HandlerFunc{
+			"campaigns":                          s.createCampaign,
+			"campaign-clone":                     s.cloneCampaign,
+			"campaign-send-jobs":                 s.createSendJob,
+			"campaign-recipient-estimation-jobs": s.createEstimationJob,
+			"campaign-message-assign-template":   s.assignTemplate,
+			"image-upload":                       s.uploadImage,
+			"images":                             s.importImage,
+			"catalog-item-bulk-create-jobs":      s.createBulkJob,
+			"catalog-item-bulk-update-jobs":      s.createBulkJob,
+			"catalog-item-bulk-delete-jobs":      s.createBulkJob,
+		}[collection]
+		if ok {
+			handler(w, r)
+		}
+		return ok
+	case r.Method == http.MethodPatch && single && collection == "campaign-send-jobs":
+		s.updateSendJob(w, r, segments[1])
+		return true
+	case r.Method == http.MethodGet && single && isJobCollection(collection):
+		s.pollJob(w, r, collection, segments[1])
+		return true
+	case r.Method == http.MethodGet && single && collection == "campaign-recipient-estimations":
+		s.getEstimation(w, r, segments[1])
+		return true
+	}
+
+	return false
+}
+
+// Serves create, read, update and delete of resources, related resources and relationships
+func (s *Server) serveGeneric(w http.ResponseWriter, r *http.Request, segments []string) {
+	resourceType, ok := typeOfCollection(segments[0])
+	if !ok {
+		writeNotFound(w, r)
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.writeList(w, r, resourceType, s.list(resourceType))
+	case len(segments) == 1 && r.Method == http.MethodPost:
+		s.create(w, r, resourceType)
+	case len(segments) == 2:
+		res := s.get(models.RelationshipData{Type: resourceType, ID: segments[1]})
+		if res == nil {
+			writeNotFound(w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			s.writeResource(w, r, http.StatusOK, res)
+		case http.MethodPatch:
+			s.update(w, r, res)
+		case http.MethodDelete:
+			s.remove(res)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+	case len(segments) == 3 && r.Method == http.MethodGet:
+		s.writeRelated(w, r, resourceType, segments[1], segments[2], false)
+	case len(segments) == 4 && segments[2] == "relationships" && r.Method == http.MethodGet:
+		s.writeRelated(w, r, resourceType, segments[1], segments[3], true)
+	case len(segments) <= 4:
+		writeMethodNotAllowed(w, r)
+	default:
+		writeNotFound(w, r)
+	}
+}
+
+// Writes related resources, or their linkage when `linkage` is set
+func (s *Server) writeRelated(w http.ResponseWriter, r *http.Request, resourceType string, id string, name string, linkage bool) {
+	res := s.get(models.RelationshipData{Type: resourceType, ID: id})
+	if res == nil {
+		writeNotFound(w, r)
+		return
+	}
+	rel, ok := schemas[resourceType].relationships[name]
+	if !ok {
+		writeNotFound(w, r)
+		return
+	}
+
+	related := s.resolve(res.Relationships[name])
+	switch {
+	case rel.toOne && linkage:
+		writeJSON(w, http.StatusOK, map[string]any{"data": linkageData(res, name), "links": map[string]any{"self": s.requestURL(r)}})
+	case rel.toOne && len(related) == 0:
+		writeJSON(w, http.StatusOK, map[string]any{"data": nil, "links": map[string]any{"self": s.requestURL(r)}})
+	case rel.toOne:
+		s.writeResource(w, r, http.StatusOK, related[0])
+	case linkage:
+		s.writeLinkage(w, r, rel.target, related)
+	default:
+		s.writeList(w, r, rel.target, related)
+	}
+}
+
+// Creates a resource from the `data` member of the request body
+func (s *Server) create(w http.ResponseWriter, r *http.Request, resourceType string) {
+	res, ok := s.decodeBody(w, r, resourceType)
+	if !ok {
+		return
+	}
+
+	if err := s.assignID(res); err != nil {
+		writeValidationError(w, err.Error(), "/data/attributes/external_id")
+		return
+	}
+	if s.get(res.identifier()) != nil {
+		writeError(w, http.StatusConflict, "conflict", "Conflict.", fmt.Sprintf("A %s with id %s already exists.", res.Type, res.ID), pointer("/data/id"))
+		return
+	}
+
+	s.stamp(res, true)
+	s.put(res)
+	s.writeResource(w, r, http.StatusCreated, res)
+}
+
+// Updates attributes and relationships sent in the request body
+func (s *Server) update(w http.ResponseWriter, r *http.Request, res *resource) {
+	patch, ok := s.decodeBody(w, r, res.Type)
+	if !ok {
+		return
+	}
+	if patch.ID != "" && patch.ID != res.ID {
+		writeError(w, http.StatusConflict, "conflict", "Conflict.", "The id in the body does not match the id in the URL.", pointer("/data/id"))
+		return
+	}
+
+	for key, value := range patch.Attributes {
+		res.Attributes[key] = value
+	}
+	s.stamp(res, false)
+	for name, linkage := range patch.Relationships {
+		s.setLinkage(res, name, linkage)
+	}
+
+	s.writeResource(w, r, http.StatusOK, res)
+}
+
+// Decodes the request body into a resource of `resourceType`. The resource object is read from the `data` member,
+// or from the body itself when it has none. Writes an error and returns false when the body is invalid
+func (s *Server) decodeBody(w http.ResponseWriter, r *http.Request, resourceType string) (*resource, bool) {
+	object, ok := s.decodeObject(w, r)
+	if !ok {
+		return nil, false
+	}
+	return s.resourceOf(w, object, resourceType)
+}
+
+// Decodes resource object `object` of `resourceType`. Writes an error and returns false when it is invalid
+func (s *Server) resourceOf(w http.ResponseWriter, object map[string]any, resourceType string) (*resource, bool) {
+	res, err := decodeResource(object)
+	if err != nil {
+		writeValidationError(w, err.Error(), "/data")
+		return nil, false
+	}
+	if res.Type == "" {
+		writeValidationError(w, "The type of the resource is required.", "/data/type")
+		return nil, false
+	}
+	if res.Type != resourceType {
+		writeError(w, http.StatusConflict, "conflict", "Conflict.", fmt.Sprintf("The type %s does not match the endpoint type %s.", res.Type, resourceType), pointer("/data/type"))
+		return nil, false
+	}
+
+	for _, attribute := range schemas[resourceType].writeOnly {
+		delete(res.Attributes, attribute)
+	}
+	for name := range res.Relationships {
+		if _, ok := schemas[resourceType].relationships[name]; !ok {
+			writeValidationError(w, fmt.Sprintf("%s is not a relationship of %s.", name, resourceType), "/data/relationships/"+name)
+			return nil, false
+		}
+	}
+	return res, true
+}
+
+// Decodes the resource object of the request body, the `data` member or the body itself when it has none
+func (s *Server) decodeObject(w http.ResponseWriter, r *http.Request) (map[string]any, bool) {
+	var body map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeValidationError(w, "The request body is not a JSON object.", "/")
+		return nil, false
+	}
+
+	if data, ok := member(body, "data").(map[string]any); ok {
+		return data, true
+	}
+	return body, true
+}
+
+// Sets timestamps declared by the schema of the resource
+func (s *Server) stamp(res *resource, created bool) {
+	now := timestamp(s.now())
+	schema := schemas[res.Type]
+
+	if created && schema.created != "" {
+		if _, ok := res.Attributes[schema.created]; !ok {
+			res.Attributes[schema.created] = now
+		}
+	}
+	if schema.updated != "" {
+		res.Attributes[schema.updated] = now
+	}
+}
+
+// Sets ID of a resource without one. Catalog items and variants get the compound ID of their external ID
+func (s *Server) assignID(res *resource) error {
+	if res.ID != "" {
+		return nil
+	}
+	if res.Type != "catalog-item" && res.Type != "catalog-variant" {
+		res.ID = s.newID()
+		return nil
+	}
+
+	id, err := catalogID(res.Attributes)
+	res.ID = id
+	return err
+}
+
+func (s *Server) newID() string {
+	s.seq++
+	return fmt.Sprintf("%06X", s.seq)
+}
+
+func (s *Server) serveMedia(w http.ResponseWriter, r *http.Request, name string) {
+	id, _, _ := strings.Cut(name, ".")
+	content, ok := s.media[id]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", http.DetectContentType(content))
+	w.Write(content)
+}
+
+func (s *Server) resourceURL(resourceType string, id string) string {
+	return fmt.Sprintf("%s/api/%ss/%s/", s.URL, resourceType, url.PathEscape(id))
+}
+
+func (s *Server) requestURL(r *http.Request) string {
+	return s.URL + r.URL.RequestURI()
+}
@@ -0,0 +1,254 @@
+package klaviyotest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	klaviyo "github.com/developertom01/klaviyo-go"
+	campaigns "github.com/developertom01/klaviyo-go/api/campaignsApi"
+	catalog "github.com/developertom01/klaviyo-go/api/catalogApi"
+	images "github.com/developertom01/klaviyo-go/api/imagesApi"
+	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/common/filter"
+	"github.com/developertom01/klaviyo-go/exceptions"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerTestSuite struct {
+	suite.Suite
+	server *Server
+}
+
+func (suite *ServerTestSuite) SetupTest() {
+	suite.server = NewServer()
+}
+
+func (suite *ServerTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *ServerTestSuite) createCampaign(name string, channel models.Channel) *models.CampaignResponse {
+	campaign, err := suite.server.Client().Campaigns.CreateCampaign(context.Background(), campaigns.CreateCampaignRequestData{
+		Data: campaigns.CreateCampaignData{
+			Type: "campaign",
+			Attributes: campaigns.CreateCampaignDataDataAttributes{
+				Name:      name,
+				Audiences: campaigns.CampaignDataAttributesAudiences{Included: []string{"LIST01"}},
+				CampaignMessages: campaigns.CampaignAttributesMessages{
+					Data: []campaigns.CampaignAttributesMessagesData{
+						{Type: "campaign-message", Attributes: campaigns.CreateCampaignMessagesDataAttributes{Channel: channel}},
+					},
+				},
+			},
+		},
+	})
+	suite.Require().NoError(err)
+
+	return campaign
+}
+
+// Sends a raw request and decodes the response body into `out`
+func (suite *ServerTestSuite) do(method string, path string, body any, out any) int {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		suite.Require().NoError(err)
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, suite.server.URL+path, reader)
+	suite.Require().NoError(err)
+	req.Header.Set("Authorization", "Klaviyo-API-Key "+API_KEY)
+
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+	defer res.Body.Close()
+
+	if out != nil {
+		suite.Require().NoError(json.NewDecoder(res.Body).Decode(out))
+	}
+	return res.StatusCode
+}
+
+func (suite *ServerTestSuite) TestCreateAndListCampaigns() {
+	created := suite.createCampaign("Spring sale", "email")
+	suite.createCampaign("Text blast", "sms")
+
+	suite.Equal("Draft", created.Data.Attributes.Status)
+	suite.Len(suite.server.CampaignMessages().List(), 2)
+
+	email, err := filter.Build(filter.Equals("messages.channel", filter.String("email")))
+	suite.Require().NoError(err)
+
+	list, err := suite.server.Client().Campaigns.GetCampaigns(context.Background(), email, &campaigns.GetCampaignsOptions{
+		Include: []models.CampaignIncludeField{models.CampaignIncludeFieldCampaignMessage},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Len(list.Data, 1)
+	suite.Equal(created.Data.ID, list.Data[0].ID)
+
+	messages := models.RelatedOf[models.CampaignMessage](list.Included, list.Data[0], "campaign-messages")
+	suite.Require().Len(messages, 1)
+	suite.Equal(models.ChannelEmail, messages[0].Attributes.Channel)
+}
+
+func (suite *ServerTestSuite) TestCloneCampaign() {
+	source := suite.createCampaign("Spring sale", "email")
+
+	clone, err := suite.server.Client().Campaigns.CreateCampaignClone(context.Background(), campaigns.CreateCampaignCloneRequestData{
+		Data: campaigns.CreateCampaignCloneData{Type: "campaign", ID: source.Data.ID},
+	})
+	suite.Require().NoError(err)
+
+	suite.NotEqual(source.Data.ID, clone.Data.ID)
+	suite.Equal("Spring sale (Clone)", clone.Data.Attributes.Name)
+	suite.Len(suite.server.CampaignMessages().List(), 2)
+}
+
+func (suite *ServerTestSuite) TestSendJobCompletesAfterPolls() {
+	suite.server.WithJobPolls(2)
+	campaign := suite.createCampaign("Spring sale", "email")
+	api := suite.server.Client().Campaigns
+
+	_, err := api.CreateCampaignSendJob(context.Background(), campaigns.CampaignSendCreationJobPayload{Type: "campaign-send-job", ID: campaign.Data.ID})
+	suite.Require().NoError(err)
+
+	var statuses []string
+	job, err := common.WaitForJob(context.Background(), func(ctx context.Context) (*models.CampaignSendJobResponse, error) {
+		return api.GetCampaignSendJob(ctx, campaign.Data.ID, nil)
+	}, &common.WaitOptions{Interval: time.Millisecond, OnProgress: func(progress models.JobProgress) {
+		statuses = append(statuses, progress.Status)
+	}})
+	suite.Require().NoError(err)
+	suite.Equal(models.CampaignSendJobStatusCompleted, job.Attributes.Status)
+	suite.Equal([]string{statusProcessing, string(models.CampaignSendJobStatusCompleted)}, statuses)
+
+	sent, ok := suite.server.Campaigns().Get(campaign.Data.ID)
+	suite.True(ok)
+	suite.Equal("Sent", sent.Attributes.Status)
+}
+
+func (suite *ServerTestSuite) TestErrors() {
+	_, err := suite.server.Client().Campaigns.GetCampaign(context.Background(), "MISSING", "", nil)
+	suite.ErrorIs(err, exceptions.ErrNotFound)
+
+	var response exceptions.ApiErrorResponse
+	status := suite.do(http.MethodGet, "/api/images/?filter="+url.QueryEscape("equals(unknown,1)"), nil, &response)
+	suite.Equal(http.StatusBadRequest, status)
+	suite.Require().Len(response.Errors, 1)
+	suite.Equal("filter", *response.Errors[0].Source.Parameter)
+
+	client := klaviyo.NewKlaviyoApi(suite.server.Options().WithApiKey("pk_other"), &common.RetryOptions{MaxRetries: 1})
+	_, err = client.Images.GetImages(context.Background(), "", nil)
+	suite.ErrorIs(err, exceptions.ErrUnauthorized)
+}
+
+func (suite *ServerTestSuite) TestImagePages() {
+	for i := 0; i < 3; i++ {
+		suite.server.Images().Put(models.Image{Type: "image", Attributes: models.ImageAttributes{Name: fmt.Sprintf("image-%d", i), Format: "png"}})
+	}
+
+	size := 2
+	options := &images.GetImagesOptions{PageSize: &size, Sort: models.SortBy(models.ImageSortFieldNameDESC)}
+	first, err := suite.server.Client().Images.GetImages(context.Background(), "", options)
+	suite.Require().NoError(err)
+	suite.Require().Len(first.Data, 2)
+	suite.Equal("image-2", first.Data[0].Attributes.Name)
+	suite.Require().NotNil(first.Links.Next)
+
+	next, err := url.Parse(*first.Links.Next)
+	suite.Require().NoError(err)
+	cursor := next.Query().Get("page[cursor]")
+	options.PageCursor = &cursor
+
+	second, err := suite.server.Client().Images.GetImages(context.Background(), "", options)
+	suite.Require().NoError(err)
+	suite.Require().Len(second.Data, 1)
+	suite.Equal("image-0", second.Data[0].Attributes.Name)
+	suite.Nil(second.Links.Next)
+}
+
+func (suite *ServerTestSuite) TestUploadImage() {
+	var content bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, color.White)
+	suite.Require().NoError(png.Encode(&content, img))
+
+	name := "logo"
+	uploaded, err := suite.server.Client().Images.UploadImageFromFile(context.Background(), bytes.NewReader(content.Bytes()), images.UploadImageFromFilePayload{Name: &name})
+	suite.Require().NoError(err)
+	suite.Equal("png", uploaded.Data.Attributes.Format)
+	suite.Equal(content.Len(), uploaded.Data.Attributes.Size)
+
+	res, err := http.Get(uploaded.Data.Attributes.ImageUrl)
+	suite.Require().NoError(err)
+	defer res.Body.Close()
+	served, err := io.ReadAll(res.Body)
+	suite.Require().NoError(err)
+	suite.Equal(content.Bytes(), served)
+
+	_, err = suite.server.Client().Images.UploadImageFromFile(context.Background(), strings.NewReader("not an image"), images.UploadImageFromFilePayload{Name: &name})
+	suite.ErrorIs(err, images.ErrUnsupportedImageFormat)
+}
+
+func (suite *ServerTestSuite) TestBulkCreateItems() {
+	suite.server.CatalogItems().Put(models.CatalogItem{Type: "catalog-item", ID: "$custom:::$default:::SKU-1"})
+
+	payload := catalog.SpawnCreateItemsJobPayload{
+		Data: catalog.SpawnCreateItemsJobPayloadData{Type: "catalog-item-bulk-create-job"},
+	}
+	for _, externalID := range []string{"SKU-1", "SKU-2"} {
+		payload.Data.Attributes.Data = append(payload.Data.Attributes.Data, catalog.CreateCatalogItemPayload{
+			Data: catalog.CreateCatalogItemPayloadData{
+				Type:       "catalog-item",
+				Attributes: catalog.CreateCatalogItemAttributesPayload{ExternalId: externalID, Title: externalID},
+			},
+		})
+	}
+
+	job, err := suite.server.Client().Catalog.SpawnCreateItemsJob(context.Background(), payload)
+	suite.Require().NoError(err)
+	suite.Equal(models.CatalogItemBulkJobStatus(statusQueued), job.Data.Attributes.Status)
+
+	job, err = suite.server.Client().Catalog.GetCreateItemsJob(context.Background(), job.Data.ID, nil)
+	suite.Require().NoError(err)
+	suite.Equal(models.CatalogItemBulkJobStatusComplete, job.Data.Attributes.Status)
+	suite.EqualValues(1, *job.Data.Attributes.CompletedCount)
+	suite.Require().Len(job.Data.Attributes.Errors, 1)
+	suite.Equal("/data/attributes/items/data/0", *job.Data.Attributes.Errors[0].Source.Pointer)
+
+	_, ok := suite.server.CatalogItems().Get("$custom:::$default:::SKU-2")
+	suite.True(ok)
+}
+
+func (suite *ServerTestSuite) TestLinkKeepsInverseInSync() {
+	flow := suite.server.Flows().Put(models.Flow{Type: "flow"})
+	action := suite.server.FlowActions().Put(models.FlowAction{Type: "flow-action"})
+
+	err := suite.server.Link(models.RelationshipData{Type: "flow-action", ID: action.ID}, "flow", models.RelationshipData{Type: "flow", ID: flow.ID})
+	suite.Require().NoError(err)
+
+	linkage, err := suite.server.Client().Flows.GetFlowRelationshipsFlowActions(context.Background(), flow.ID, nil, nil)
+	suite.Require().NoError(err)
+	suite.Require().Len(linkage.Data, 1)
+	suite.Equal(action.ID, linkage.Data[0].ID)
+
+	suite.True(suite.server.Flows().Delete(flow.ID))
+	suite.Error(suite.server.Link(models.RelationshipData{Type: "flow", ID: flow.ID}, "flow-actions"))
+}
+
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
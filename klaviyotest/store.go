@@ -0,0 +1,288 @@
+package klaviyotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/developertom01/klaviyo-go/models"
+)
+
+// Typed view of the resources of one type held by a Server, eg. campaigns as models.Campaign
+type Store[T any] struct {
+	server       *Server
+	resourceType string
+}
+
+// Returns store of resources of `resourceType` decoded into T, eg.
+// StoreOf[models.CampaignRecipientCountData](server, "campaign-recipient-estimation")
+func StoreOf[T any](server *Server, resourceType string) Store[T] {
+	return Store[T]{server: server, resourceType: resourceType}
+}
+
+func (s *Server) Campaigns() Store[models.Campaign] {
+	return StoreOf[models.Campaign](s, "campaign")
+}
+
+func (s *Server) CampaignMessages() Store[models.CampaignMessage] {
+	return StoreOf[models.CampaignMessage](s, "campaign-message")
+}
+
+func (s *Server) SendJobs() Store[models.CampaignSendJob] {
+	return StoreOf[models.CampaignSendJob](s, "campaign-send-job")
+}
+
+func (s *Server) Templates() Store[models.Template] {
+	return StoreOf[models.Template](s, "template")
+}
+
+func (s *Server) Tags() Store[models.Tag] {
+	return StoreOf[models.Tag](s, "tag")
+}
+
+func (s *Server) Flows() Store[models.Flow] {
+	return StoreOf[models.Flow](s, "flow")
+}
+
+func (s *Server) FlowActions() Store[models.FlowAction] {
+	return StoreOf[models.FlowAction](s, "flow-action")
+}
+
+func (s *Server) FlowMessages() Store[models.FlowMessage] {
+	return StoreOf[models.FlowMessage](s, "flow-message")
+}
+
+func (s *Server) CatalogItems() Store[models.CatalogItem] {
+	return StoreOf[models.CatalogItem](s, "catalog-item")
+}
+
+func (s *Server) CatalogVariants() Store[models.CatalogVariant] {
+	return StoreOf[models.CatalogVariant](s, "catalog-variant")
+}
+
+func (s *Server) Images() Store[models.Image] {
+	return StoreOf[models.Image](s, "image")
+}
+
+func (s *Server) Accounts() Store[models.Account] {
+	return StoreOf[models.Account](s, "account")
+}
+
+// Adds `resource` or replaces the one with its ID, and returns it as served. A resource without ID gets a generated one.
+// Relationships sent with linkage replace the stored linkage, other relationships are kept
+func (st Store[T]) Put(resource T) T {
+	value, err := toValue(resource)
+	object, ok := value.(map[string]any)
+	if err != nil || !ok {
+		panic(fmt.Sprintf("klaviyotest: %T is not a resource object: %v", resource, err))
+	}
+
+	res, err := decodeResource(object)
+	if err != nil {
+		panic(fmt.Sprintf("klaviyotest: %v", err))
+	}
+	res.Type = st.resourceType
+
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	if err := st.server.assignID(res); err != nil {
+		panic(fmt.Sprintf("klaviyotest: %v", err))
+	}
+	st.server.put(res)
+
+	return st.decode(res)
+}
+
+// Returns resource with `id`
+func (st Store[T]) Get(id string) (T, bool) {
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	res := st.server.get(models.RelationshipData{Type: st.resourceType, ID: id})
+	if res == nil {
+		var zero T
+		return zero, false
+	}
+	return st.decode(res), true
+}
+
+// Returns resources in the order they were added
+func (st Store[T]) List() []T {
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	resources := st.server.list(st.resourceType)
+	list := make([]T, 0, len(resources))
+	for _, res := range resources {
+		list = append(list, st.decode(res))
+	}
+	return list
+}
+
+// Deletes resource with `id`. Reports whether it was found
+func (st Store[T]) Delete(id string) bool {
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	res := st.server.get(models.RelationshipData{Type: st.resourceType, ID: id})
+	if res == nil {
+		return false
+	}
+	st.server.remove(res)
+	return true
+}
+
+func (st Store[T]) Len() int {
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	return len(st.server.resources[st.resourceType])
+}
+
+func (st Store[T]) decode(res *resource) T {
+	var model T
+
+	byteData, err := json.Marshal(st.server.render(res, nil))
+	if err == nil {
+		err = json.Unmarshal(byteData, &model)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("klaviyotest: decoding %s %s into %T: %v", res.Type, res.ID, model, err))
+	}
+
+	return model
+}
+
+func (s *Server) get(id models.RelationshipData) *resource {
+	return s.resources[id.Type][id.ID]
+}
+
+// Returns resources of `resourceType` in the order they were added
+func (s *Server) list(resourceType string) []*resource {
+	resources := make([]*resource, 0, len(s.resources[resourceType]))
+	for _, res := range s.resources[resourceType] {
+		resources = append(resources, res)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].seq < resources[j].seq })
+
+	return resources
+}
+
+// Returns the stored resources of `ids`, skipping missing ones
+func (s *Server) resolve(ids []models.RelationshipData) []*resource {
+	resources := make([]*resource, 0, len(ids))
+	for _, id := range ids {
+		if res := s.get(id); res != nil {
+			resources = append(resources, res)
+		}
+	}
+	return resources
+}
+
+// Stores `res`, replacing the resource with its type and ID. Linkage of `res` replaces the stored linkage.
+// A new resource gets the linkage of inverse relationships from resources pointing at it
+func (s *Server) put(res *resource) {
+	provided := res.Relationships
+	res.Relationships = map[string][]models.RelationshipData{}
+
+	if existing := s.get(res.identifier()); existing != nil {
+		res.seq = existing.seq
+		res.Relationships = existing.Relationships
+	} else {
+		s.seq++
+		res.seq = s.seq
+		s.deriveLinkage(res)
+	}
+
+	if s.resources[res.Type] == nil {
+		s.resources[res.Type] = map[string]*resource{}
+	}
+	s.resources[res.Type][res.ID] = res
+
+	for name, linkage := range provided {
+		s.setLinkage(res, name, linkage)
+	}
+}
+
+func (s *Server) deriveLinkage(res *resource) {
+	for name, rel := range schemas[res.Type].relationships {
+		if rel.inverse == "" {
+			continue
+		}
+		for _, other := range s.list(rel.target) {
+			if slices.Contains(other.Relationships[rel.inverse], res.identifier()) {
+				res.Relationships[name] = append(res.Relationships[name], other.identifier())
+			}
+		}
+	}
+}
+
+// Deletes `res` and linkage pointing at it
+func (s *Server) remove(res *resource) {
+	delete(s.resources[res.Type], res.ID)
+	delete(s.media, res.ID)
+
+	for _, resources := range s.resources {
+		for _, other := range resources {
+			for name := range other.Relationships {
+				s.removeLinkage(other, name, res.identifier())
+			}
+		}
+	}
+}
+
+// Replaces linkage of relationship `name` and updates the inverse relationship of old and new targets
+func (s *Server) setLinkage(res *resource, name string, linkage []models.RelationshipData) {
+	rel, declared := schemas[res.Type].relationships[name]
+	if declared && rel.toOne && len(linkage) > 1 {
+		linkage = linkage[:1]
+	}
+
+	previous := res.Relationships[name]
+	res.Relationships[name] = slices.Clone(linkage)
+	if !declared || rel.inverse == "" {
+		return
+	}
+
+	for _, id := range previous {
+		if target := s.get(id); target != nil && !slices.Contains(linkage, id) {
+			s.removeLinkage(target, rel.inverse, res.identifier())
+		}
+	}
+	for _, id := range linkage {
+		if target := s.get(id); target != nil {
+			s.addLinkage(target, rel.inverse, res.identifier())
+		}
+	}
+}
+
+// Adds `id` to relationship `name` of `res`. A to-one relationship pointing elsewhere is moved,
+// removing `res` from the inverse relationship of its previous target
+func (s *Server) addLinkage(res *resource, name string, id models.RelationshipData) {
+	if slices.Contains(res.Relationships[name], id) {
+		return
+	}
+
+	rel := schemas[res.Type].relationships[name]
+	if !rel.toOne {
+		res.Relationships[name] = append(res.Relationships[name], id)
+		return
+	}
+
+	for _, previous := range res.Relationships[name] {
+		if target := s.get(previous); target != nil && rel.inverse != "" {
+			s.removeLinkage(target, rel.inverse, res.identifier())
+		}
+	}
+	res.Relationships[name] = []models.RelationshipData{id}
+}
+
+func (s *Server) removeLinkage(res *resource, name string, id models.RelationshipData) {
+	linkage, ok := res.Relationships[name]
+	if !ok {
+		return
+	}
+	res.Relationships[name] = slices.DeleteFunc(slices.Clone(linkage), func(item models.RelationshipData) bool { return item == id })
+}
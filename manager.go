@@ -0,0 +1,281 @@
+package klaviyo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/options"
+)
+
+var ErrAccountNotFound = errors.New("Klaviyo account not registered")
+
+type (
+	// Credentials and limits of a single Klaviyo account
+	AccountConfig struct {
+		ApiKey       string               //Private API key. Ignored when Session is set
+		Session      common.Session       //Optional session, eg. common.OAuthSession
+		RetryOptions *common.RetryOptions //Defaults to ClientManagerOptions.RetryOptions
+		RateLimit    *RateLimit           //Defaults to ClientManagerOptions.RateLimit
+	}
+
+	// Client side request rate of one account
+	RateLimit struct {
+		RequestsPerSecond float64 //Requests are not limited when 0 or less
+		Burst             int
+	}
+
+	// Loads the configuration of accounts that were not registered, eg. from a database.
+	// Return ErrAccountNotFound for unknown accounts
+	AccountResolver func(ctx context.Context, accountId string) (AccountConfig, error)
+
+	ClientManagerOptions struct {
+		HttpClient   common.HTTPClient    //Shared by all accounts. Defaults to a client with a pooled transport
+		Options      options.Options      //Revision, base URL, user agent, timeout, logger and instrumentation applied to every account
+		RetryOptions *common.RetryOptions //Default retry options
+		RateLimit    *RateLimit           //Default rate limit. Unlimited when nil
+		Resolver     AccountResolver      //Optional
+	}
+
+	// Lazily creates and caches one KlaviyoApi per account.
+	// All accounts share one connection pool while rate limits and retries are kept per account. Safe for concurrent use
+	ClientManager struct {
+		opt ClientManagerOptions
+
+		mu      sync.Mutex
+		configs map[string]AccountConfig
+		clients map[string]*KlaviyoApi
+	}
+
+	// Called for every account by ForEachAccount
+	AccountFunc func(ctx context.Context, accountId string, api *KlaviyoApi) error
+
+	// Errors of a fan-out keyed by account ID
+	AccountErrors map[string]error
+)
+
+func NewClientManager(opt ClientManagerOptions) *ClientManager {
+	if opt.Options == nil {
+		opt.Options = options.NewOptionsWithDefaultValues()
+	}
+	if opt.HttpClient == nil {
+		opt.HttpClient = opt.Options.HTTPClient()
+	}
+	if opt.HttpClient == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConnsPerHost = 100
+		opt.HttpClient = &http.Client{Transport: transport}
+	}
+
+	return &ClientManager{
+		opt:     opt,
+		configs: make(map[string]AccountConfig),
+		clients: make(map[string]*KlaviyoApi),
+	}
+}
+
+// Registers or replaces an account. A cached client of the account is discarded
+func (m *ClientManager) Register(accountId string, config AccountConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.configs[accountId] = config
+	delete(m.clients, accountId)
+}
+
+// Removes an account and its cached client
+func (m *ClientManager) Remove(accountId string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.configs, accountId)
+	delete(m.clients, accountId)
+}
+
+// Returns IDs of registered and resolved accounts in ascending order
+func (m *ClientManager) Accounts() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	accountIds := make([]string, 0, len(m.configs))
+	for accountId := range m.configs {
+		accountIds = append(accountIds, accountId)
+	}
+	sort.Strings(accountIds)
+
+	return accountIds
+}
+
+// Returns the client of `accountId`, creating it on first use
+func (m *ClientManager) Client(ctx context.Context, accountId string) (*KlaviyoApi, error) {
+	m.mu.Lock()
+	if api, ok := m.clients[accountId]; ok {
+		m.mu.Unlock()
+		return api, nil
+	}
+	config, ok := m.configs[accountId]
+	m.mu.Unlock()
+
+	if !ok {
+		if m.opt.Resolver == nil {
+			return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, accountId)
+		}
+
+		var err error
+		config, err = m.opt.Resolver(ctx, accountId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Another goroutine created the client while the account was resolved
+	if api, ok := m.clients[accountId]; ok {
+		return api, nil
+	}
+
+	api := m.newClient(config)
+	m.configs[accountId] = config
+	m.clients[accountId] = api
+
+	return api, nil
+}
+
+func (m *ClientManager) newClient(config AccountConfig) *KlaviyoApi {
+	retryOptions := config.RetryOptions
+	if retryOptions == nil {
+		retryOptions = m.opt.RetryOptions
+	}
+
+	session := config.Session
+	if session == nil {
+		opt := options.NewOptions().
+			WithRevision(m.opt.Options.Revision()).
+			WithApiKey(config.ApiKey).
+			WithBaseUrl(m.opt.Options.BaseUrl()).
+			WithUserAgent(m.opt.Options.UserAgent()).
+			WithTimeout(m.opt.Options.Timeout()).
+			WithLogger(m.opt.Options.Logger()).
+			WithInstrumentation(m.opt.Options.Instrumentation()).
+			WithFilterValidation(m.opt.Options.FilterValidation())
+		session = common.NewApiKeySession(opt, retryOptions)
+	}
+
+	var httpClient = m.opt.HttpClient
+
+	rateLimit := config.RateLimit
+	if rateLimit == nil {
+		rateLimit = m.opt.RateLimit
+	}
+	if rateLimit != nil {
+		httpClient = common.NewRateLimitedClient(httpClient, common.NewRateLimiter(rateLimit.RequestsPerSecond, rateLimit.Burst))
+	}
+
+	return newKlaviyoApi(session, httpClient)
+}
+
+// Runs `fn` for every registered account with at most `concurrency` calls in flight.
+// Returns AccountErrors holding the error of every failed account
+func (m *ClientManager) ForEachAccount(ctx context.Context, concurrency int, fn AccountFunc) error {
+	_, err := FanOut(ctx, m, m.Accounts(), concurrency, func(ctx context.Context, accountId string, api *KlaviyoApi) (struct{}, error) {
+		return struct{}{}, fn(ctx, accountId, api)
+	})
+
+	return err
+}
+
+// Runs `fn` for each of `accountIds` with at most `concurrency` calls in flight and collects results of successful accounts.
+// Accounts not started before `ctx` is done fail with the context error. Returns AccountErrors when any account failed
+func FanOut[T any](ctx context.Context, m *ClientManager, accountIds []string, concurrency int, fn func(ctx context.Context, accountId string, api *KlaviyoApi) (T, error)) (map[string]T, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]T)
+		errs    = make(AccountErrors)
+		sem     = make(chan struct{}, concurrency)
+	)
+
+	record := func(accountId string, result T, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			errs[accountId] = err
+			return
+		}
+		results[accountId] = result
+	}
+
+	for _, accountId := range accountIds {
+		if err := ctx.Err(); err != nil {
+			var zero T
+			record(accountId, zero, err)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			record(accountId, zero, ctx.Err())
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(accountId string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var result T
+			api, err := m.Client(ctx, accountId)
+			if err == nil {
+				result, err = fn(ctx, accountId, api)
+			}
+			record(accountId, result, err)
+		}(accountId)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return results, errs
+	}
+
+	return results, nil
+}
+
+func (errs AccountErrors) Error() string {
+	accountIds := make([]string, 0, len(errs))
+	for accountId := range errs {
+		accountIds = append(accountIds, accountId)
+	}
+	slices.Sort(accountIds)
+
+	messages := make([]string, 0, len(accountIds))
+	for _, accountId := range accountIds {
+		messages = append(messages, fmt.Sprintf("%s: %v", accountId, errs[accountId]))
+	}
+
+	return fmt.Sprintf("%d account(s) failed: %s", len(errs), strings.Join(messages, "; "))
+}
+
+// Supports errors.Is and errors.As against the error of any account
+func (errs AccountErrors) Unwrap() []error {
+	unwrapped := make([]error, 0, len(errs))
+	for _, err := range errs {
+		unwrapped = append(unwrapped, err)
+	}
+
+	return unwrapped
+}
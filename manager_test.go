@@ -0,0 +1,164 @@
+package klaviyo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/developertom01/klaviyo-go/common"
+	"github.com/developertom01/klaviyo-go/options"
+	"github.com/stretchr/testify/suite"
+)
+
+type recordingHTTPClient struct {
+	mu          sync.Mutex
+	authHeaders []string
+	status      func(req *http.Request) int
+}
+
+func (c *recordingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.authHeaders = append(c.authHeaders, req.Header.Get("Authorization"))
+	c.mu.Unlock()
+
+	status := http.StatusOK
+	if c.status != nil {
+		status = c.status(req)
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(`{"data":[]}`)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+type ClientManagerTestSuite struct {
+	suite.Suite
+	httpClient *recordingHTTPClient
+	manager    *ClientManager
+}
+
+func (suit *ClientManagerTestSuite) SetupTest() {
+	suit.httpClient = &recordingHTTPClient{}
+	suit.manager = NewClientManager(ClientManagerOptions{
+		HttpClient:   suit.httpClient,
+		RetryOptions: &common.RetryOptions{MaxRetries: 1},
+	})
+}
+
+func (suit *ClientManagerTestSuite) TestClientIsCachedPerAccount() {
+	suit.manager.Register("a", AccountConfig{ApiKey: "key-a"})
+	suit.manager.Register("b", AccountConfig{ApiKey: "key-b"})
+
+	first, err := suit.manager.Client(context.Background(), "a")
+	suit.NoError(err)
+	second, err := suit.manager.Client(context.Background(), "a")
+	suit.NoError(err)
+	other, err := suit.manager.Client(context.Background(), "b")
+	suit.NoError(err)
+
+	suit.Same(first, second)
+	suit.NotSame(first, other)
+	suit.Equal([]string{"a", "b"}, suit.manager.Accounts())
+}
+
+func (suit *ClientManagerTestSuite) TestUnknownAccount() {
+	_, err := suit.manager.Client(context.Background(), "missing")
+
+	suit.ErrorIs(err, ErrAccountNotFound)
+}
+
+func (suit *ClientManagerTestSuite) TestResolverLoadsAccountOnce() {
+	var calls atomic.Int32
+	manager := NewClientManager(ClientManagerOptions{
+		HttpClient: suit.httpClient,
+		Resolver: func(ctx context.Context, accountId string) (AccountConfig, error) {
+			calls.Add(1)
+			return AccountConfig{ApiKey: "key-" + accountId}, nil
+		},
+	})
+
+	_, err := manager.Client(context.Background(), "resolved")
+	suit.NoError(err)
+	_, err = manager.Client(context.Background(), "resolved")
+	suit.NoError(err)
+
+	suit.Equal(int32(1), calls.Load())
+	suit.Equal([]string{"resolved"}, manager.Accounts())
+}
+
+func (suit *ClientManagerTestSuite) TestForEachAccountUsesAccountCredentials() {
+	suit.manager.Register("a", AccountConfig{ApiKey: "key-a"})
+	suit.manager.Register("b", AccountConfig{ApiKey: "key-b"})
+
+	err := suit.manager.ForEachAccount(context.Background(), 2, func(ctx context.Context, accountId string, api *KlaviyoApi) error {
+		_, err := api.Accounts.GetAccounts(ctx, nil)
+		return err
+	})
+
+	suit.NoError(err)
+	suit.ElementsMatch([]string{"Klaviyo-API-Key key-a", "Klaviyo-API-Key key-b"}, suit.httpClient.authHeaders)
+}
+
+func (suit *ClientManagerTestSuite) TestFanOutCollectsResultsAndErrors() {
+	suit.manager.Register("ok", AccountConfig{ApiKey: "key-ok"})
+	suit.manager.Register("bad", AccountConfig{ApiKey: "key-bad"})
+	failure := errors.New("failed")
+
+	results, err := FanOut(context.Background(), suit.manager, []string{"ok", "bad", "missing"}, 1, func(ctx context.Context, accountId string, api *KlaviyoApi) (string, error) {
+		if accountId == "bad" {
+			return "", failure
+		}
+		return accountId, nil
+	})
+
+	suit.Equal(map[string]string{"ok": "ok"}, results)
+
+	var accountErrors AccountErrors
+	suit.ErrorAs(err, &accountErrors)
+	suit.Len(accountErrors, 2)
+	suit.ErrorIs(err, failure)
+	suit.ErrorIs(err, ErrAccountNotFound)
+}
+
+func (suit *ClientManagerTestSuite) TestFanOutCancelledContext() {
+	suit.manager.Register("a", AccountConfig{ApiKey: "key-a"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := FanOut(ctx, suit.manager, []string{"a"}, 1, func(ctx context.Context, accountId string, api *KlaviyoApi) (struct{}, error) {
+		return struct{}{}, nil
+	})
+
+	suit.ErrorIs(err, context.Canceled)
+}
+
+func (suit *ClientManagerTestSuite) TestRateLimitAppliesToCallHTTPClient() {
+	suit.manager.Register("a", AccountConfig{ApiKey: "key-a", RateLimit: &RateLimit{RequestsPerSecond: 0.001, Burst: 1}})
+	api, err := suit.manager.Client(context.Background(), "a")
+	suit.Require().NoError(err)
+
+	callClient := &recordingHTTPClient{}
+	ctx := common.WithRequestOptions(context.Background(), options.NewOptions().WithHTTPClient(callClient))
+	_, err = api.Accounts.GetAccounts(ctx, nil)
+	suit.NoError(err)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = api.Accounts.GetAccounts(ctx, nil)
+
+	suit.ErrorIs(err, context.DeadlineExceeded)
+	suit.Len(callClient.authHeaders, 1)
+	suit.Empty(suit.httpClient.authHeaders)
+}
+
+func TestClientManagerTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientManagerTestSuite))
+}
@@ -0,0 +1,39 @@
+// Code generated by mocks/internal/mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	accounts "github.com/developertom01/klaviyo-go/api/accountsApi"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock of accounts.AccountsApi
+type AccountsApi struct {
+	mock.Mock
+}
+
+var _ accounts.AccountsApi = (*AccountsApi)(nil)
+
+// Returns mock of accounts.AccountsApi asserting its expectations when the test ends
+func NewAccountsApi(t TestingT) *AccountsApi {
+	m := &AccountsApi{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+
+	return m
+}
+
+func (_m *AccountsApi) GetAccount(ctx context.Context, id string, accountFields models.Fields[models.AccountsField]) (*models.AccountResponse, error) {
+	ret := _m.Called(ctx, id, accountFields)
+
+	return value[*models.AccountResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *AccountsApi) GetAccounts(ctx context.Context, accountFields models.Fields[models.AccountsField]) (*models.AccountsCollectionResponse, error) {
+	ret := _m.Called(ctx, accountFields)
+
+	return value[*models.AccountsCollectionResponse](ret, 0), ret.Error(1)
+}
@@ -0,0 +1,165 @@
+// Code generated by mocks/internal/mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	campaigns "github.com/developertom01/klaviyo-go/api/campaignsApi"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock of campaigns.CampaignsApi
+type CampaignsApi struct {
+	mock.Mock
+}
+
+var _ campaigns.CampaignsApi = (*CampaignsApi)(nil)
+
+// Returns mock of campaigns.CampaignsApi asserting its expectations when the test ends
+func NewCampaignsApi(t TestingT) *CampaignsApi {
+	m := &CampaignsApi{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+
+	return m
+}
+
+func (_m *CampaignsApi) AssignCampaignMessageTemplate(ctx context.Context, payload campaigns.AssignCampaignMessageTemplatePayload) (*models.CampaignMessageResponse, error) {
+	ret := _m.Called(ctx, payload)
+
+	return value[*models.CampaignMessageResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) CreateCampaign(ctx context.Context, data campaigns.CreateCampaignRequestData) (*models.CampaignResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	return value[*models.CampaignResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) CreateCampaignClone(ctx context.Context, data campaigns.CreateCampaignCloneRequestData) (*models.CampaignResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	return value[*models.CampaignResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) CreateCampaignRecipientEstimationJob(ctx context.Context, payload campaigns.CampaignSendCreationJobPayload) (*models.CampaignSendJobResponse, error) {
+	ret := _m.Called(ctx, payload)
+
+	return value[*models.CampaignSendJobResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) CreateCampaignSendJob(ctx context.Context, payload campaigns.CampaignSendCreationJobPayload) (*models.CampaignSendJobResponse, error) {
+	ret := _m.Called(ctx, payload)
+
+	return value[*models.CampaignSendJobResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) DeleteCampaigns(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	return ret.Error(0)
+}
+
+func (_m *CampaignsApi) GetCampaign(ctx context.Context, id string, filter string, options *campaigns.GetCampaignsOptions) (*models.CampaignResponse, error) {
+	ret := _m.Called(ctx, id, filter, options)
+
+	return value[*models.CampaignResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) GetCampaignMessage(ctx context.Context, messageId string, options *campaigns.GetCampaignMessageOptions) (*models.CampaignMessageResponse, error) {
+	ret := _m.Called(ctx, messageId, options)
+
+	return value[*models.CampaignMessageResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) GetCampaignMessageCampaign(ctx context.Context, messageId string, campaignFields models.Fields[models.CampaignsField]) (*models.CampaignResponse, error) {
+	ret := _m.Called(ctx, messageId, campaignFields)
+
+	return value[*models.CampaignResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) GetCampaignMessageRelationshipsCampaign(ctx context.Context, messageId string) (*models.RelationshipData, error) {
+	ret := _m.Called(ctx, messageId)
+
+	return value[*models.RelationshipData](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) GetCampaignMessageRelationshipsTemplate(ctx context.Context, messageId string) (*models.RelationshipData, error) {
+	ret := _m.Called(ctx, messageId)
+
+	return value[*models.RelationshipData](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) GetCampaignMessageTemplate(ctx context.Context, messageId string, templateFields models.Fields[models.TemplateField]) (*models.TemplateResponse, error) {
+	ret := _m.Called(ctx, messageId, templateFields)
+
+	return value[*models.TemplateResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) GetCampaignMessages(ctx context.Context, campaignId string, options *campaigns.GetCampaignMessagesOptions) (*models.CampaignMessageCollectionResponse, error) {
+	ret := _m.Called(ctx, campaignId, options)
+
+	return value[*models.CampaignMessageCollectionResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) GetCampaignRecipientEstimation(ctx context.Context, id string, fields models.Fields[models.CampaignRecipientEstimationField]) (*models.CampaignRecipientCountResponse, error) {
+	ret := _m.Called(ctx, id, fields)
+
+	return value[*models.CampaignRecipientCountResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) GetCampaignRecipientEstimationJob(ctx context.Context, campaignId string, jobFields models.Fields[models.CampaignSendJobField]) (*models.CampaignSendJobResponse, error) {
+	ret := _m.Called(ctx, campaignId, jobFields)
+
+	return value[*models.CampaignSendJobResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) GetCampaignRelationshipsCampaignMessages(ctx context.Context, campaignId string) (*models.RelationshipDataCollection, error) {
+	ret := _m.Called(ctx, campaignId)
+
+	return value[*models.RelationshipDataCollection](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) GetCampaignRelationshipsTags(ctx context.Context, campaignId string) (*models.RelationshipDataCollection, error) {
+	ret := _m.Called(ctx, campaignId)
+
+	return value[*models.RelationshipDataCollection](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) GetCampaignSendJob(ctx context.Context, jobId string, jobFields models.Fields[models.CampaignSendJobField]) (*models.CampaignSendJobResponse, error) {
+	ret := _m.Called(ctx, jobId, jobFields)
+
+	return value[*models.CampaignSendJobResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) GetCampaignTags(ctx context.Context, campaignId string, tagFields models.Fields[models.TagField]) (*models.TagsCollectionResponse, error) {
+	ret := _m.Called(ctx, campaignId, tagFields)
+
+	return value[*models.TagsCollectionResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) GetCampaigns(ctx context.Context, filter string, options *campaigns.GetCampaignsOptions) (*models.CampaignsCollectionResponse, error) {
+	ret := _m.Called(ctx, filter, options)
+
+	return value[*models.CampaignsCollectionResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) UpdateCampaignMessage(ctx context.Context, messageId string, payload campaigns.UpdateCampaignMessagePayload) (*models.CampaignMessageResponse, error) {
+	ret := _m.Called(ctx, messageId, payload)
+
+	return value[*models.CampaignMessageResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) UpdateCampaignSendJob(ctx context.Context, jobId string, payload campaigns.UpdateCampaignSendJobPayload) (*models.CampaignSendJobResponse, error) {
+	ret := _m.Called(ctx, jobId, payload)
+
+	return value[*models.CampaignSendJobResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *CampaignsApi) UpdateCampaigns(ctx context.Context, id string, data campaigns.CreateCampaignRequestData) (*models.CampaignResponse, error) {
+	ret := _m.Called(ctx, id, data)
+
+	return value[*models.CampaignResponse](ret, 0), ret.Error(1)
+}
@@ -0,0 +1,141 @@
+// Code generated by mocks/internal/mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	catalog "github.com/developertom01/klaviyo-go/api/catalogApi"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock of catalog.CatalogApi
+type CatalogApi struct {
+	mock.Mock
+}
+
+var _ catalog.CatalogApi = (*CatalogApi)(nil)
+
+// Returns mock of catalog.CatalogApi asserting its expectations when the test ends
+func NewCatalogApi(t TestingT) *CatalogApi {
+	m := &CatalogApi{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+
+	return m
+}
+
+func (_m *CatalogApi) CreateCatalogItem(ctx context.Context, payload catalog.CreateCatalogItemPayload) (*models.CatalogItemResource, error) {
+	ret := _m.Called(ctx, payload)
+
+	return value[*models.CatalogItemResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) CreateCatalogVariant(ctx context.Context, payload catalog.CreateCatalogItemVariantPayload) (*models.CatalogVariantResource, error) {
+	ret := _m.Called(ctx, payload)
+
+	return value[*models.CatalogVariantResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) DeleteCatalogItem(ctx context.Context, catalogItemId models.CatalogID) error {
+	ret := _m.Called(ctx, catalogItemId)
+
+	return ret.Error(0)
+}
+
+func (_m *CatalogApi) DeleteCatalogVariant(ctx context.Context, catalogVariantId models.CatalogID) error {
+	ret := _m.Called(ctx, catalogVariantId)
+
+	return ret.Error(0)
+}
+
+func (_m *CatalogApi) GetCatalogItem(ctx context.Context, catalogItemId models.CatalogID, options *catalog.GetCatalogItemApiOptions) (*models.CatalogItemResource, error) {
+	ret := _m.Called(ctx, catalogItemId, options)
+
+	return value[*models.CatalogItemResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) GetCatalogItems(ctx context.Context, filterString string, options *catalog.CatalogItemApiOptions) (*models.CatalogItemCollectionResource, error) {
+	ret := _m.Called(ctx, filterString, options)
+
+	return value[*models.CatalogItemCollectionResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) GetCatalogVariant(ctx context.Context, catalogVariantId models.CatalogID, options *catalog.GetCatalogVariantApiOptions) (*models.CatalogVariantResource, error) {
+	ret := _m.Called(ctx, catalogVariantId, options)
+
+	return value[*models.CatalogVariantResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) GetCatalogVariants(ctx context.Context, options *catalog.CatalogVariantsApiOptions) (*models.CatalogVariantCollectionResource, error) {
+	ret := _m.Called(ctx, options)
+
+	return value[*models.CatalogVariantCollectionResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) GetCreateItemsJob(ctx context.Context, buildCreateJobId string, options *catalog.GetBulkItemsJobOptions) (*models.CatalogItemBulkJobResource, error) {
+	ret := _m.Called(ctx, buildCreateJobId, options)
+
+	return value[*models.CatalogItemBulkJobResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) GetCreateItemsJobs(ctx context.Context, options *catalog.GetBulkItemsJobsOptions) (*models.CatalogItemBulkJobCollectionResource, error) {
+	ret := _m.Called(ctx, options)
+
+	return value[*models.CatalogItemBulkJobCollectionResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) GetDeleteItemsJob(ctx context.Context, buildDeleteJobId string, options *catalog.GetBulkItemsJobOptions) (*models.CatalogItemBulkJobResource, error) {
+	ret := _m.Called(ctx, buildDeleteJobId, options)
+
+	return value[*models.CatalogItemBulkJobResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) GetDeleteItemsJobs(ctx context.Context, options *catalog.GetBulkItemsJobsOptions) (*models.CatalogItemBulkJobCollectionResource, error) {
+	ret := _m.Called(ctx, options)
+
+	return value[*models.CatalogItemBulkJobCollectionResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) GetUpdateItemsJob(ctx context.Context, buildUpdateJobId string, options *catalog.GetBulkItemsJobOptions) (*models.CatalogItemBulkJobResource, error) {
+	ret := _m.Called(ctx, buildUpdateJobId, options)
+
+	return value[*models.CatalogItemBulkJobResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) GetUpdateItemsJobs(ctx context.Context, options *catalog.GetBulkItemsJobsOptions) (*models.CatalogItemBulkJobCollectionResource, error) {
+	ret := _m.Called(ctx, options)
+
+	return value[*models.CatalogItemBulkJobCollectionResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) SpawnCreateItemsJob(ctx context.Context, payload catalog.SpawnCreateItemsJobPayload) (*models.CatalogItemBulkJobResource, error) {
+	ret := _m.Called(ctx, payload)
+
+	return value[*models.CatalogItemBulkJobResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) SpawnDeleteItemsJob(ctx context.Context, payload catalog.SpawnDeleteItemsJobPayload) (*models.CatalogItemBulkJobResource, error) {
+	ret := _m.Called(ctx, payload)
+
+	return value[*models.CatalogItemBulkJobResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) SpawnUpdateItemsJob(ctx context.Context, payload catalog.SpawnUpdateItemsJobPayload) (*models.CatalogItemBulkJobResource, error) {
+	ret := _m.Called(ctx, payload)
+
+	return value[*models.CatalogItemBulkJobResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) UpdateCatalogItem(ctx context.Context, catalogItemId models.CatalogID, payload catalog.UpdateCatalogItemPayload) (*models.CatalogItemResource, error) {
+	ret := _m.Called(ctx, catalogItemId, payload)
+
+	return value[*models.CatalogItemResource](ret, 0), ret.Error(1)
+}
+
+func (_m *CatalogApi) UpdateCatalogVariant(ctx context.Context, catalogVariantId models.CatalogID, payload catalog.UpdateCatalogVariantPayload) (*models.CatalogVariantResource, error) {
+	ret := _m.Called(ctx, catalogVariantId, payload)
+
+	return value[*models.CatalogVariantResource](ret, 0), ret.Error(1)
+}
@@ -0,0 +1,123 @@
+// Code generated by mocks/internal/mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	flows "github.com/developertom01/klaviyo-go/api/flowsApi"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock of flows.FlowsApi
+type FlowsApi struct {
+	mock.Mock
+}
+
+var _ flows.FlowsApi = (*FlowsApi)(nil)
+
+// Returns mock of flows.FlowsApi asserting its expectations when the test ends
+func NewFlowsApi(t TestingT) *FlowsApi {
+	m := &FlowsApi{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+
+	return m
+}
+
+func (_m *FlowsApi) GetFlow(ctx context.Context, flowId string, options *flows.GetFlowsOptions) (*models.FlowResource, error) {
+	ret := _m.Called(ctx, flowId, options)
+
+	return value[*models.FlowResource](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlowAction(ctx context.Context, flowId string, opt *flows.GetFlowActionOptions) (*models.FlowActionResource, error) {
+	ret := _m.Called(ctx, flowId, opt)
+
+	return value[*models.FlowActionResource](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlowActionForMessage(ctx context.Context, actionMessageId string, flowActionFields models.Fields[models.FlowActionField]) (*models.FlowActionResource, error) {
+	ret := _m.Called(ctx, actionMessageId, flowActionFields)
+
+	return value[*models.FlowActionResource](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlowActionMessages(ctx context.Context, flowActionId string, filterStr *string, paginationOpt *flows.FlowActionMessagePaginationOptions) (*models.FlowActionMessageCollectionResource, error) {
+	ret := _m.Called(ctx, flowActionId, filterStr, paginationOpt)
+
+	return value[*models.FlowActionMessageCollectionResource](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlowActionRelationshipsFlow(ctx context.Context, flowActionId string) (*models.RelationshipData, error) {
+	ret := _m.Called(ctx, flowActionId)
+
+	return value[*models.RelationshipData](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlowActionRelationshipsMessages(ctx context.Context, flowId string, filterStr *string, paginationOption *flows.FlowActionMessagePaginationOptions) (*models.RelationshipDataCollection, error) {
+	ret := _m.Called(ctx, flowId, filterStr, paginationOption)
+
+	return value[*models.RelationshipDataCollection](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlowFlowActions(ctx context.Context, flowId string, opt *flows.GetFlowActionOptions, paginationOpt *flows.FlowActionPaginationOptions) (*models.FlowActionCollectionResource, error) {
+	ret := _m.Called(ctx, flowId, opt, paginationOpt)
+
+	return value[*models.FlowActionCollectionResource](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlowForFlowAction(ctx context.Context, flowActionId string, flowsFields models.Fields[models.FlowField]) (*models.FlowActionResource, error) {
+	ret := _m.Called(ctx, flowActionId, flowsFields)
+
+	return value[*models.FlowActionResource](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlowMessage(ctx context.Context, flowMessageID string, opt *flows.GetFlowMessageOptions) (*models.FlowMessageResource, error) {
+	ret := _m.Called(ctx, flowMessageID, opt)
+
+	return value[*models.FlowMessageResource](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlowMessageRelationshipsAction(ctx context.Context, flowMessageId string) (*models.RelationshipData, error) {
+	ret := _m.Called(ctx, flowMessageId)
+
+	return value[*models.RelationshipData](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlowMessageRelationshipsTemplate(ctx context.Context, flowMessageId string, templateFields models.Fields[models.TemplateField]) (*models.TemplateResponse, error) {
+	ret := _m.Called(ctx, flowMessageId, templateFields)
+
+	return value[*models.TemplateResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlowRelationshipsFlowActions(ctx context.Context, flowId string, filterStr *string, paginationOption *flows.FlowActionPaginationOptions) (*models.RelationshipDataCollection, error) {
+	ret := _m.Called(ctx, flowId, filterStr, paginationOption)
+
+	return value[*models.RelationshipDataCollection](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlowRelationshipsTags(ctx context.Context, flowId string) (*models.RelationshipDataCollection, error) {
+	ret := _m.Called(ctx, flowId)
+
+	return value[*models.RelationshipDataCollection](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlowTags(ctx context.Context, flowId string, tagFields models.Fields[models.TagField]) (*models.FlowTagCollectionResource, error) {
+	ret := _m.Called(ctx, flowId, tagFields)
+
+	return value[*models.FlowTagCollectionResource](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) GetFlows(ctx context.Context, filterStr *string, options *flows.GetFlowsOptions, paginationOpt *flows.FlowPaginationOptions) (*models.FlowCollectionResource, error) {
+	ret := _m.Called(ctx, filterStr, options, paginationOpt)
+
+	return value[*models.FlowCollectionResource](ret, 0), ret.Error(1)
+}
+
+func (_m *FlowsApi) UpdateFlowStatus(ctx context.Context, flowId string, payload flows.UpdateFlowStatusPayload) (*models.FlowResource, error) {
+	ret := _m.Called(ctx, flowId, payload)
+
+	return value[*models.FlowResource](ret, 0), ret.Error(1)
+}
@@ -0,0 +1,58 @@
+// Code generated by mocks/internal/mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"io"
+
+	images "github.com/developertom01/klaviyo-go/api/imagesApi"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock of images.ImagesApi
+type ImagesApi struct {
+	mock.Mock
+}
+
+var _ images.ImagesApi = (*ImagesApi)(nil)
+
+// Returns mock of images.ImagesApi asserting its expectations when the test ends
+func NewImagesApi(t TestingT) *ImagesApi {
+	m := &ImagesApi{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+
+	return m
+}
+
+func (_m *ImagesApi) GetImage(ctx context.Context, imageId string, fields models.Fields[models.ImageField]) (*models.ImageResponse, error) {
+	ret := _m.Called(ctx, imageId, fields)
+
+	return value[*models.ImageResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *ImagesApi) GetImages(ctx context.Context, filterString string, options *images.GetImagesOptions) (*models.ImageCollectionResponse, error) {
+	ret := _m.Called(ctx, filterString, options)
+
+	return value[*models.ImageCollectionResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *ImagesApi) UpdateImage(ctx context.Context, imageId string, payload images.UpdateImagePayload) (*models.ImageResponse, error) {
+	ret := _m.Called(ctx, imageId, payload)
+
+	return value[*models.ImageResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *ImagesApi) UploadImageFromFile(ctx context.Context, file io.Reader, payload images.UploadImageFromFilePayload) (*models.ImageResponse, error) {
+	ret := _m.Called(ctx, file, payload)
+
+	return value[*models.ImageResponse](ret, 0), ret.Error(1)
+}
+
+func (_m *ImagesApi) UploadImageFromURL(ctx context.Context, payload images.UploadImageFromUrlPayload) (*models.ImageResponse, error) {
+	ret := _m.Called(ctx, payload)
+
+	return value[*models.ImageResponse](ret, 0), ret.Error(1)
+}
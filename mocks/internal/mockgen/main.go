@@ -0,0 +1,176 @@
+// Generates the testify mocks of the mocks package from the Api interfaces. Run with `go generate ./mocks`
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const MODULE = "github.com/developertom01/klaviyo-go"
+
+// Interface mocked into `file` of the mocks package
+type target struct {
+	pkg   string
+	iface string
+	file  string
+}
+
+var targets = []target{
+	{pkg: MODULE + "/api/accountsApi", iface: "AccountsApi", file: "accounts.go"},
+	{pkg: MODULE + "/api/campaignsApi", iface: "CampaignsApi", file: "campaigns.go"},
+	{pkg: MODULE + "/api/catalogApi", iface: "CatalogApi", file: "catalog.go"},
+	{pkg: MODULE + "/api/flowsApi", iface: "FlowsApi", file: "flows.go"},
+	{pkg: MODULE + "/api/imagesApi", iface: "ImagesApi", file: "images.go"},
+}
+
+func main() {
+	out := flag.String("out", ".", "directory the mocks are written to")
+	flag.Parse()
+
+	dir, err := filepath.Abs(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	importer := newImporter()
+	for _, t := range targets {
+		source, err := generate(importer, dir, t)
+		if err != nil {
+			log.Fatalf("mockgen: %s.%s: %v", t.pkg, t.iface, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, t.file), source, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// Returns importer type checking packages from source, sharing packages between imports
+func newImporter() types.ImporterFrom {
+	return importer.ForCompiler(token.NewFileSet(), "source", nil).(types.ImporterFrom)
+}
+
+// Returns the source of the mock of `t`. Packages are resolved from `dir`
+func generate(importer types.ImporterFrom, dir string, t target) ([]byte, error) {
+	pkg, err := importer.ImportFrom(t.pkg, dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	object := pkg.Scope().Lookup(t.iface)
+	if object == nil {
+		return nil, fmt.Errorf("interface not found")
+	}
+	iface, ok := object.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an interface", object.Type())
+	}
+
+	imports := map[string]string{"github.com/stretchr/testify/mock": "mock"}
+	qualifier := func(p *types.Package) string {
+		imports[p.Path()] = p.Name()
+		return p.Name()
+	}
+	qualified := qualifier(pkg) + "." + t.iface
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "// Mock of %s\n", qualified)
+	fmt.Fprintf(&body, "type %s struct {\n\tmock.Mock\n}\n\n", t.iface)
+	fmt.Fprintf(&body, "var _ %s = (*%s)(nil)\n\n", qualified, t.iface)
+	fmt.Fprintf(&body, "// Returns mock of %s asserting its expectations when the test ends\n", qualified)
+	fmt.Fprintf(&body, "func New%s(t TestingT) *%s {\n\tm := &%s{}\n\tm.Mock.Test(t)\n\tt.Cleanup(func() { m.AssertExpectations(t) })\n\n\treturn m\n}\n", t.iface, t.iface, t.iface)
+
+	for i := 0; i < iface.NumMethods(); i++ {
+		writeMethod(&body, t.iface, iface.Method(i), qualifier)
+	}
+
+	var source bytes.Buffer
+	source.WriteString("// Code generated by mocks/internal/mockgen. DO NOT EDIT.\n\npackage mocks\n\n")
+	writeImports(&source, imports)
+	source.Write(body.Bytes())
+
+	return format.Source(source.Bytes())
+}
+
+func writeMethod(w *bytes.Buffer, receiver string, method *types.Func, qualifier types.Qualifier) {
+	signature := method.Type().(*types.Signature)
+
+	var params, names []string
+	for i := 0; i < signature.Params().Len(); i++ {
+		param := signature.Params().At(i)
+		name := param.Name()
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+
+		typ := types.TypeString(param.Type(), qualifier)
+		if signature.Variadic() && i == signature.Params().Len()-1 {
+			typ = "..." + types.TypeString(param.Type().(*types.Slice).Elem(), qualifier)
+		}
+		params = append(params, name+" "+typ)
+		names = append(names, name)
+	}
+
+	var results, values []string
+	for i := 0; i < signature.Results().Len(); i++ {
+		typ := signature.Results().At(i).Type()
+		results = append(results, types.TypeString(typ, qualifier))
+		if types.Identical(typ, types.Universe.Lookup("error").Type()) {
+			values = append(values, fmt.Sprintf("ret.Error(%d)", i))
+		} else {
+			values = append(values, fmt.Sprintf("value[%s](ret, %d)", types.TypeString(typ, qualifier), i))
+		}
+	}
+
+	returns := strings.Join(results, ", ")
+	if len(results) > 1 {
+		returns = "(" + returns + ")"
+	}
+
+	fmt.Fprintf(w, "\nfunc (_m *%s) %s(%s) %s {\n", receiver, method.Name(), strings.Join(params, ", "), returns)
+	if len(values) == 0 {
+		fmt.Fprintf(w, "\t_m.Called(%s)\n}\n", strings.Join(names, ", "))
+		return
+	}
+	fmt.Fprintf(w, "\tret := _m.Called(%s)\n\n\treturn %s\n}\n", strings.Join(names, ", "), strings.Join(values, ", "))
+}
+
+// Writes standard library imports, then the others. Imports named unlike their path are aliased
+func writeImports(w *bytes.Buffer, imports map[string]string) {
+	var std, others []string
+	for importPath, name := range imports {
+		spec := fmt.Sprintf("%q", importPath)
+		if path.Base(importPath) != name {
+			spec = name + " " + spec
+		}
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			others = append(others, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(others)
+
+	w.WriteString("import (\n")
+	for _, spec := range std {
+		fmt.Fprintf(w, "\t%s\n", spec)
+	}
+	if len(std) > 0 {
+		w.WriteString("\n")
+	}
+	for _, spec := range others {
+		fmt.Fprintf(w, "\t%s\n", spec)
+	}
+	w.WriteString(")\n\n")
+}
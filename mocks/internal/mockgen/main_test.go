@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MockgenTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MockgenTestSuite) TestMocksAreUpToDate() {
+	dir, err := filepath.Abs("../..")
+	suite.Require().NoError(err)
+
+	importer := newImporter()
+	for _, t := range targets {
+		generated, err := generate(importer, dir, t)
+		suite.Require().NoError(err)
+
+		committed, err := os.ReadFile(filepath.Join(dir, t.file))
+		suite.Require().NoError(err)
+		suite.Equal(string(generated), string(committed), "%s is out of date, run `go generate ./mocks`", t.file)
+	}
+}
+
+func TestMockgenTestSuite(t *testing.T) {
+	suite.Run(t, new(MockgenTestSuite))
+}
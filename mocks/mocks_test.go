@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/developertom01/klaviyo-go/exceptions"
+	"github.com/developertom01/klaviyo-go/models"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MocksTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MocksTestSuite) TestStubbedCalls() {
+	klaviyo := NewKlaviyo(suite.T())
+	api := klaviyo.Api()
+
+	campaign := &models.CampaignResponse{Data: models.Campaign{ID: "01HXYZ"}}
+	klaviyo.Campaigns.On("GetCampaign", mock.Anything, "01HXYZ", "", mock.Anything).Return(campaign, nil)
+	klaviyo.Campaigns.On("DeleteCampaigns", mock.Anything, "MISSING").Return(exceptions.ErrNotFound)
+	klaviyo.Images.On("GetImages", mock.Anything, "", mock.Anything).Return(nil, errors.New("unavailable"))
+
+	got, err := api.Campaigns.GetCampaign(context.Background(), "01HXYZ", "", nil)
+	suite.NoError(err)
+	suite.Same(campaign, got)
+
+	suite.ErrorIs(api.Campaigns.DeleteCampaigns(context.Background(), "MISSING"), exceptions.ErrNotFound)
+
+	images, err := api.Images.GetImages(context.Background(), "", nil)
+	suite.Nil(images)
+	suite.EqualError(err, "unavailable")
+}
+
+func TestMocksTestSuite(t *testing.T) {
+	suite.Run(t, new(MocksTestSuite))
+}
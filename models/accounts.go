@@ -1,10 +1,5 @@
 package models
 
-import (
-	"fmt"
-	"strings"
-)
-
 type (
 	Account struct {
 		Type       string            `json:"type"`
@@ -29,14 +24,9 @@ type (
 		StreetAddress      StreetAddress `json:"street_address"`
 	}
 
-	AccountsCollectionResponse struct {
-		Data  []Account `json:"data"`
-		Links Links     `json:"links"`
-	}
+	AccountsCollectionResponse = CollectionDocument[Account]
 
-	AccountResponse struct {
-		Data Account `json:"data"`
-	}
+	AccountResponse = Document[Account]
 )
 
 type AccountsField string
@@ -60,16 +50,6 @@ const (
 	AccountsFieldPublicApiKey                              AccountsField = "public_api_key"
 )
 
-func BuildAccountFieldsParam(fields []AccountsField) string {
-
-	if len(fields) == 0 {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[account]=%v", strings.Join(formattedFields, ","))
+func (AccountsField) ResourceType() string {
+	return "account"
 }
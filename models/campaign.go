@@ -2,21 +2,12 @@ package models
 
 import (
 	"encoding/json"
-	"fmt"
-	"strings"
 	"time"
 )
 
 type (
-	CampaignsCollectionResponse struct {
-		Data     []Campaign         `json:"data"`
-		Links    Links              `json:"links"`
-		Included []CampaignIncluded `json:"included"`
-	}
-	CampaignResponse struct {
-		Data     Campaign           `json:"data"`
-		Included []CampaignIncluded `json:"included"`
-	}
+	CampaignsCollectionResponse = CollectionDocument[Campaign]
+	CampaignResponse            = Document[Campaign]
 
 	Campaign struct {
 		Type          string                `json:"type"` //campaign
@@ -44,6 +35,7 @@ type (
 		SendTime        time.Time       `json:"send_time"`        //The datetime when the campaign will be / was sent or None if not yet scheduled by a send_job.
 	}
 
+	// Deprecated: included resources are decoded by Included, see Document.Related
 	CampaignIncluded struct {
 		Type       string                          `json:"type"`
 		ID         string                          `json:"id"`
@@ -51,6 +43,7 @@ type (
 		Links      DataLinks                       `json:"links"`
 	}
 
+	// Deprecated: included resources are decoded by Included, see Document.Related
 	CampaignIncludedAttributesUnion map[string]any
 )
 
@@ -87,9 +80,8 @@ func (atr CampaignIncludedAttributesUnion) IsCampaignMessage() (*CampaignMessage
 }
 
 type (
-	CampaignRecipientCountResponse struct {
-		Data CampaignRecipientCountData `json:"data"`
-	}
+	CampaignRecipientCountResponse = Document[CampaignRecipientCountData]
+
 	CampaignRecipientCountData struct {
 		Type       string                           `json:"type"`       //campaign-recipient-estimation
 		ID         string                           `json:"id"`         //The ID of the campaign for which to get the estimated number of recipients
@@ -153,7 +145,7 @@ const (
 )
 
 const (
-	CampaignIncludeFieldCampaignMessage CampaignIncludeField = "campaign-message"
+	CampaignIncludeFieldCampaignMessage CampaignIncludeField = "campaign-messages"
 	CampaignIncludeFieldTags            CampaignIncludeField = "tags"
 )
 
@@ -161,42 +153,10 @@ const (
 	CampaignRecipientEstimationFieldEstimatedRecipientCount CampaignRecipientEstimationField = "estimated_recipient_count"
 )
 
-func BuildCampaignFieldsParam(fields []CampaignsField) string {
-	if len(fields) == 0 {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[campaign]=%s", strings.Join(formattedFields, ","))
-}
-
-// Build query param string. eg. fields[campaign-recipient-estimation]=[name,contact_information]
-func BuildCampaignRecipientEstimateFieldParam(fields []CampaignRecipientEstimationField) string {
-	if len(fields) == 0 {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[campaign-recipient-estimation]=%s", strings.Join(formattedFields, ","))
+func (CampaignsField) ResourceType() string {
+	return "campaign"
 }
 
-func BuildCampaignIncludeFieldParam(fields []CampaignIncludeField) string {
-	if len(fields) == 0 {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("include=%s", strings.Join(formattedFields, ","))
+func (CampaignRecipientEstimationField) ResourceType() string {
+	return "campaign-recipient-estimation"
 }
@@ -2,22 +2,13 @@ package models
 
 import (
 	"encoding/json"
-	"fmt"
-	"strings"
 	"time"
 )
 
 type (
-	CampaignMessageResponse struct {
-		Data     CampaignMessage                    `json:"data"`
-		Included []CampaignMessageIncludedUnionType `json:"included,omitempty"` //This can either be array of Template or Campaign object.
-	}
+	CampaignMessageResponse = Document[CampaignMessage] //Included can hold Template and Campaign
 
-	CampaignMessageCollectionResponse struct {
-		Data     []CampaignMessage                  `json:"data"`
-		Links    Links                              `json:"links"`
-		Included []CampaignMessageIncludedUnionType `json:"included,omitempty"` //This can either be array of Template or Campaign object.
-	}
+	CampaignMessageCollectionResponse = CollectionDocument[CampaignMessage] //Included can hold Template and Campaign
 
 	CampaignMessage struct {
 		Type          string                    `json:"type"`
@@ -29,7 +20,7 @@ type (
 
 	CampaignMessageAttributes struct {
 		Label         string               `json:"label"`
-		Channel       string               `json:"channel"`
+		Channel       Channel              `json:"channel"`
 		Content       MessageContent       `json:"content"`
 		SendTimes     []SendTime           `json:"send_times"`
 		RenderOptions MessageRenderOptions `json:"render_options"`
@@ -59,18 +50,8 @@ const (
 	CampaignMessageFieldUpdatedAt                       CampaignMessageField = "updated_at"
 )
 
-// Build query param string. eg. fields[campaign-message]=[name,contact_information]
-func BuildCampaignMessageFieldsParam(fields []CampaignMessageField) string {
-	if len(fields) == 0 {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[campaign-message]=%s", strings.Join(formattedFields, ","))
+func (CampaignMessageField) ResourceType() string {
+	return "campaign-message"
 }
 
 type CampaignMessageIncludeField string
@@ -80,20 +61,7 @@ const (
 	CampaignMessageIncludeFieldTemplate CampaignMessageIncludeField = "template"
 )
 
-func BuildCampaignMessageIncludeFieldParam(fields []CampaignMessageIncludeField) string {
-	if len(fields) == 0 {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("includes=%s", strings.Join(formattedFields, ","))
-
-}
-
+// Deprecated: included resources are decoded by Included, see Document.Related
 type CampaignMessageIncludedUnionType map[string]any
 
 func (cms *CampaignMessageIncludedUnionType) GetCampaign() (*Campaign, bool) {
@@ -1,15 +1,7 @@
 package models
 
-import (
-	"fmt"
-	"strings"
-)
-
 type (
-	CampaignSendJobResponse struct {
-		Data  CampaignSendJob `json:"data"`
-		Links DataLinks       `json:"link"`
-	}
+	CampaignSendJobResponse = Document[CampaignSendJob]
 
 	CampaignSendJob struct {
 		Type       string                    `json:"type"` //campaign-send-job
@@ -27,8 +19,8 @@ type (
 )
 
 const (
-	CampaignSendJobStatusCancelled  CampaignSendJobStatus = "canceled"
-	CampaignSendJobStatusCompleted  CampaignSendJobStatus = "completed"
+	CampaignSendJobStatusCancelled  CampaignSendJobStatus = "cancelled"
+	CampaignSendJobStatusCompleted  CampaignSendJobStatus = "complete"
 	CampaignSendJobStatusProcessing CampaignSendJobStatus = "processing"
 	CampaignSendJobStatusQueued     CampaignSendJobStatus = "queued"
 )
@@ -39,15 +31,6 @@ const (
 	CampaignSendJobFieldStatus CampaignSendJobField = "status"
 )
 
-func BuildCampaignSendJobFieldsParam(fields []CampaignSendJobField) string {
-	if len(fields) == 0 {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[campaign-send-job]=%s", strings.Join(formattedFields, ","))
+func (CampaignSendJobField) ResourceType() string {
+	return "campaign-send-job"
 }
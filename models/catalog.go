@@ -2,8 +2,6 @@ package models
 
 import (
 	"encoding/json"
-	"fmt"
-	"strings"
 	"time"
 
 	"github.com/developertom01/klaviyo-go/exceptions"
@@ -18,18 +16,12 @@ type (
 		Relationship *CatalogItemRelationships `json:"relationships"`
 	}
 
-	CatalogItemCollectionResource struct {
-		Data     []CatalogItem         `json:"data"`
-		Links    Links                 `json:"links"`
-		Included []CatalogItemIncluded `json:"included"`
-	}
+	CatalogItemCollectionResource = CollectionDocument[CatalogItem] //Included can hold CatalogVariant
 
-	CatalogItemResource struct {
-		Data CatalogItem `json:"data"`
-	}
+	CatalogItemResource = Document[CatalogItem]
 
 	CatalogItemRelationships struct {
-		Variant Relationships `json:"variant,omitempty"`
+		Variant Relationships `json:"variants,omitempty"`
 	}
 
 	CatalogItemAttributes struct {
@@ -48,6 +40,7 @@ type (
 	}
 )
 
+// Deprecated: included resources are decoded by Included, see Document.Related
 type CatalogItemIncluded map[string]any
 
 func (inc *CatalogItemIncluded) ToCatalogVariant() (*CatalogVariant, bool) {
@@ -92,20 +85,11 @@ const (
 type CatalogItemIncludedField string
 
 const (
-	CatalogItemIncludedFieldVariant CatalogItemIncludedField = "variant"
+	CatalogItemIncludedFieldVariant CatalogItemIncludedField = "variants"
 )
 
-func BuildCatalogItemFieldParams(fields []CatalogItemField) string {
-	if fields == nil {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[catalog-item]=%s", strings.Join(formattedFields, ","))
+func (CatalogItemField) ResourceType() string {
+	return "catalog-item"
 }
 
 type (
@@ -117,14 +101,9 @@ type (
 		Links         DataLinks                    `json:"links"`
 	}
 
-	CatalogVariantCollectionResource struct {
-		Data  []CatalogVariant `json:"data"`
-		Links Links            `json:"links"`
-	}
+	CatalogVariantCollectionResource = CollectionDocument[CatalogVariant]
 
-	CatalogVariantResource struct {
-		Data CatalogVariant `json:"data"`
-	}
+	CatalogVariantResource = Document[CatalogVariant]
 
 	CatalogVariantRelationships struct {
 		Item Relationships `json:"item"`
@@ -173,17 +152,8 @@ const (
 	CatalogVariantFieldUpdated           CatalogVariantField = "updated"
 )
 
-func BuildCatalogVariantFieldParams(fields []CatalogVariantField) string {
-	if fields == nil {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[catalog-variant]=%s", strings.Join(formattedFields, ","))
+func (CatalogVariantField) ResourceType() string {
+	return "catalog-variant"
 }
 
 type CatalogVariantSortField string
@@ -195,14 +165,9 @@ const (
 
 type (
 	//Resource for bulk catalog creation or update
-	CatalogItemBulkJobResource struct {
-		Data CatalogItemBulkJob `json:"data"`
-	}
+	CatalogItemBulkJobResource = Document[CatalogItemBulkJob]
 	//Collection Resource for bulk catalog creation or update
-	CatalogItemBulkJobCollectionResource struct {
-		Data  []CatalogItemBulkJob `json:"data"`
-		Links Links                `json:"links"`
-	}
+	CatalogItemBulkJobCollectionResource = CollectionDocument[CatalogItemBulkJob]
 
 	//Resource for bulk catalog creation or update. Type is `catalog-item-bulk-create-job` if the job is creation.
 	//Type is `catalog-item-bulk-update-job` if job is update
@@ -252,21 +217,13 @@ const (
 	CatalogItemBulkJobFieldExpiredAt      CatalogItemBulkJobField = "expired_at"
 )
 
-func BuildCatalogItemBulkJobFieldParams(fields []CatalogItemBulkJobField) string {
-	if fields == nil {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[catalog-item-bulk-create-job]=%s", strings.Join(formattedFields, ","))
+// Type of bulk create jobs. Bulk update and delete job endpoints request the fields under their own type
+func (CatalogItemBulkJobField) ResourceType() string {
+	return "catalog-item-bulk-create-job"
 }
 
 type CatalogItemBulkJobIncludeField string
 
 const (
-	CatalogItemBulkJobIncludeFieldItem CatalogItemBulkJobIncludeField = "item"
+	CatalogItemBulkJobIncludeFieldItem CatalogItemBulkJobIncludeField = "items"
 )
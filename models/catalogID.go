@@ -0,0 +1,115 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Integration and catalog of a CatalogID when none is set, currently the only supported ones
+const (
+	CATALOG_INTEGRATION_CUSTOM = "$custom"
+	CATALOG_DEFAULT            = "$default"
+)
+
+// Separator of the parts of a CatalogID
+const CATALOG_ID_SEPARATOR = ":::"
+
+var ErrInvalidCatalogID = errors.New("Invalid catalog ID")
+
+// Compound ID of a catalog item, variant or category, {integration}:::{catalog}:::{external_id}.
+// Marshals to its string form, eg. $custom:::$default:::SKU-1
+type CatalogID struct {
+	Integration string //$custom when empty
+	Catalog     string //$default when empty
+	ExternalID  string //The ID in an external system
+}
+
+// Returns ID of `externalId` in the $custom integration and $default catalog
+func NewCatalogID(externalId string) CatalogID {
+	return CatalogID{Integration: CATALOG_INTEGRATION_CUSTOM, Catalog: CATALOG_DEFAULT, ExternalID: externalId}
+}
+
+// Parses a compound ID, eg. $custom:::$default:::SKU-1. The external ID may itself hold the separator
+func ParseCatalogID(id string) (CatalogID, error) {
+	parts := strings.SplitN(id, CATALOG_ID_SEPARATOR, 3)
+	if len(parts) != 3 {
+		return CatalogID{}, fmt.Errorf("%w %q: expected {integration}:::{catalog}:::{external_id}", ErrInvalidCatalogID, id)
+	}
+
+	catalogID := CatalogID{Integration: parts[0], Catalog: parts[1], ExternalID: parts[2]}
+	if catalogID.Integration == "" || catalogID.Catalog == "" {
+		return CatalogID{}, fmt.Errorf("%w %q: integration and catalog are required", ErrInvalidCatalogID, id)
+	}
+	if err := catalogID.Validate(); err != nil {
+		return CatalogID{}, err
+	}
+
+	return catalogID, nil
+}
+
+// Returns ID with $custom and $default for an empty integration and catalog
+func (id CatalogID) withDefaults() CatalogID {
+	if id.Integration == "" {
+		id.Integration = CATALOG_INTEGRATION_CUSTOM
+	}
+	if id.Catalog == "" {
+		id.Catalog = CATALOG_DEFAULT
+	}
+	return id
+}
+
+// Returns an error wrapping ErrInvalidCatalogID when the external ID is empty or a part holds whitespace only,
+// or the integration or catalog holds the separator
+func (id CatalogID) Validate() error {
+	id = id.withDefaults()
+
+	switch {
+	case id.ExternalID == "":
+		return fmt.Errorf("%w: external ID is required", ErrInvalidCatalogID)
+	case strings.TrimSpace(id.ExternalID) == "":
+		return fmt.Errorf("%w: external ID %q is blank", ErrInvalidCatalogID, id.ExternalID)
+	case strings.Contains(id.Integration, CATALOG_ID_SEPARATOR), strings.Contains(id.Catalog, CATALOG_ID_SEPARATOR):
+		return fmt.Errorf("%w: integration %q and catalog %q can not hold %s", ErrInvalidCatalogID, id.Integration, id.Catalog, CATALOG_ID_SEPARATOR)
+	}
+
+	return nil
+}
+
+func (id CatalogID) String() string {
+	id = id.withDefaults()
+	return id.Integration + CATALOG_ID_SEPARATOR + id.Catalog + CATALOG_ID_SEPARATOR + id.ExternalID
+}
+
+// Returns the ID escaped as a URL path segment, eg. an external ID holding a slash or space
+func (id CatalogID) PathEscape() string {
+	return url.PathEscape(id.String())
+}
+
+func (id CatalogID) MarshalText() ([]byte, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(id.String()), nil
+}
+
+func (id *CatalogID) UnmarshalText(text []byte) error {
+	parsed, err := ParseCatalogID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
+
+// Returns the compound ID of the item
+func (item CatalogItem) CatalogID() (CatalogID, error) {
+	return ParseCatalogID(item.ID)
+}
+
+// Returns the compound ID of the variant
+func (variant CatalogVariant) CatalogID() (CatalogID, error) {
+	return ParseCatalogID(variant.ID)
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogIDString(t *testing.T) {
+	assert.Equal(t, "$custom:::$default:::SKU-1", NewCatalogID("SKU-1").String())
+	assert.Equal(t, "$custom:::$default:::SKU-1", CatalogID{ExternalID: "SKU-1"}.String())
+	assert.Equal(t, "shopify:::main:::SKU-1", CatalogID{Integration: "shopify", Catalog: "main", ExternalID: "SKU-1"}.String())
+}
+
+func TestParseCatalogID(t *testing.T) {
+	id, err := ParseCatalogID("$custom:::$default:::a:::b")
+	require.NoError(t, err)
+	assert.Equal(t, NewCatalogID("a:::b"), id)
+	assert.Equal(t, "$custom:::$default:::a:::b", id.String())
+
+	for _, invalid := range []string{"", "SKU-1", "$custom:::SKU-1", ":::$default:::SKU-1", "$custom:::$default:::", "$custom:::$default:::  "} {
+		_, err := ParseCatalogID(invalid)
+		assert.ErrorIs(t, err, ErrInvalidCatalogID, invalid)
+	}
+}
+
+func TestCatalogIDValidate(t *testing.T) {
+	assert.NoError(t, NewCatalogID("SKU-1").Validate())
+	assert.ErrorIs(t, CatalogID{}.Validate(), ErrInvalidCatalogID)
+	assert.ErrorIs(t, CatalogID{Catalog: "a:::b", ExternalID: "SKU-1"}.Validate(), ErrInvalidCatalogID)
+}
+
+func TestCatalogIDPathEscape(t *testing.T) {
+	assert.Equal(t, "$custom:::$default:::shirts%2Fblue%20M", NewCatalogID("shirts/blue M").PathEscape())
+}
+
+func TestCatalogIDJSON(t *testing.T) {
+	data, err := json.Marshal(map[string]CatalogID{"id": NewCatalogID("SKU-1")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "$custom:::$default:::SKU-1"}`, string(data))
+
+	var payload struct{ ID CatalogID }
+	require.NoError(t, json.Unmarshal([]byte(`{"ID": "$custom:::$default:::SKU-1"}`), &payload))
+	assert.Equal(t, NewCatalogID("SKU-1"), payload.ID)
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"ID": "SKU-1"}`), &payload), ErrInvalidCatalogID)
+	_, err = json.Marshal(CatalogID{})
+	assert.ErrorIs(t, err, ErrInvalidCatalogID)
+}
+
+func TestCatalogItemCatalogID(t *testing.T) {
+	id, err := CatalogItem{ID: "$custom:::$default:::SKU-1"}.CatalogID()
+	require.NoError(t, err)
+	assert.Equal(t, "SKU-1", id.ExternalID)
+}
@@ -1,34 +1,12 @@
 package models
 
 import (
-	"encoding/json"
 	"time"
 
 	"github.com/jaswdr/faker"
 )
 
 type (
-	//MessageContent can be either MessageSmsContent | MessageSmsContent
-	// Default type of `MessageContent` is map[string]any but had
-	// iSMessageContentSms method which returns (MessageSmsContent, bool)
-	// and isMessageContentEmail which returns (MessageEmailContent, bool)
-	MessageContent map[string]any
-
-	MessageEmailContent struct {
-		Subject      *string `json:"subject,omitempty"`        //The subject of the message
-		PreviewText  *string `json:"preview_text,omitempty"`   //Preview text associated with the message
-		FromEmail    *string `json:"from_email,omitempty"`     //The email the message should be sent from
-		FromLabel    *string `json:"from_label,omitempty"`     //The label associated with the from_email
-		ReplyToEmail *string `json:"reply_to_email,omitempty"` //Optional Reply-To email address
-		CcEmail      *string `json:"cc_email,omitempty"`       //Optional CC email address
-		BccEmail     *string `json:"bcc_email,omitempty"`      //Optional BCC email address
-	}
-
-	MessageSmsContent struct {
-		Body     *string `json:"body,omitempty"`      //The message body
-		MediaUrl *string `json:"media_url,omitempty"` //URL for included media
-	}
-
 	//Additional options for rendering the message
 	MessageRenderOptions struct {
 		ShortenLinks      *bool `json:"shorten_links,omitempty"`
@@ -135,38 +113,6 @@ type (
 	}
 )
 
-// Returns `*MessageEmailContent` if type is email content
-func (mc MessageContent) IsMessageContentEmail() (*MessageEmailContent, bool) {
-	byteContent, err := json.Marshal(mc)
-	if err != nil {
-		return nil, false
-	}
-
-	var emailContent MessageEmailContent
-	err = json.Unmarshal(byteContent, &emailContent)
-	if err != nil {
-		return nil, false
-	}
-
-	return &emailContent, true
-}
-
-// Returns `*MessageSmsContent` if type is sms content
-func (mc MessageContent) IsMessageContentSms() (*MessageSmsContent, bool) {
-	byteContent, err := json.Marshal(mc)
-	if err != nil {
-		return nil, false
-	}
-
-	var smsContent MessageSmsContent
-	err = json.Unmarshal(byteContent, &smsContent)
-	if err != nil {
-		return nil, false
-	}
-
-	return &smsContent, true
-}
-
 // Describes the shape of the options object. Allowed values: ['static', 'throttled', 'immediate', 'smart_send_time']
 type SendStrategyMethod string
 
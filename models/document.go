@@ -0,0 +1,378 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type (
+	// JSON:API document holding a single primary resource
+	Document[T any] struct {
+		Data     T        `json:"data"`
+		Links    Links    `json:"links"`
+		Included Included `json:"included"`
+	}
+
+	// JSON:API document holding a page of primary resources
+	CollectionDocument[T any] struct {
+		Data     []T      `json:"data"`
+		Links    Links    `json:"links"`
+		Included Included `json:"included"`
+	}
+
+	// Included resources of a document. Every resource is decoded into the model registered for its `type`,
+	// eg. tag into Tag, and relationships of primary and included resources are indexed for Related
+	Included struct {
+		resources []IncludedResource
+		index     map[RelationshipData]int
+		linkage   map[RelationshipData]map[string][]RelationshipData
+	}
+
+	// Included resource decoded according to its `type`
+	IncludedResource struct {
+		Type  string
+		ID    string
+		Value any //Registered model, eg. Tag, or UnknownResource when type is not registered
+		raw   json.RawMessage
+	}
+
+	// Included resource of a type without registered model
+	UnknownResource struct {
+		Type string
+		ID   string
+		Raw  json.RawMessage
+	}
+
+	resourceLinkage struct {
+		Type          string `json:"type"`
+		ID            string `json:"id"`
+		Relationships map[string]struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"relationships"`
+	}
+)
+
+var errUnidentifiedResource = errors.New("Resource has no type and id")
+
+var (
+	includedTypesMu sync.RWMutex
+	includedTypes   = map[string]func(json.RawMessage) (any, error){
+		"account":          decodeIncluded[Account],
+		"campaign":         decodeIncluded[Campaign],
+		"campaign-message": decodeIncluded[CampaignMessage],
+		"catalog-item":     decodeIncluded[CatalogItem],
+		"catalog-variant":  decodeIncluded[CatalogVariant],
+		"flow":             decodeIncluded[Flow],
+		"flow-action":      decodeIncluded[FlowAction],
+		"flow-message":     decodeIncluded[FlowMessage],
+		"image":            decodeIncluded[Image],
+		"tag":              decodeIncluded[Tag],
+		"template":         decodeIncluded[Template],
+	}
+)
+
+// Registers model R for included resources of `resourceType`. Replaces the model registered before
+func RegisterIncludedType[R any](resourceType string) {
+	includedTypesMu.Lock()
+	defer includedTypesMu.Unlock()
+
+	includedTypes[resourceType] = decodeIncluded[R]
+}
+
+func decodeIncluded[R any](raw json.RawMessage) (any, error) {
+	var resource R
+	if err := json.Unmarshal(raw, &resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Creates Included from models, eg. Tag or Campaign. Models are encoded as JSON:API resource objects
+func NewIncluded(resources ...any) (Included, error) {
+	var inc Included
+	for _, resource := range resources {
+		raw, err := json.Marshal(resource)
+		if err != nil {
+			return Included{}, err
+		}
+		if err := inc.add(raw); err != nil {
+			return Included{}, err
+		}
+	}
+
+	return inc, nil
+}
+
+func (d *Document[T]) UnmarshalJSON(data []byte) error {
+	var doc struct {
+		Data     json.RawMessage `json:"data"`
+		Links    Links           `json:"links"`
+		Included Included        `json:"included"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	var primary T
+	if len(doc.Data) > 0 {
+		if err := json.Unmarshal(doc.Data, &primary); err != nil {
+			return err
+		}
+	}
+	if err := doc.Included.link(doc.Data); err != nil {
+		return err
+	}
+
+	d.Data, d.Links, d.Included = primary, doc.Links, doc.Included
+	return nil
+}
+
+func (d *CollectionDocument[T]) UnmarshalJSON(data []byte) error {
+	var doc struct {
+		Data     json.RawMessage `json:"data"`
+		Links    Links           `json:"links"`
+		Included Included        `json:"included"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	var primary []T
+	if len(doc.Data) > 0 {
+		if err := json.Unmarshal(doc.Data, &primary); err != nil {
+			return err
+		}
+	}
+	if err := doc.Included.link(doc.Data); err != nil {
+		return err
+	}
+
+	d.Data, d.Links, d.Included = primary, doc.Links, doc.Included
+	return nil
+}
+
+// Returns included resources related to `resource` through `relationship`, eg. tags of a Campaign.
+// Values are the models registered for their type, see RelatedOf for a typed slice
+func (d Document[T]) Related(resource any, relationship string) []any {
+	return d.Included.Related(resource, relationship)
+}
+
+// Returns included resources related to `resource` through `relationship`, eg. tags of a Campaign.
+// Values are the models registered for their type, see RelatedOf for a typed slice
+func (d CollectionDocument[T]) Related(resource any, relationship string) []any {
+	return d.Included.Related(resource, relationship)
+}
+
+// Returns included resources of model R related to `resource` through `relationship`.
+//
+//	tags := models.RelatedOf[models.Tag](doc.Included, campaign, "tags")
+func RelatedOf[R any](inc Included, resource any, relationship string) []R {
+	var related []R
+	for _, value := range inc.Related(resource, relationship) {
+		if typed, ok := value.(R); ok {
+			related = append(related, typed)
+		}
+	}
+
+	return related
+}
+
+// Returns included resources related to `resource` through `relationship`. Resources that were not included are skipped
+func (inc Included) Related(resource any, relationship string) []any {
+	id, err := identify(resource)
+	if err != nil {
+		return nil
+	}
+
+	var related []any
+	for _, target := range inc.linkage[id][relationship] {
+		if i, ok := inc.index[target]; ok {
+			related = append(related, inc.resources[i].Value)
+		}
+	}
+
+	return related
+}
+
+// Returns included resource of `resourceType` and `id`
+func (inc Included) Get(resourceType string, id string) (IncludedResource, bool) {
+	i, ok := inc.index[RelationshipData{Type: resourceType, ID: id}]
+	if !ok {
+		return IncludedResource{}, false
+	}
+	return inc.resources[i], true
+}
+
+// Returns included resources in document order
+func (inc Included) Resources() []IncludedResource {
+	return inc.resources
+}
+
+// Number of included resources
+func (inc Included) Len() int {
+	return len(inc.resources)
+}
+
+func (inc Included) MarshalJSON() ([]byte, error) {
+	raws := make([]json.RawMessage, 0, len(inc.resources))
+	for _, resource := range inc.resources {
+		raws = append(raws, resource.raw)
+	}
+
+	return json.Marshal(raws)
+}
+
+func (inc *Included) UnmarshalJSON(data []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return err
+	}
+
+	*inc = Included{}
+	for _, raw := range raws {
+		if err := inc.add(raw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (inc *Included) add(raw json.RawMessage) error {
+	var linkage resourceLinkage
+	if err := json.Unmarshal(raw, &linkage); err != nil {
+		return err
+	}
+
+	includedTypesMu.RLock()
+	decode, ok := includedTypes[linkage.Type]
+	includedTypesMu.RUnlock()
+
+	var value any = UnknownResource{Type: linkage.Type, ID: linkage.ID, Raw: raw}
+	if ok {
+		var err error
+		if value, err = decode(raw); err != nil {
+			return fmt.Errorf("decoding included %s %s: %w", linkage.Type, linkage.ID, err)
+		}
+	}
+
+	if inc.index == nil {
+		inc.index = make(map[RelationshipData]int)
+	}
+	inc.index[RelationshipData{Type: linkage.Type, ID: linkage.ID}] = len(inc.resources)
+	inc.resources = append(inc.resources, IncludedResource{Type: linkage.Type, ID: linkage.ID, Value: value, raw: raw})
+
+	return inc.addLinkage(linkage)
+}
+
+// Indexes relationships of primary data, either a resource object or an array of them
+func (inc *Included) link(data json.RawMessage) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if data[0] != '[' {
+		var linkage resourceLinkage
+		if err := json.Unmarshal(data, &linkage); err != nil {
+			return err
+		}
+		return inc.addLinkage(linkage)
+	}
+
+	var linkages []resourceLinkage
+	if err := json.Unmarshal(data, &linkages); err != nil {
+		return err
+	}
+	for _, linkage := range linkages {
+		if err := inc.addLinkage(linkage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (inc *Included) addLinkage(linkage resourceLinkage) error {
+	for name, relationship := range linkage.Relationships {
+		targets, err := decodeLinkageData(relationship.Data)
+		if err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			continue
+		}
+
+		if inc.linkage == nil {
+			inc.linkage = make(map[RelationshipData]map[string][]RelationshipData)
+		}
+		source := RelationshipData{Type: linkage.Type, ID: linkage.ID}
+		if inc.linkage[source] == nil {
+			inc.linkage[source] = make(map[string][]RelationshipData)
+		}
+		inc.linkage[source][name] = targets
+	}
+
+	return nil
+}
+
+// Decodes resource linkage of a to-one or to-many relationship
+func decodeLinkageData(data json.RawMessage) ([]RelationshipData, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	if data[0] == '[' {
+		var targets []RelationshipData
+		err := json.Unmarshal(data, &targets)
+		return targets, err
+	}
+
+	var target RelationshipData
+	err := json.Unmarshal(data, &target)
+	return []RelationshipData{target}, err
+}
+
+// Decodes linkage of to-one relationships, sent as a single object, as well as of to-many relationships
+func (r *Relationships) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Data  json.RawMessage    `json:"data"`
+		Links *RelationshipLinks `json:"links"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	linkage, err := decodeLinkageData(raw.Data)
+	if err != nil {
+		return err
+	}
+
+	r.Data = linkage
+	r.Links = raw.Links
+	return nil
+}
+
+// Returns type and ID of a model such as Campaign, a pointer to it or RelationshipData
+func identify(resource any) (RelationshipData, error) {
+	if id, ok := resource.(RelationshipData); ok {
+		return id, nil
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(resource))
+	if value.Kind() != reflect.Struct {
+		return RelationshipData{}, errUnidentifiedResource
+	}
+
+	resourceType, id := value.FieldByName("Type"), value.FieldByName("ID")
+	if resourceType.Kind() != reflect.String || id.Kind() != reflect.String {
+		return RelationshipData{}, errUnidentifiedResource
+	}
+
+	return RelationshipData{Type: resourceType.String(), ID: id.String()}, nil
+}
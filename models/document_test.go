@@ -0,0 +1,128 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const campaignDocument = `{
+	"data": {
+		"type": "campaign",
+		"id": "c1",
+		"attributes": {"name": "Spring sale"},
+		"relationships": {
+			"tags": {"data": [{"type": "tag", "id": "t1"}, {"type": "tag", "id": "t2"}, {"type": "tag", "id": "missing"}]},
+			"campaign-messages": {"data": [{"type": "campaign-message", "id": "m1"}]}
+		}
+	},
+	"links": {"self": "https://a.klaviyo.com/api/campaigns/c1/"},
+	"included": [
+		{"type": "tag", "id": "t1", "attributes": {"name": "sale"}},
+		{"type": "campaign-message", "id": "m1", "attributes": {"label": "Email", "channel": "email"},
+		 "relationships": {"template": {"data": {"type": "template", "id": "tpl1"}}}},
+		{"type": "tag", "id": "t2", "attributes": {"name": "spring"}},
+		{"type": "template", "id": "tpl1", "attributes": {"name": "Default"}},
+		{"type": "custom-thing", "id": "x1", "attributes": {}}
+	]
+}`
+
+func TestDocumentRelated(t *testing.T) {
+	var doc CampaignResponse
+	err := json.Unmarshal([]byte(campaignDocument), &doc)
+	assert.Nil(t, err)
+
+	campaign := doc.Data
+	assert.Equal(t, "Spring sale", campaign.Attributes.Name)
+	assert.Equal(t, 5, doc.Included.Len())
+
+	tags := RelatedOf[Tag](doc.Included, campaign, "tags")
+	assert.Len(t, tags, 2)
+	assert.Equal(t, "sale", tags[0].Attributes.Name)
+	assert.Equal(t, "spring", tags[1].Attributes.Name)
+
+	related := doc.Related(&campaign, "campaign-messages")
+	assert.Len(t, related, 1)
+	message, ok := related[0].(CampaignMessage)
+	assert.True(t, ok)
+	assert.Equal(t, "Email", message.Attributes.Label)
+
+	templates := RelatedOf[Template](doc.Included, message, "template")
+	assert.Len(t, templates, 1)
+	assert.Equal(t, "tpl1", templates[0].ID)
+
+	unknown, ok := doc.Included.Get("custom-thing", "x1")
+	assert.True(t, ok)
+	assert.IsType(t, UnknownResource{}, unknown.Value)
+
+	assert.Empty(t, doc.Related(campaign, "unknown"))
+	assert.Empty(t, doc.Related("not a resource", "tags"))
+}
+
+func TestCollectionDocumentRelated(t *testing.T) {
+	body := `{
+		"data": [
+			{"type": "flow", "id": "f1", "attributes": {}, "relationships": {"tags": {"data": [{"type": "tag", "id": "t1"}]}}},
+			{"type": "flow", "id": "f2", "attributes": {}, "relationships": {"tags": {"data": []}}}
+		],
+		"links": {"next": "https://a.klaviyo.com/api/flows/?page[cursor]=abc"},
+		"included": [{"type": "tag", "id": "t1", "attributes": {"name": "welcome"}}]
+	}`
+
+	var doc FlowCollectionResource
+	err := json.Unmarshal([]byte(body), &doc)
+	assert.Nil(t, err)
+
+	assert.Len(t, doc.Data, 2)
+	assert.Equal(t, "https://a.klaviyo.com/api/flows/?page[cursor]=abc", *doc.Links.Next)
+	assert.Equal(t, "welcome", RelatedOf[Tag](doc.Included, doc.Data[0], "tags")[0].Attributes.Name)
+	assert.Empty(t, doc.Related(doc.Data[1], "tags"))
+}
+
+func TestIncludedRoundTrip(t *testing.T) {
+	tag := Tag{Type: "tag", ID: "t1", Attributes: TagAttributes{Name: "sale"}}
+	included, err := NewIncluded(tag)
+	assert.Nil(t, err)
+
+	doc := TagsCollectionResponse{Data: []Tag{tag}, Included: included}
+	byteData, err := json.Marshal(doc)
+	assert.Nil(t, err)
+
+	var decoded TagsCollectionResponse
+	err = json.Unmarshal(byteData, &decoded)
+	assert.Nil(t, err)
+
+	resource, ok := decoded.Included.Get("tag", "t1")
+	assert.True(t, ok)
+	assert.Equal(t, tag.Attributes, resource.Value.(Tag).Attributes)
+}
+
+func TestRegisterIncludedType(t *testing.T) {
+	type segment struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	}
+	RegisterIncludedType[segment]("segment-test")
+
+	var included Included
+	err := json.Unmarshal([]byte(`[{"type": "segment-test", "id": "s1"}]`), &included)
+	assert.Nil(t, err)
+
+	resource, _ := included.Get("segment-test", "s1")
+	assert.Equal(t, segment{Type: "segment-test", ID: "s1"}, resource.Value)
+}
+
+func TestRelationshipsToOneLinkage(t *testing.T) {
+	var variant CatalogVariant
+	err := json.Unmarshal([]byte(`{"type": "catalog-variant", "id": "v1", "relationships": {
+		"item": {"data": {"type": "catalog-item", "id": "i1"}, "links": {"self": "s", "related": "r"}}
+	}}`), &variant)
+	assert.Nil(t, err)
+	assert.Equal(t, []RelationshipData{{Type: "catalog-item", ID: "i1"}}, variant.Relationships.Item.Data)
+	assert.Equal(t, "r", variant.Relationships.Item.Links.Related)
+
+	err = json.Unmarshal([]byte(`{"item": {"data": null}}`), &variant.Relationships)
+	assert.Nil(t, err)
+	assert.Empty(t, variant.Relationships.Item.Data)
+}
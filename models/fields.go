@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// Field type of a resource, eg. CampaignsField. ResourceType returns the JSON:API type of the resource, eg. campaign
+	ResourceType interface {
+		~string
+		ResourceType() string
+	}
+
+	// Sparse fieldset of the resource of R, eg. Fields[CampaignsField]{CampaignsFieldName}.
+	// Nil and empty fieldsets both request all fields
+	Fields[R ResourceType] []R
+
+	// Sort keys of a list, eg. SortBy(CampaignSortFieldCreatedAtDesc).Asc(CampaignSortFieldNameAsc).
+	// A key prefixed with `-` sorts in descending order
+	Sort[F ~string] []F
+)
+
+// Returns fieldset of `fields`
+func FieldsOf[R ResourceType](fields ...R) Fields[R] {
+	return Fields[R](fields)
+}
+
+// JSON:API type of the resource
+func (f Fields[R]) ResourceType() string {
+	var field R
+	return field.ResourceType()
+}
+
+// Fields as strings
+func (f Fields[R]) Strings() []string {
+	strs := make([]string, 0, len(f))
+	for _, field := range f {
+		strs = append(strs, string(field))
+	}
+	return strs
+}
+
+// Query parameter of the fieldset, eg. fields[campaign] and name,status. Value is empty when fieldset is empty
+func (f Fields[R]) QueryParam() (string, string) {
+	return fmt.Sprintf("fields[%s]", f.ResourceType()), strings.Join(f.Strings(), ",")
+}
+
+// Returns sort by `keys`, in order
+func SortBy[F ~string](keys ...F) Sort[F] {
+	return Sort[F](keys)
+}
+
+// Appends ascending key `field`
+func (s Sort[F]) Asc(field F) Sort[F] {
+	return append(s, F(strings.TrimPrefix(string(field), "-")))
+}
+
+// Appends descending key `field`
+func (s Sort[F]) Desc(field F) Sort[F] {
+	return append(s, F("-"+strings.TrimPrefix(string(field), "-")))
+}
+
+// Sort as sent in the `sort` query parameter, eg. -created_at,name
+func (s Sort[F]) String() string {
+	keys := make([]string, 0, len(s))
+	for _, key := range s {
+		keys = append(keys, string(key))
+	}
+	return strings.Join(keys, ",")
+}
+
+// Query parameter of the sort. Value is empty when sort has no keys
+func (s Sort[F]) QueryParam() (string, string) {
+	return "sort", s.String()
+}
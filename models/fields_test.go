@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldsQueryParam(t *testing.T) {
+	key, value := FieldsOf(CampaignsFieldName, CampaignsFieldStatus).QueryParam()
+
+	assert.Equal(t, "fields[campaign]", key)
+	assert.Equal(t, "name,status", value)
+}
+
+func TestEmptyFieldsQueryParam(t *testing.T) {
+	var nilFields Fields[TagField]
+	key, value := nilFields.QueryParam()
+
+	assert.Equal(t, "fields[tag]", key)
+	assert.Equal(t, "", value)
+
+	_, value = Fields[TagField]{}.QueryParam()
+	assert.Equal(t, "", value)
+}
+
+func TestFieldsResourceTypes(t *testing.T) {
+	assert.Equal(t, "account", Fields[AccountsField]{}.ResourceType())
+	assert.Equal(t, "campaign-message", Fields[CampaignMessageField]{}.ResourceType())
+	assert.Equal(t, "campaign-send-job", Fields[CampaignSendJobField]{}.ResourceType())
+	assert.Equal(t, "catalog-variant", Fields[CatalogVariantField]{}.ResourceType())
+	assert.Equal(t, "flow-action", Fields[FlowActionField]{}.ResourceType())
+	assert.Equal(t, "image", Fields[ImageField]{}.ResourceType())
+	assert.Equal(t, "template", Fields[TemplateField]{}.ResourceType())
+}
+
+func TestSort(t *testing.T) {
+	sort := SortBy(CampaignSortFieldCreatedAtDesc).
+		Asc(CampaignSortFieldNameDesc).
+		Desc(CampaignSortFieldIdAsc).
+		Desc(CampaignSortFieldUpdatedAtDesc)
+
+	key, value := sort.QueryParam()
+
+	assert.Equal(t, "sort", key)
+	assert.Equal(t, "-created_at,name,-id,-updated_at", value)
+}
+
+func TestEmptySort(t *testing.T) {
+	var sort Sort[ImageSortField]
+
+	_, value := sort.QueryParam()
+
+	assert.Equal(t, "", value)
+}
@@ -2,25 +2,15 @@ package models
 
 import (
 	"encoding/json"
-	"fmt"
-	"strings"
 	"time"
 )
 
 // ---- Flow
 
 type (
-	FlowCollectionResource struct {
-		Data  []Flow `json:"data"`
-		Links Links  `json:"links"`
-		// Array of type Tag | FlowAction. FlowIncludesUnionType exports isTag and isFlowAction methods
-		Included []FlowIncludesUnionType `json:"included,omitempty"`
-	}
+	FlowCollectionResource = CollectionDocument[Flow] //Included can hold Tag and FlowAction
 
-	FlowResource struct {
-		Data     Flow                    `json:"data"`
-		Included []FlowIncludesUnionType `json:"included,omitempty"`
-	}
+	FlowResource = Document[Flow] //Included can hold Tag and FlowAction
 
 	Flow struct {
 		Type          string             `json:"type"` //flow
@@ -68,6 +58,7 @@ const (
 
 // ---- FlowIncludesUnionType
 
+// Deprecated: included resources are decoded by Included, see Document.Related
 type FlowIncludesUnionType map[string]any
 
 // Return Tag type and True if FlowIncludesUnionType is a Tag
@@ -106,17 +97,9 @@ func (fi FlowIncludesUnionType) IsFlowAction() (*FlowAction, bool) {
 // ---- FlowAction
 
 type (
-	FlowActionResource struct {
-		Data  FlowAction `json:"data"`
-		Links DataLinks  `json:"links"`
-		// Array of type Flow | FlowMessage. FlowIncludesUnionType exports IsFlow and IsFlowMessage methods
-		Includes []FlowActionIncludesUnionType `json:"includes"`
-	}
+	FlowActionResource = Document[FlowAction] //Included can hold Flow and FlowMessage
 
-	FlowActionCollectionResource struct {
-		Data  []FlowAction `json:"data"`
-		Links Links        `json:"links"`
-	}
+	FlowActionCollectionResource = CollectionDocument[FlowAction]
 
 	FlowAction struct {
 		Type          string                   `json:"type"` //flow-action
@@ -139,7 +122,7 @@ type (
 
 	FlowActionRelationships struct {
 		Flow        Relationships `json:"flow"`
-		FlowMessage Relationships `json:"flow-message"`
+		FlowMessage Relationships `json:"flow-messages"`
 	}
 
 	FlowActionSettings interface{}
@@ -147,6 +130,7 @@ type (
 
 // ---- FlowActionIncludesUnionType
 
+// Deprecated: included resources are decoded by Included, see Document.Related
 type FlowActionIncludesUnionType map[string]any
 
 func (actionIncludes FlowActionIncludesUnionType) IsFlow() (*Flow, bool) {
@@ -184,15 +168,9 @@ func (actionIncludes FlowActionIncludesUnionType) IsFlowMessage() (*FlowMessage,
 // ---- FlowMessage
 
 type (
-	FlowMessageResource struct {
-		Data     FlowMessage                    `json:"data"`
-		Included []FlowMessageIncludedUnionType `json:"includes,omitempty"`
-	}
+	FlowMessageResource = Document[FlowMessage] //Included can hold FlowAction and Template
 
-	FlowActionMessageCollectionResource struct {
-		Data  []FlowMessage `json:"data"`
-		Links Links         `json:"links"`
-	}
+	FlowActionMessageCollectionResource = CollectionDocument[FlowMessage]
 
 	FlowMessage struct {
 		Type          string                   `json:"type"` //flow-message
@@ -209,7 +187,7 @@ type (
 
 	FlowMessageAttributes struct {
 		Name      string         `json:"name"`
-		Channel   string         `json:"channel"`
+		Channel   Channel        `json:"channel"`
 		Content   MessageContent `json:"content"`
 		CreatedAt *time.Time     `json:"created_at,omitempty"`
 		UpdatedAt *time.Time     `json:"updated_at,omitempty"`
@@ -218,6 +196,7 @@ type (
 
 // ---- FlowMessageIncludeUnionType
 
+// Deprecated: included resources are decoded by Included, see Document.Related
 type FlowMessageIncludedUnionType map[string]any
 
 // Returns pointer to FlowAction and true if instance is FlowActon
@@ -267,17 +246,8 @@ const (
 	FlowFieldTriggerType FlowField = "trigger_type"
 )
 
-func BuildFlowFieldsParam(fields []FlowField) string {
-	if len(fields) == 0 {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[flow]=%s", strings.Join(formattedFields, ","))
+func (FlowField) ResourceType() string {
+	return "flow"
 }
 
 // ---- FlowActionField
@@ -300,17 +270,8 @@ const (
 	FlowActionFieldRenderOptions_AddOptOutLanguage FlowActionField = "render_options.add_opt_out_language"
 )
 
-func BuildFlowActionFieldsParam(fields []FlowActionField) string {
-	if len(fields) == 0 {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[flow-action]=%s", strings.Join(formattedFields, ","))
+func (FlowActionField) ResourceType() string {
+	return "flow-action"
 }
 
 // ---- FlowMessageField
@@ -324,17 +285,8 @@ const (
 	FlowMessageFieldUpdated FlowMessageField = "updated"
 )
 
-func BuildFlowMessageFieldsParam(fields []FlowMessageField) string {
-	if len(fields) == 0 {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[flow-message]=%s", strings.Join(formattedFields, ","))
+func (FlowMessageField) ResourceType() string {
+	return "flow-message"
 }
 
 // FlowTags
@@ -1,22 +1,15 @@
 package models
 
 import (
-	"fmt"
-	"strings"
 	"time"
 
 	"github.com/jaswdr/faker/v2"
 )
 
 type (
-	ImageResponse struct {
-		Data Image `json:"data"`
-	}
+	ImageResponse = Document[Image]
 
-	ImageCollectionResponse struct {
-		Data  []Image `json:"data"`
-		Links Links   `json:"links"`
-	}
+	ImageCollectionResponse = CollectionDocument[Image]
 
 	Image struct {
 		Type       string          `json:"type"` //image
@@ -48,18 +41,8 @@ const (
 	ImageFieldUpdatedAt ImageField = "updated_at"
 )
 
-func BuildImageFieldParam(fields []ImageField) string {
-	if fields == nil {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[images]=%s", strings.Join(formattedFields, ","))
-
+func (ImageField) ResourceType() string {
+	return "image"
 }
 
 // ---- ImageSortField
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"github.com/developertom01/klaviyo-go/exceptions"
+)
+
+type (
+	// Asynchronous job, eg. CatalogItemBulkJob or CampaignSendJob
+	Job interface {
+		Progress() JobProgress
+	}
+
+	// State of an asynchronous job
+	JobProgress struct {
+		Type      string
+		ID        string
+		Status    string
+		Total     int64                 //Operations processed by the job, 0 when the job does not report counts
+		Completed int64                 //Operations completed
+		Failed    int64                 //Operations that failed
+		Errors    []exceptions.ApiError //Errors of the failed operations
+		Done      bool                  //Whether the job reached a terminal status
+		Cancelled bool                  //Whether the job was cancelled
+	}
+)
+
+func (job CatalogItemBulkJob) Progress() JobProgress {
+	progress := JobProgress{
+		Type:      job.Type,
+		ID:        job.ID,
+		Status:    string(job.Attributes.Status),
+		Total:     job.Attributes.TotalCount,
+		Errors:    job.Attributes.Errors,
+		Cancelled: job.Attributes.Status == CatalogItemBulkJobStatusCancelled,
+	}
+	if job.Attributes.CompletedCount != nil {
+		progress.Completed = *job.Attributes.CompletedCount
+	}
+	if job.Attributes.FailedCount != nil {
+		progress.Failed = *job.Attributes.FailedCount
+	}
+	progress.Done = progress.Cancelled || job.Attributes.Status == CatalogItemBulkJobStatusComplete
+
+	return progress
+}
+
+func (job CampaignSendJob) Progress() JobProgress {
+	cancelled := job.Attributes.Status == CampaignSendJobStatusCancelled
+
+	return JobProgress{
+		Type:      job.Type,
+		ID:        job.ID,
+		Status:    string(job.Attributes.Status),
+		Cancelled: cancelled,
+		Done:      cancelled || job.Attributes.Status == CampaignSendJobStatusCompleted,
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCampaignSendJobProgress(t *testing.T) {
+	tests := []struct {
+		status    string
+		done      bool
+		cancelled bool
+	}{
+		{"queued", false, false},
+		{"processing", false, false},
+		{"complete", true, false},
+		{"cancelled", true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.status, func(t *testing.T) {
+			var job CampaignSendJobResponse
+			data := `{"data": {"type": "campaign-send-job", "id": "campaign-id", "attributes": {"status": "` + test.status + `"}}}`
+			require.NoError(t, json.Unmarshal([]byte(data), &job))
+
+			progress := job.Data.Progress()
+			assert.Equal(t, test.done, progress.Done)
+			assert.Equal(t, test.cancelled, progress.Cancelled)
+		})
+	}
+
+	assert.Equal(t, CampaignSendJobStatus("complete"), CampaignSendJobStatusCompleted)
+	assert.Equal(t, CampaignSendJobStatus("cancelled"), CampaignSendJobStatusCancelled)
+}
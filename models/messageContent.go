@@ -0,0 +1,187 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrContentChannelMismatch = errors.New("Message content does not match its channel")
+
+// Channel a message is sent on
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelSms   Channel = "sms"
+	ChannelPush  Channel = "push"
+)
+
+type (
+	// Content of a message, keyed off its channel: EmailContent, SMSContent, or RawMessageContent for a channel
+	// without typed content, eg. push. Use a type switch to read it
+	MessageContent interface {
+		Channel() Channel
+		isMessageContent()
+	}
+
+	EmailContent struct {
+		Subject      *string `json:"subject,omitempty"`        //The subject of the message
+		PreviewText  *string `json:"preview_text,omitempty"`   //Preview text associated with the message
+		FromEmail    *string `json:"from_email,omitempty"`     //The email the message should be sent from
+		FromLabel    *string `json:"from_label,omitempty"`     //The label associated with the from_email
+		ReplyToEmail *string `json:"reply_to_email,omitempty"` //Optional Reply-To email address
+		CcEmail      *string `json:"cc_email,omitempty"`       //Optional CC email address
+		BccEmail     *string `json:"bcc_email,omitempty"`      //Optional BCC email address
+	}
+
+	SMSContent struct {
+		Body     *string `json:"body,omitempty"`      //The message body
+		MediaUrl *string `json:"media_url,omitempty"` //URL for included media
+	}
+
+	// Content of a channel without typed content, kept as sent by the API
+	RawMessageContent struct {
+		MessageChannel Channel
+		Data           json.RawMessage
+	}
+)
+
+func (EmailContent) Channel() Channel {
+	return ChannelEmail
+}
+
+func (SMSContent) Channel() Channel {
+	return ChannelSms
+}
+
+func (c RawMessageContent) Channel() Channel {
+	return c.MessageChannel
+}
+
+func (EmailContent) isMessageContent()      {}
+func (SMSContent) isMessageContent()        {}
+func (RawMessageContent) isMessageContent() {}
+
+func (c RawMessageContent) MarshalJSON() ([]byte, error) {
+	if len(c.Data) == 0 {
+		return []byte("null"), nil
+	}
+	return c.Data, nil
+}
+
+// Returns ErrContentChannelMismatch when `content` is not content of `channel`. Nil content and an empty channel are not checked
+func ValidateMessageContent(channel Channel, content MessageContent) error {
+	if content == nil || channel == "" || content.Channel() == channel {
+		return nil
+	}
+	return fmt.Errorf("%w: %s content on a %s message", ErrContentChannelMismatch, content.Channel(), channel)
+}
+
+// Keys only found in the content of one channel, used when the channel is not known, eg. left out by sparse fieldsets
+var (
+	emailContentKeys = []string{"subject", "preview_text", "from_email", "from_label", "reply_to_email", "cc_email", "bcc_email"}
+	smsContentKeys   = []string{"body", "media_url"}
+)
+
+// Decodes content `data` of a message on `channel`. Returns nil for null or missing content.
+// When `channel` is empty, it is guessed from the keys of the content
+func UnmarshalMessageContent(channel Channel, data []byte) (MessageContent, error) {
+	if len(bytes.TrimSpace(data)) == 0 || bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil, nil
+	}
+	if channel == "" {
+		channel = guessMessageChannel(data)
+	}
+
+	var (
+		content MessageContent
+		err     error
+	)
+	switch channel {
+	case ChannelEmail:
+		var email EmailContent
+		err = json.Unmarshal(data, &email)
+		content = email
+	case ChannelSms:
+		var sms SMSContent
+		err = json.Unmarshal(data, &sms)
+		content = sms
+	default:
+		if !json.Valid(data) {
+			return nil, fmt.Errorf("klaviyo: %s message content is not valid JSON", channel)
+		}
+		content = RawMessageContent{MessageChannel: channel, Data: append(json.RawMessage(nil), data...)}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("klaviyo: decoding %s message content: %w", channel, err)
+	}
+
+	return content, nil
+}
+
+// Returns the channel whose keys content `data` holds, empty when there are none or keys of both
+func guessMessageChannel(data []byte) Channel {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return ""
+	}
+
+	has := func(names []string) bool {
+		for _, name := range names {
+			if _, ok := keys[name]; ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch email, sms := has(emailContentKeys), has(smsContentKeys); {
+	case email && !sms:
+		return ChannelEmail
+	case sms && !email:
+		return ChannelSms
+	}
+	return ""
+}
+
+func (a *CampaignMessageAttributes) UnmarshalJSON(data []byte) error {
+	type attributes CampaignMessageAttributes
+	var raw struct {
+		attributes
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	content, err := UnmarshalMessageContent(raw.Channel, raw.Content)
+	if err != nil {
+		return err
+	}
+
+	*a = CampaignMessageAttributes(raw.attributes)
+	a.Content = content
+	return nil
+}
+
+func (a *FlowMessageAttributes) UnmarshalJSON(data []byte) error {
+	type attributes FlowMessageAttributes
+	var raw struct {
+		attributes
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	content, err := UnmarshalMessageContent(raw.Channel, raw.Content)
+	if err != nil {
+		return err
+	}
+
+	*a = FlowMessageAttributes(raw.attributes)
+	a.Content = content
+	return nil
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalCampaignMessageContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected MessageContent
+	}{
+		{
+			"email",
+			`{"channel": "email", "label": "Welcome", "content": {"subject": "Hi", "bcc_email": "archive@example.com"}}`,
+			EmailContent{Subject: ptr("Hi"), BccEmail: ptr("archive@example.com")},
+		},
+		{
+			"sms",
+			`{"channel": "sms", "content": {"body": "Hi", "media_url": "https://example.com/a.png"}}`,
+			SMSContent{Body: ptr("Hi"), MediaUrl: ptr("https://example.com/a.png")},
+		},
+		{
+			"push is kept raw",
+			`{"channel": "push", "content": {"title": "Hi"}}`,
+			RawMessageContent{MessageChannel: ChannelPush, Data: json.RawMessage(`{"title": "Hi"}`)},
+		},
+		{
+			"channel guessed from the keys",
+			`{"content": {"body": "Hi"}}`,
+			SMSContent{Body: ptr("Hi")},
+		},
+		{"null", `{"channel": "email", "content": null}`, nil},
+		{"missing", `{"channel": "email"}`, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var attributes CampaignMessageAttributes
+			require.NoError(t, json.Unmarshal([]byte(test.data), &attributes))
+
+			assert.Equal(t, test.expected, attributes.Content)
+		})
+	}
+}
+
+func TestUnmarshalMessageContentRejectsMismatchedContent(t *testing.T) {
+	var attributes FlowMessageAttributes
+	err := json.Unmarshal([]byte(`{"channel": "sms", "content": {"body": 5}}`), &attributes)
+	assert.Error(t, err)
+}
+
+func TestMarshalCampaignMessageContent(t *testing.T) {
+	attributes := CampaignMessageAttributes{Channel: ChannelSms, Content: SMSContent{Body: ptr("Hi")}}
+
+	data, err := json.Marshal(attributes)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, map[string]any{"body": "Hi"}, decoded["content"])
+
+	var roundTrip CampaignMessageAttributes
+	require.NoError(t, json.Unmarshal(data, &roundTrip))
+	assert.Equal(t, attributes.Content, roundTrip.Content)
+
+	data, err = json.Marshal(RawMessageContent{MessageChannel: ChannelPush, Data: json.RawMessage(`{"title":"Hi"}`)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title": "Hi"}`, string(data))
+}
+
+func TestValidateMessageContent(t *testing.T) {
+	assert.NoError(t, ValidateMessageContent(ChannelEmail, EmailContent{}))
+	assert.NoError(t, ValidateMessageContent(ChannelSms, nil))
+	assert.NoError(t, ValidateMessageContent(ChannelPush, RawMessageContent{MessageChannel: ChannelPush}))
+	assert.ErrorIs(t, ValidateMessageContent(ChannelEmail, SMSContent{}), ErrContentChannelMismatch)
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
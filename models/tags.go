@@ -1,15 +1,7 @@
 package models
 
-import (
-	"fmt"
-	"strings"
-)
-
 type (
-	TagsCollectionResponse struct {
-		Data  []Tag `json:"data"`
-		Links Links `json:"links"`
-	}
+	TagsCollectionResponse = CollectionDocument[Tag]
 
 	Tag struct {
 		Type          string         `json:"type"` //tag
@@ -38,14 +30,6 @@ const (
 	TagFieldName TagField = "name"
 )
 
-func BuildTagFieldParam(fields []TagField) string {
-	if len(fields) == 0 {
-		return ""
-	}
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[tag]=%s", strings.Join(formattedFields, ","))
+func (TagField) ResourceType() string {
+	return "tag"
 }
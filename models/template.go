@@ -1,20 +1,13 @@
 package models
 
 import (
-	"fmt"
-	"strings"
 	"time"
 )
 
 type (
-	TemplateCollectionResponse struct {
-		Data  []Template `json:"data"`
-		Links Links      `json:"links"`
-	}
+	TemplateCollectionResponse = CollectionDocument[Template]
 
-	TemplateResponse struct {
-		Data Template `json:"data"`
-	}
+	TemplateResponse = Document[Template]
 
 	Template struct {
 		Type       string             `json:"type"` //template
@@ -63,15 +56,6 @@ const (
 	TemplateFieldUpdatedAt  TemplateField = "updated_at"
 )
 
-func BuildTemplateFieldParam(fields []TemplateField) string {
-	if len(fields) == 0 {
-		return ""
-	}
-
-	var formattedFields []string
-	for _, field := range fields {
-		formattedFields = append(formattedFields, string(field))
-	}
-
-	return fmt.Sprintf("fields[template]=%v", strings.Join(formattedFields, ","))
+func (TemplateField) ResourceType() string {
+	return "template"
 }
@@ -1,9 +1,23 @@
 package options
 
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/developertom01/klaviyo-go/instrumentation"
+)
+
 // Latest revision date
 const DEFAULT_REVISION string = "2024-02-15"
 
 type (
+	// HTTPClient interface for making HTTP requests
+	HTTPClient interface {
+		Do(req *http.Request) (*http.Response, error)
+	}
+
 	Options interface {
 		//Set Klaviyo API revision date
 		WithRevision(revision string) Options
@@ -14,6 +28,27 @@ type (
 		//Set Company ID - Needed when calling client APIs
 		WithCompanyId(companyId string) Options
 
+		//Set logger used for SDK diagnostics. Logging is disabled when not set
+		WithLogger(logger *slog.Logger) Options
+
+		//Set instrumentation notified about every operation and HTTP attempt. See the otelinstrumentation package for OpenTelemetry support
+		WithInstrumentation(instr instrumentation.Instrumentation) Options
+
+		//Set base URL of the Klaviyo API, eg. a local stand-in server. Defaults to https://a.klaviyo.com
+		WithBaseUrl(baseUrl string) Options
+
+		//Set User-Agent header sent with every request
+		WithUserAgent(userAgent string) Options
+
+		//Set timeout of a call including its retries. No timeout when zero
+		WithTimeout(timeout time.Duration) Options
+
+		//Set HTTP client used when none is passed to an API constructor
+		WithHTTPClient(httpClient HTTPClient) Options
+
+		//Enable or disable client-side validation of list filters against the fields and operators each endpoint allows. Enabled by default
+		WithFilterValidation(enabled bool) Options
+
 		//Returns revision
 		Revision() string
 
@@ -22,12 +57,44 @@ type (
 
 		//Returns company ID
 		CompanyId() *string
+
+		//Returns logger. Never nil, a logger that discards all records is returned when none is set
+		Logger() *slog.Logger
+
+		//Returns instrumentation. Never nil, a no-op instrumentation is returned when none is set
+		Instrumentation() instrumentation.Instrumentation
+
+		//Returns base URL. Empty when not set
+		BaseUrl() string
+
+		//Returns User-Agent. Empty when not set
+		UserAgent() string
+
+		//Returns timeout. Zero when not set
+		Timeout() time.Duration
+
+		//Returns HTTP client. Nil when not set
+		HTTPClient() HTTPClient
+
+		//Returns whether list filters are validated before sending
+		FilterValidation() bool
+
+		//Returns a copy not affected by later With* calls on these options
+		Clone() Options
 	}
 
 	options struct {
 		revision  string
 		apiKey    *string
 		companyId *string
+		logger    *slog.Logger
+		instr     instrumentation.Instrumentation
+		baseUrl   string
+		userAgent string
+		timeout   time.Duration
+		client    HTTPClient
+
+		skipFilterValidation bool
 	}
 )
 
@@ -67,3 +134,114 @@ func (opt *options) ApiKey() *string {
 func (opt *options) CompanyId() *string {
 	return opt.companyId
 }
+
+func (opt *options) WithLogger(logger *slog.Logger) Options {
+	opt.logger = logger
+	return opt
+}
+
+func (opt *options) Logger() *slog.Logger {
+	if opt.logger == nil {
+		return discardLogger
+	}
+	return opt.logger
+}
+
+func (opt *options) WithInstrumentation(instr instrumentation.Instrumentation) Options {
+	opt.instr = instr
+	return opt
+}
+
+func (opt *options) Instrumentation() instrumentation.Instrumentation {
+	if opt.instr == nil {
+		return noopInstrumentation
+	}
+	return opt.instr
+}
+
+func (opt *options) WithBaseUrl(baseUrl string) Options {
+	opt.baseUrl = baseUrl
+	return opt
+}
+
+func (opt *options) BaseUrl() string {
+	return opt.baseUrl
+}
+
+func (opt *options) WithUserAgent(userAgent string) Options {
+	opt.userAgent = userAgent
+	return opt
+}
+
+func (opt *options) UserAgent() string {
+	return opt.userAgent
+}
+
+func (opt *options) WithTimeout(timeout time.Duration) Options {
+	opt.timeout = timeout
+	return opt
+}
+
+func (opt *options) Timeout() time.Duration {
+	return opt.timeout
+}
+
+func (opt *options) WithHTTPClient(httpClient HTTPClient) Options {
+	opt.client = httpClient
+	return opt
+}
+
+func (opt *options) HTTPClient() HTTPClient {
+	return opt.client
+}
+
+func (opt *options) WithFilterValidation(enabled bool) Options {
+	opt.skipFilterValidation = !enabled
+	return opt
+}
+
+func (opt *options) FilterValidation() bool {
+	return !opt.skipFilterValidation
+}
+
+func (opt *options) Clone() Options {
+	clone := *opt
+	return &clone
+}
+
+// Redacts API key when options are logged
+func (opt *options) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.String("revision", opt.revision)}
+	if opt.apiKey != nil {
+		attrs = append(attrs, slog.String("api_key", RedactedValue))
+	}
+	if opt.companyId != nil {
+		attrs = append(attrs, slog.String("company_id", *opt.companyId))
+	}
+	if opt.baseUrl != "" {
+		attrs = append(attrs, slog.String("base_url", opt.baseUrl))
+	}
+	if opt.userAgent != "" {
+		attrs = append(attrs, slog.String("user_agent", opt.userAgent))
+	}
+	if opt.timeout > 0 {
+		attrs = append(attrs, slog.Duration("timeout", opt.timeout))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// Value logged in place of secrets
+const RedactedValue = "[REDACTED]"
+
+var discardLogger = slog.New(discardHandler{})
+
+var noopInstrumentation = instrumentation.NewNoopInstrumentation()
+
+// slog.Handler that drops every record
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
@@ -1,7 +1,12 @@
 package options
 
 import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -38,3 +43,43 @@ func TestWithCompanyIdAndCompanyId(t *testing.T) {
 	options.WithCompanyId(companyId)
 	assert.Equal(t, companyId, *options.CompanyId())
 }
+
+func TestLoggerDefaultsToDiscard(t *testing.T) {
+	options := NewOptions()
+	assert.NotNil(t, options.Logger())
+	assert.False(t, options.Logger().Enabled(context.Background(), slog.LevelError))
+}
+
+func TestLogValueRedactsApiKey(t *testing.T) {
+	var output bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&output, nil))
+
+	options := NewOptionsWithDefaultValues().WithApiKey(testApiKey)
+	logger.Info("options", "options", options)
+
+	assert.NotContains(t, output.String(), testApiKey)
+	assert.Contains(t, output.String(), RedactedValue)
+}
+
+func TestTransportOptions(t *testing.T) {
+	options := NewOptions().
+		WithBaseUrl("http://localhost:8080").
+		WithUserAgent("my-app/1.0").
+		WithTimeout(time.Second).
+		WithHTTPClient(http.DefaultClient)
+
+	assert.Equal(t, "http://localhost:8080", options.BaseUrl())
+	assert.Equal(t, "my-app/1.0", options.UserAgent())
+	assert.Equal(t, time.Second, options.Timeout())
+	assert.Equal(t, http.DefaultClient, options.HTTPClient())
+}
+
+func TestClone(t *testing.T) {
+	options := NewOptionsWithDefaultValues().WithApiKey(testApiKey)
+	clone := options.Clone()
+
+	options.WithApiKey("other_key").WithRevision("2023-02-15")
+
+	assert.Equal(t, testApiKey, *clone.ApiKey())
+	assert.Equal(t, DEFAULT_REVISION, clone.Revision())
+}